@@ -76,6 +76,15 @@ type Diagnostic struct {
 	// developer, Terraform should render the root block (provider, resource,
 	// datasource) in cases where the attribute path is invalid.
 	AttributePath cty.Path
+
+	// Code is an optional stable identifier of the problem, such as
+	// "invalid_string_length", which allows programmatic consumers to
+	// distinguish diagnostics without matching the Summary or Detail text.
+	//
+	// PLEASE NOTE: The protocol does not currently support diagnostic codes,
+	// so the Code is available to Go callers of the SDK, such as provider
+	// unit tests, but is not sent to Terraform.
+	Code string
 }
 
 // Validate ensures a valid Severity and a non-empty Summary are set.
@@ -3,7 +3,10 @@
 
 package diag
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // FromErr will convert an error into a Diagnostics. This returns Diagnostics
 // as the most common use case in Go will be handling a single error
@@ -20,6 +23,7 @@ func FromErr(err error) Diagnostics {
 		Diagnostic{
 			Severity: Error,
 			Summary:  err.Error(),
+			Code:     ErrorCode(err),
 		},
 	}
 }
@@ -40,3 +44,91 @@ func Errorf(format string, a ...interface{}) Diagnostics {
 		},
 	}
 }
+
+// NewWarning creates a Warning level Diagnostic with the given summary and
+// detail. Warnings are surfaced to practitioners without halting the
+// operation, so they can be returned alongside a successful result. The
+// constructor is not named Warning as that identifier is the Severity.
+//
+//	diags = append(diags, diag.NewWarning(
+//	  "Deprecated instance type",
+//	  "Instance type m1.small will be retired, consider m5.large.",
+//	))
+func NewWarning(summary, detail string) Diagnostic {
+	return Diagnostic{
+		Severity: Warning,
+		Summary:  summary,
+		Detail:   detail,
+	}
+}
+
+// AppendWarningf appends a single Warning level Diagnostic entry to the
+// Diagnostics. The summary is populated by performing a fmt.Sprintf with the
+// supplied values.
+//
+//	var diags diag.Diagnostics
+//	diags.AppendWarningf("tag %q is managed outside of Terraform", key)
+//	return diags
+func (diags *Diagnostics) AppendWarningf(format string, a ...interface{}) {
+	*diags = append(*diags, Diagnostic{
+		Severity: Warning,
+		Summary:  fmt.Sprintf(format, a...),
+	})
+}
+
+// WithCode creates an Error level Diagnostic with the given stable code,
+// summary and detail. The code allows programmatic consumers to identify
+// the problem without matching the summary text.
+//
+//	diags = append(diags, diag.WithCode(
+//	  "instance_type_unavailable",
+//	  "Instance type unavailable",
+//	  "Instance type m1.small is not offered in this region.",
+//	))
+func WithCode(code, summary, detail string) Diagnostic {
+	return Diagnostic{
+		Severity: Error,
+		Summary:  summary,
+		Detail:   detail,
+		Code:     code,
+	}
+}
+
+// ErrorWithCode wraps the error with a stable code. FromErr, and the SDK when
+// converting the errors returned by a schema.SchemaValidateFunc, copy the code
+// into the Code of the resulting Diagnostic.
+//
+//	return nil, []error{diag.ErrorWithCode("invalid_name", err)}
+func ErrorWithCode(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &codedError{code: code, err: err}
+}
+
+// ErrorCode returns the code of the first error in the chain given a code by
+// ErrorWithCode, or an empty string if there is none.
+func ErrorCode(err error) string {
+	var coded *codedError
+
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+
+	return ""
+}
+
+// codedError is an error with a stable code.
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acctest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math/big"
+	"math/rand"
+	"net/netip"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-testing-interface"
+)
+
+// EnvRandSeed is the environment variable which, if set, seeds the random
+// values returned by the package level functions, such as RandomWithPrefix,
+// and by each Rand returned from NewRandForTest. Setting it to the seed
+// logged by a failed test run reproduces that run's values.
+const EnvRandSeed = "TF_ACC_RAND_SEED"
+
+// baseSeed is the process wide seed, from EnvRandSeed if set.
+var baseSeed = newBaseSeed()
+
+// defaultRand is used by the package level functions. Its seed is logged so
+// a failing run can be reproduced with EnvRandSeed, although the values each
+// test receives also depend on the order in which tests draw from it, which
+// varies when tests run in parallel. Use NewRandForTest in that case.
+var defaultRand = NewRand(baseSeed)
+
+func newBaseSeed() int64 {
+	seed := time.Now().UnixNano()
+
+	if v := os.Getenv(EnvRandSeed); v != "" {
+		s, err := strconv.ParseInt(v, 10, 64)
+
+		if err != nil {
+			log.Printf("[WARN] Ignoring invalid %s value %q: %s", EnvRandSeed, v, err)
+		} else {
+			seed = s
+		}
+	}
+
+	log.Printf("[INFO] Random seed for acceptance testing values: %d (set %s to reproduce)", seed, EnvRandSeed)
+
+	return seed
+}
+
+// NewRandForTest returns a Rand for the given test, seeded from the process
+// wide seed and a hash of the test name. Unlike the package level functions,
+// the values it generates do not depend on what other tests run alongside it,
+// so a test run with ParallelTest is reproduced by setting EnvRandSeed to the
+// logged base seed.
+func NewRandForTest(t testing.T) *Rand {
+	t.Helper()
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(t.Name()))
+
+	seed := baseSeed + int64(h.Sum64())
+
+	t.Logf("Random seed for %s: %d (set %s=%d to reproduce)", t.Name(), seed, EnvRandSeed, baseSeed)
+
+	return NewRand(seed)
+}
+
+// Rand generates random tidbits for use in identifiers, like the package
+// level functions, from a fixed seed. Tests which use a Rand with a known
+// seed, such as one logged by a previous run, generate the same values and so
+// can be reproduced.
+//
+// A Rand is safe for concurrent use, although the order of values is then
+// only deterministic within each goroutine if they do not share the Rand.
+type Rand struct {
+	seed int64
+
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// NewRand returns a Rand seeded with the given seed.
+func NewRand(seed int64) *Rand {
+	return &Rand{
+		seed: seed,
+		r:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Seed returns the seed the Rand was created with.
+func (r *Rand) Seed() int64 {
+	return r.seed
+}
+
+// Int generates a random integer.
+func (r *Rand) Int() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.r.Int()
+}
+
+// IntRange returns a random integer between minVal (inclusive) and maxVal
+// (exclusive).
+func (r *Rand) IntRange(minVal int, maxVal int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.r.Intn(maxVal-minVal) + minVal
+}
+
+// Prefix is used to generate a unique name with a prefix, for randomizing
+// names in acceptance tests.
+func (r *Rand) Prefix(name string) string {
+	return fmt.Sprintf("%s-%d", name, r.Int())
+}
+
+// String generates a random alphanumeric string of the length specified.
+func (r *Rand) String(strlen int) string {
+	return r.StringFromCharSet(strlen, CharSetAlphaNum)
+}
+
+// StringFromCharSet generates a random string by selecting characters from
+// the charset provided.
+func (r *Rand) StringFromCharSet(strlen int, charSet string) string {
+	result := make([]byte, strlen)
+	for i := 0; i < strlen; i++ {
+		result[i] = charSet[r.IntRange(0, len(charSet))]
+	}
+	return string(result)
+}
+
+// IP returns a random IP address in the specified CIDR block.
+// The prefix length must be less than 31.
+func (r *Rand) IP(s string) (string, error) {
+	prefix, err := netip.ParsePrefix(s)
+
+	if err != nil {
+		return "", err
+	}
+
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String(), nil
+	}
+
+	prefixSizeExponent := uint(prefix.Addr().BitLen() - prefix.Bits())
+
+	if prefix.Addr().Is4() && prefixSizeExponent > 31 {
+		return "", fmt.Errorf("CIDR range is too large: %d", prefixSizeExponent)
+	}
+
+	// Prevent panics with rand.Int63n().
+	if prefix.Addr().Is6() && prefixSizeExponent > 63 {
+		return "", fmt.Errorf("CIDR range is too large: %d", prefixSizeExponent)
+	}
+
+	// Calculate max random integer based on the prefix.
+	// Bit shift 1<<size and subtract 1 to not overflow.
+	// e.g. 1<<8 - 1 = 256 - 1 = 255 for 192.168.0.0/24
+	randIntMax := big.NewInt(1)
+	randIntMax.Lsh(randIntMax, prefixSizeExponent)
+	randIntMax.Sub(randIntMax, big.NewInt(1))
+
+	// Prevent panics with rand.Int63n().
+	if randIntMax.Cmp(big.NewInt(0)) <= 0 {
+		return prefix.Addr().String(), nil
+	}
+
+	r.mu.Lock()
+	randInt := r.r.Int63n(randIntMax.Int64())
+	r.mu.Unlock()
+
+	if randInt == 0 {
+		return prefix.Addr().String(), nil
+	}
+
+	// Calculate random address by taking prefix address and adding the random
+	// integer.
+	randAddrInt := new(big.Int).SetBytes(prefix.Addr().AsSlice())
+	randAddrInt.Add(randAddrInt, big.NewInt(randInt))
+
+	randAddr, ok := netip.AddrFromSlice(randAddrInt.Bytes())
+
+	if !ok {
+		return "", fmt.Errorf("unable to create random address from bytes: %#v", randAddrInt.Bytes())
+	}
+
+	return randAddr.String(), nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acctest
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNewRand(t *testing.T) {
+	values := func(r *Rand) []interface{} {
+		ip, err := r.IP("10.0.0.0/8")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return []interface{}{r.Int(), r.Prefix("test"), r.String(10), ip}
+	}
+
+	expected := values(NewRand(42))
+	got := values(NewRand(42))
+
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("value %d: expected %v with the same seed, got: %v", i, expected[i], got[i])
+		}
+	}
+
+	if got := values(NewRand(43)); got[0] == expected[0] {
+		t.Errorf("expected a different value with a different seed, got: %v", got[0])
+	}
+}
+
+func TestRand_Prefix(t *testing.T) {
+	if v := NewRand(1).Prefix("test"); !regexp.MustCompile(`^test-\d+$`).MatchString(v) {
+		t.Errorf("expected prefixed name, got: %s", v)
+	}
+}
+
+func TestRand_Seed(t *testing.T) {
+	if seed := NewRand(42).Seed(); seed != 42 {
+		t.Errorf("expected seed 42, got: %d", seed)
+	}
+}
+
+func TestNewRandForTest(t *testing.T) {
+	t.Run("same", func(t *testing.T) {
+		expected := NewRandForTest(t).String(10)
+
+		if got := NewRandForTest(t).String(10); got != expected {
+			t.Errorf("expected %q for the same test, got: %q", expected, got)
+		}
+
+		if got, want := NewRandForTest(t).Seed(), NewRandForTest(t).Seed(); got != want {
+			t.Errorf("expected seed %d for the same test, got: %d", want, got)
+		}
+	})
+
+	t.Run("different", func(t *testing.T) {
+		var other int64
+
+		t.Run("other", func(t *testing.T) {
+			other = NewRandForTest(t).Seed()
+		})
+
+		if got := NewRandForTest(t).Seed(); got == other {
+			t.Errorf("expected a different seed for a different test, got: %d", got)
+		}
+	})
+}
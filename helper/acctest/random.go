@@ -12,8 +12,6 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
-	"math/rand"
-	"net/netip"
 	"strings"
 	"time"
 
@@ -22,36 +20,36 @@ import (
 
 // Helpers for generating random tidbits for use in identifiers to prevent
 // collisions in acceptance tests.
+//
+// The values are generated from a seed which is logged when the package is
+// initialized, and may be set with the TF_ACC_RAND_SEED environment variable
+// to reproduce a previous run. Use NewRand for a Rand with its own seed.
 
 // RandInt generates a random integer
 func RandInt() int {
-	return rand.Int()
+	return defaultRand.Int()
 }
 
 // RandomWithPrefix is used to generate a unique name with a prefix, for
 // randomizing names in acceptance tests
 func RandomWithPrefix(name string) string {
-	return fmt.Sprintf("%s-%d", name, RandInt())
+	return defaultRand.Prefix(name)
 }
 
 // RandIntRange returns a random integer between minVal (inclusive) and maxVal (exclusive)
 func RandIntRange(minVal int, maxVal int) int {
-	return rand.Intn(maxVal-minVal) + minVal
+	return defaultRand.IntRange(minVal, maxVal)
 }
 
 // RandString generates a random alphanumeric string of the length specified
 func RandString(strlen int) string {
-	return RandStringFromCharSet(strlen, CharSetAlphaNum)
+	return defaultRand.String(strlen)
 }
 
 // RandStringFromCharSet generates a random string by selecting characters from
 // the charset provided
 func RandStringFromCharSet(strlen int, charSet string) string {
-	result := make([]byte, strlen)
-	for i := 0; i < strlen; i++ {
-		result[i] = charSet[RandIntRange(0, len(charSet))]
-	}
-	return string(result)
+	return defaultRand.StringFromCharSet(strlen, charSet)
 }
 
 // RandSSHKeyPair generates a random public and private SSH key pair.
@@ -124,57 +122,7 @@ func RandTLSCert(orgName string) (string, string, error) {
 // RandIpAddress returns a random IP address in the specified CIDR block.
 // The prefix length must be less than 31.
 func RandIpAddress(s string) (string, error) {
-	prefix, err := netip.ParsePrefix(s)
-
-	if err != nil {
-		return "", err
-	}
-
-	if prefix.IsSingleIP() {
-		return prefix.Addr().String(), nil
-	}
-
-	prefixSizeExponent := uint(prefix.Addr().BitLen() - prefix.Bits())
-
-	if prefix.Addr().Is4() && prefixSizeExponent > 31 {
-		return "", fmt.Errorf("CIDR range is too large: %d", prefixSizeExponent)
-	}
-
-	// Prevent panics with rand.Int63n().
-	if prefix.Addr().Is6() && prefixSizeExponent > 63 {
-		return "", fmt.Errorf("CIDR range is too large: %d", prefixSizeExponent)
-	}
-
-	// Calculate max random integer based on the prefix.
-	// Bit shift 1<<size and subtract 1 to not overflow.
-	// e.g. 1<<8 - 1 = 256 - 1 = 255 for 192.168.0.0/24
-	randIntMax := big.NewInt(1)
-	randIntMax.Lsh(randIntMax, prefixSizeExponent)
-	randIntMax.Sub(randIntMax, big.NewInt(1))
-
-	// Prevent panics with rand.Int63n().
-	if randIntMax.Cmp(big.NewInt(0)) <= 0 {
-		return prefix.Addr().String(), nil
-	}
-
-	randInt := rand.Int63n(randIntMax.Int64())
-
-	if randInt == 0 {
-		return prefix.Addr().String(), nil
-	}
-
-	// Calculate random address by taking prefix address and adding the random
-	// integer.
-	randAddrInt := new(big.Int).SetBytes(prefix.Addr().AsSlice())
-	randAddrInt.Add(randAddrInt, big.NewInt(randInt))
-
-	randAddr, ok := netip.AddrFromSlice(randAddrInt.Bytes())
-
-	if !ok {
-		return "", fmt.Errorf("unable to create random address from bytes: %#v", randAddrInt.Bytes())
-	}
-
-	return randAddr.String(), nil
+	return defaultRand.IP(s)
 }
 
 func genPrivateKey() (*rsa.PrivateKey, string, error) {
@@ -5,6 +5,7 @@ package customdiff
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/logging"
@@ -34,3 +35,38 @@ func ComputedIf(key string, f ResourceConditionFunc) schema.CustomizeDiffFunc {
 		return nil
 	}
 }
+
+// ComputedIfBlock returns a CustomizeDiffFunc that sets the new value of the
+// given attribute, nested within each element of the given list block, as
+// computed if the given condition function returns true for that element.
+//
+// The condition function is called once per block element, so, for example,
+// "block.0.attr" can be computed while "block.1.attr" keeps the value
+// provided in configuration.
+//
+// This function is best effort and will generate a warning log on any errors.
+func ComputedIfBlock(key, attr string, f BlockConditionFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		blocks, _ := d.Get(key).([]interface{})
+
+		for i, raw := range blocks {
+			block, ok := raw.(map[string]interface{})
+
+			if !ok || !f(ctx, block, meta) {
+				continue
+			}
+
+			attrKey := fmt.Sprintf("%s.%d.%s", key, i, attr)
+
+			// Similar to ComputedIf, this logic only generates a warning log
+			// instead of returning the error.
+			if err := d.SetNewComputed(attrKey); err != nil {
+				logging.HelperSchemaWarn(ctx, "unable to set attribute value to unknown", map[string]interface{}{
+					logging.KeyAttributePath: attrKey,
+					logging.KeyError:         err,
+				})
+			}
+		}
+		return nil
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestComputedIf(t *testing.T) {
@@ -166,3 +167,91 @@ func TestComputedIf(t *testing.T) {
 		}
 	})
 }
+
+func TestComputedIfBlock(t *testing.T) {
+	t.Parallel()
+
+	provider := testProvider(
+		map[string]*schema.Schema{
+			"block": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"a": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"b": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		ComputedIfBlock("block", "b", func(_ context.Context, block map[string]interface{}, meta interface{}) bool {
+			return block["a"].(string) != ""
+		}),
+	)
+
+	testCases := map[string]struct {
+		state map[string]string
+	}{
+		"create": {
+			state: map[string]string{},
+		},
+		"update": {
+			state: map[string]string{
+				"id":        "test",
+				"block.#":   "2",
+				"block.0.a": "foo",
+				"block.0.b": "old",
+				"block.1.a": "",
+				"block.1.b": "bar",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diff, err := provider.ResourcesMap["test"].Diff(
+				context.Background(),
+				&terraform.InstanceState{
+					ID:         testCase.state["id"],
+					Attributes: testCase.state,
+				},
+				terraform.NewResourceConfigRaw(map[string]interface{}{
+					"block": []interface{}{
+						map[string]interface{}{
+							"a": "foo",
+						},
+						map[string]interface{}{
+							"b": "bar",
+						},
+					},
+				}),
+				provider.Meta(),
+			)
+
+			if err != nil {
+				t.Fatalf("Diff failed with error: %s", err)
+			}
+
+			if attr := diff.Attributes["block.0.b"]; attr == nil || !attr.NewComputed {
+				t.Errorf("Attribute 'block.0.b' is not marked as NewComputed: %#v", attr)
+			}
+
+			if attr := diff.Attributes["block.1.b"]; attr != nil && attr.NewComputed {
+				t.Errorf("Attribute 'block.1.b' is marked as NewComputed, but should not be")
+			}
+
+			if testCase.state["id"] == "" {
+				if attr := diff.Attributes["block.1.b"]; attr == nil || attr.New != "bar" {
+					t.Errorf("Attribute 'block.1.b' should be user-provided: %#v", attr)
+				}
+			}
+		})
+	}
+}
@@ -21,6 +21,10 @@ type ValueChangeConditionFunc func(ctx context.Context, oldValue, newValue, meta
 // on a given value.
 type ValueConditionFunc func(ctx context.Context, value, meta interface{}) bool
 
+// BlockConditionFunc is a function type that makes a boolean decision based
+// on the values of a single nested block element.
+type BlockConditionFunc func(ctx context.Context, block map[string]interface{}, meta interface{}) bool
+
 // If returns a CustomizeDiffFunc that calls the given condition
 // function and then calls the given CustomizeDiffFunc only if the condition
 // function returns true.
@@ -42,8 +42,9 @@ func ForceNewIf(key string, f ResourceConditionFunc) schema.CustomizeDiffFunc {
 // ForceNewIfChange returns a CustomizeDiffFunc that flags the given key as
 // requiring a new resource if the given condition function returns true.
 //
-// The return value of the condition function is ignored if the old and new
-// values compare equal, since no attribute diff is generated in that case.
+// The condition function is not called if the old and new values compare
+// equal, including when the key is not in the diff, since no attribute diff
+// is generated in that case.
 //
 // This function is similar to ForceNewIf but provides the condition function
 // only the old and new values of the given key, which leads to more compact
@@ -53,6 +54,10 @@ func ForceNewIf(key string, f ResourceConditionFunc) schema.CustomizeDiffFunc {
 // This function is best effort and will generate a warning log on any errors.
 func ForceNewIfChange(key string, f ValueChangeConditionFunc) schema.CustomizeDiffFunc {
 	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if !d.HasChange(key) {
+			return nil
+		}
+
 		oldValue, newValue := d.GetChange(key)
 		if f(ctx, oldValue, newValue, meta) {
 			// To prevent backwards compatibility issues, this logic only
@@ -72,3 +77,21 @@ func ForceNewIfChange(key string, f ValueChangeConditionFunc) schema.CustomizeDi
 		return nil
 	}
 }
+
+// ForceNewIfProvider returns a CustomizeDiffFunc that flags the given key as
+// requiring a new resource if the value changes and the given predicate
+// returns true for the provider meta, such as the API client returned by
+// the provider ConfigureContextFunc. This is intended for replacement which
+// depends on the remote system discovered when configuring the provider,
+// such as an API version which cannot update the attribute in-place.
+//
+// The meta may be nil, for example if Terraform plans the resource before the
+// provider is configured, so the predicate must handle a nil meta, typically
+// by returning false.
+//
+// This function is best effort and will generate a warning log on any errors.
+func ForceNewIfProvider(key string, predicate func(meta interface{}) bool) schema.CustomizeDiffFunc {
+	return ForceNewIf(key, func(_ context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+		return d.HasChange(key) && predicate(meta)
+	})
+}
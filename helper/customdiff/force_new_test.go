@@ -327,3 +327,128 @@ func TestForceNewIfChange(t *testing.T) {
 		}
 	})
 }
+
+func TestForceNewIfProvider(t *testing.T) {
+	t.Parallel()
+
+	type client struct {
+		legacy bool
+	}
+
+	legacy := func(meta interface{}) bool {
+		c, ok := meta.(*client)
+
+		return ok && c.legacy
+	}
+
+	s := map[string]*schema.Schema{
+		"foo": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+
+	testCases := map[string]struct {
+		meta        interface{}
+		newValue    string
+		requiresNew bool
+	}{
+		"true": {
+			meta:        &client{legacy: true},
+			newValue:    "baz",
+			requiresNew: true,
+		},
+		"false": {
+			meta:     &client{legacy: false},
+			newValue: "baz",
+		},
+		"nil meta": {
+			newValue: "baz",
+		},
+		"no change": {
+			meta:     &client{legacy: true},
+			newValue: "bar",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := testProvider(s, ForceNewIfProvider("foo", legacy))
+			provider.SetMeta(testCase.meta)
+
+			diff, err := testDiff(
+				provider,
+				map[string]string{
+					"foo": "bar",
+				},
+				map[string]string{
+					"foo": testCase.newValue,
+				},
+			)
+
+			if err != nil {
+				t.Fatalf("Diff failed with error: %s", err)
+			}
+
+			var requiresNew bool
+			if diff != nil && diff.Attributes["foo"] != nil {
+				requiresNew = diff.Attributes["foo"].RequiresNew
+			}
+
+			if requiresNew != testCase.requiresNew {
+				t.Errorf("expected RequiresNew %t, got: %t", testCase.requiresNew, requiresNew)
+			}
+		})
+	}
+}
+
+func TestForceNewIfChange_unchanged(t *testing.T) {
+	t.Parallel()
+
+	var condCalls int
+
+	provider := testProvider(
+		map[string]*schema.Schema{
+			"foo": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"bar": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		ForceNewIfChange("foo", func(_ context.Context, oldValue, newValue, meta interface{}) bool {
+			condCalls++
+			return true
+		}),
+	)
+
+	diff, err := testDiff(
+		provider,
+		map[string]string{
+			"foo": "same",
+			"bar": "old",
+		},
+		map[string]string{
+			"foo": "same",
+			"bar": "new",
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("Diff failed with error: %s", err)
+	}
+
+	if condCalls != 0 {
+		t.Fatalf("Wrong number of conditional callback calls %d; want %d", condCalls, 0)
+	}
+
+	if diff.RequiresNew() {
+		t.Error("Diff unexpectedly requires new resource")
+	}
+}
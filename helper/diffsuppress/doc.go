@@ -0,0 +1,7 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package diffsuppress provides reusable functions for the DiffSuppressFunc
+// field of attributes in package helper/schema, which suppress differences
+// between values that are equivalent despite being written differently.
+package diffsuppress
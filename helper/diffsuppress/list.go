@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package diffsuppress
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// UnorderedList returns a SchemaDiffSuppressFunc for TypeList attributes
+// which suppresses the difference when the old and new lists contain the same
+// elements, including duplicates, in any order. This is intended for lists
+// the remote system treats as unordered which cannot be a TypeSet, such as
+// when the elements do not hash cleanly.
+//
+// The function is called for the flatmap key of each element and of the
+// element count, such as "rules.0" and "rules.#", so it compares the whole
+// lists containing the key rather than the individual values. Keys are
+// resolved to their innermost list, so it is intended for lists of primitives
+// or of blocks without nested lists.
+func UnorderedList() schema.SchemaDiffSuppressFunc {
+	return func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+		o, n := d.GetChange(unorderedListKey(k))
+
+		oldList, ok := o.([]interface{})
+		if !ok {
+			return false
+		}
+
+		newList, ok := n.([]interface{})
+		if !ok {
+			return false
+		}
+
+		return unorderedEqual(oldList, newList)
+	}
+}
+
+// unorderedListKey returns the key of the list containing the given element
+// or count key, such as "rules" for "rules.0.name" or "rules.#".
+func unorderedListKey(k string) string {
+	parts := strings.Split(k, ".")
+
+	for i := len(parts) - 1; i > 0; i-- {
+		if parts[i] == "#" {
+			return strings.Join(parts[:i], ".")
+		}
+
+		if _, err := strconv.Atoi(parts[i]); err == nil {
+			return strings.Join(parts[:i], ".")
+		}
+	}
+
+	return k
+}
+
+// unorderedEqual returns whether the lists contain the same elements the same
+// number of times.
+func unorderedEqual(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	matched := make([]bool, len(b))
+
+	for _, av := range a {
+		found := false
+
+		for i, bv := range b {
+			if !matched[i] && valuesEqual(av, bv) {
+				matched[i] = true
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// valuesEqual returns whether the values, as returned by ResourceData Get,
+// are equal. Sets cannot be compared with reflect.DeepEqual because they
+// contain their hash function.
+func valuesEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case *schema.Set:
+		return a.Equal(b)
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for k, av := range a {
+			bv, ok := b[k]
+
+			if !ok || !valuesEqual(av, bv) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for i := range a {
+			if !valuesEqual(a[i], b[i]) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
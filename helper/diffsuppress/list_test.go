@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package diffsuppress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestUnorderedList(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				DiffSuppressFunc: UnorderedList(),
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+				DiffSuppressFunc: UnorderedList(),
+			},
+		},
+	}
+
+	state := &terraform.InstanceState{
+		ID: "id",
+		Attributes: map[string]string{
+			"id":           "id",
+			"names.#":      "3",
+			"names.0":      "a",
+			"names.1":      "b",
+			"names.2":      "b",
+			"rules.#":      "2",
+			"rules.0.port": "80",
+			"rules.1.port": "443",
+		},
+	}
+
+	rules := []interface{}{
+		map[string]interface{}{"port": 80},
+		map[string]interface{}{"port": 443},
+	}
+
+	testCases := map[string]struct {
+		config       map[string]interface{}
+		expectedKeys []string
+	}{
+		"unchanged": {
+			config: map[string]interface{}{
+				"names": []interface{}{"a", "b", "b"},
+				"rules": rules,
+			},
+		},
+		"reorder": {
+			config: map[string]interface{}{
+				"names": []interface{}{"b", "a", "b"},
+				"rules": []interface{}{
+					map[string]interface{}{"port": 443},
+					map[string]interface{}{"port": 80},
+				},
+			},
+		},
+		"add": {
+			config: map[string]interface{}{
+				"names": []interface{}{"b", "a", "b", "c"},
+				"rules": rules,
+			},
+			expectedKeys: []string{"names.#", "names.0", "names.1", "names.3"},
+		},
+		"remove": {
+			config: map[string]interface{}{
+				"names": []interface{}{"b", "a", "b"},
+				"rules": []interface{}{
+					map[string]interface{}{"port": 443},
+				},
+			},
+			expectedKeys: []string{"rules.#", "rules.0.port", "rules.1.port"},
+		},
+		"duplicates": {
+			config: map[string]interface{}{
+				"names": []interface{}{"a", "a", "b"},
+				"rules": rules,
+			},
+			expectedKeys: []string{"names.1"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testCase.config), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var attributes map[string]*terraform.ResourceAttrDiff
+			if diff != nil {
+				attributes = diff.Attributes
+			}
+
+			if len(attributes) != len(testCase.expectedKeys) {
+				t.Fatalf("expected differences for %v, got: %#v", testCase.expectedKeys, attributes)
+			}
+
+			for _, k := range testCase.expectedKeys {
+				if _, ok := attributes[k]; !ok {
+					t.Errorf("expected difference for %s, got: %#v", k, attributes)
+				}
+			}
+		})
+	}
+}
+
+func TestUnorderedListKey(t *testing.T) {
+	testCases := map[string]string{
+		"names.#":             "names",
+		"names.0":             "names",
+		"rules.1.port":        "rules",
+		"block.0.items.2":     "block.0.items",
+		"block.0.items.#":     "block.0.items",
+		"block.0.rules.1.cid": "block.0.rules",
+		"names":               "names",
+	}
+
+	for k, expected := range testCases {
+		if got := unorderedListKey(k); got != expected {
+			t.Errorf("%s: expected %q, got: %q", k, expected, got)
+		}
+	}
+}
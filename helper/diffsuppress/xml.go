@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package diffsuppress
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+)
+
+// XMLEqual returns a SchemaDiffSuppressFunc which suppresses the difference
+// between two XML documents that are equal once normalized, ignoring
+// attribute order, whitespace surrounding text, and comments. Refer to
+// structure.NormalizeXmlString for the normalization rules. The difference is
+// retained if either value is not well-formed XML.
+//
+// This is intended to be paired with validation.StringIsXML.
+func XMLEqual() schema.SchemaDiffSuppressFunc {
+	return func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+		oldXml, err := structure.NormalizeXmlString(oldValue)
+		if err != nil {
+			return false
+		}
+
+		newXml, err := structure.NormalizeXmlString(newValue)
+		if err != nil {
+			return false
+		}
+
+		return oldXml == newXml
+	}
+}
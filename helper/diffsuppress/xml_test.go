@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package diffsuppress
+
+import (
+	"testing"
+)
+
+func TestXMLEqual(t *testing.T) {
+	testCases := map[string]struct {
+		oldValue string
+		newValue string
+		expected bool
+	}{
+		"identical": {
+			oldValue: `<a b="1"></a>`,
+			newValue: `<a b="1"></a>`,
+			expected: true,
+		},
+		"attribute-order": {
+			oldValue: `<a b="1" c="2"></a>`,
+			newValue: `<a c="2" b="1"></a>`,
+			expected: true,
+		},
+		"whitespace": {
+			oldValue: `<a><b>text</b></a>`,
+			newValue: "<a>\n  <b> text </b>\n</a>\n",
+			expected: true,
+		},
+		"declaration-and-comments": {
+			oldValue: `<a><b/></a>`,
+			newValue: `<?xml version="1.0"?><!-- comment --><a><b></b></a>`,
+			expected: true,
+		},
+		"different-attribute": {
+			oldValue: `<a b="1"></a>`,
+			newValue: `<a b="2"></a>`,
+			expected: false,
+		},
+		"different-text": {
+			oldValue: `<a>x</a>`,
+			newValue: `<a>y</a>`,
+			expected: false,
+		},
+		"different-element-order": {
+			oldValue: `<a><b></b><c></c></a>`,
+			newValue: `<a><c></c><b></b></a>`,
+			expected: false,
+		},
+		"malformed-old": {
+			oldValue: `<a>`,
+			newValue: `<a></a>`,
+			expected: false,
+		},
+		"malformed-new": {
+			oldValue: `<a></a>`,
+			newValue: `<a></b>`,
+			expected: false,
+		},
+		"malformed-both": {
+			oldValue: `<a>`,
+			newValue: `<a>`,
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if actual := XMLEqual()("policy", testCase.oldValue, testCase.newValue, nil); actual != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -143,7 +143,7 @@ func (t *loggingHttpTransport) RoundTrip(req *http.Request) (*http.Response, err
 	}
 
 	// Decompose the response bytes in a message (HTTP body) and fields (HTTP headers), then log it
-	fields, err = decomposeResponseForLogging(res)
+	fields, err = decomposeResponseForLogging(ctx, res)
 	if err != nil {
 		t.Error(ctx, "Failed to parse response bytes for logging", map[string]interface{}{
 			"error": err,
@@ -212,7 +212,7 @@ func decomposeRequestForLogging(req *http.Request) (map[string]interface{}, erro
 	}
 
 	// Read the rest of the body content
-	fields[FieldHttpRequestBody] = bodyFromRestOfRequestReader(reqReader)
+	fields[FieldHttpRequestBody] = string(redactBody(req.Context(), []byte(bodyFromRestOfRequestReader(reqReader))))
 	return fields, nil
 }
 
@@ -257,7 +257,7 @@ func bodyFromRestOfRequestReader(reader *textproto.Reader) string {
 	return builder.String()
 }
 
-func decomposeResponseForLogging(res *http.Response) (map[string]interface{}, error) {
+func decomposeResponseForLogging(ctx context.Context, res *http.Response) (map[string]interface{}, error) {
 	fields := make(map[string]interface{}, len(res.Header)+4)
 	fields[FieldHttpOperationType] = OperationHttpResponse
 
@@ -285,7 +285,7 @@ func decomposeResponseForLogging(res *http.Response) (map[string]interface{}, er
 	// http.Client
 	res.Body = io.NopCloser(bytes.NewBuffer(resBody))
 
-	fields[FieldHttpResponseBody] = string(resBody)
+	fields[FieldHttpResponseBody] = string(redactBody(ctx, resBody))
 
 	return fields, nil
 }
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// maskedValue replaces the values of fields masked by MaskSensitive.
+const maskedValue = "***"
+
+type logRedactorKey struct{}
+
+// ContextWithLogRedactor returns a copy of the context which carries the
+// given function. NewLoggingHTTPTransport, NewSubsystemLoggingHTTPTransport
+// and NewTransport call it with each HTTP request and response body before
+// that body is written to the logs, using the context attached to the
+// http.Request.
+//
+// The SDK sets this up automatically for the contexts passed to provider,
+// resource and data source functions when schema.Provider LogRedactor is set.
+func ContextWithLogRedactor(ctx context.Context, redactor func(body []byte) []byte) context.Context {
+	return context.WithValue(ctx, logRedactorKey{}, redactor)
+}
+
+// redactBody passes the body through the log redactor of the context, if any.
+func redactBody(ctx context.Context, body []byte) []byte {
+	redactor, ok := ctx.Value(logRedactorKey{}).(func(body []byte) []byte)
+
+	if !ok || redactor == nil {
+		return body
+	}
+
+	return redactor(body)
+}
+
+// MaskSensitive returns a log redactor, suitable for schema.Provider
+// LogRedactor, which masks the values of all JSON object fields with any of
+// the given names, at any depth of the body. Bodies which are not valid JSON
+// are returned unchanged, while masked JSON bodies are re-encoded compactly.
+//
+// The names are typically those of the provider's Sensitive attributes, as
+// returned by schema.Provider SensitiveAttributeNames.
+func MaskSensitive(names ...string) func(body []byte) []byte {
+	masked := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		masked[name] = true
+	}
+
+	return func(body []byte) []byte {
+		if len(masked) == 0 || !json.Valid(body) {
+			return body
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+
+		var v interface{}
+
+		if err := decoder.Decode(&v); err != nil {
+			return body
+		}
+
+		if !maskSensitiveValue(v, masked) {
+			return body
+		}
+
+		out, err := json.Marshal(v)
+
+		if err != nil {
+			return body
+		}
+
+		return out
+	}
+}
+
+// maskSensitiveValue masks the matching object fields within the decoded JSON
+// value in place, returning whether any field was masked.
+func maskSensitiveValue(v interface{}, masked map[string]bool) bool {
+	var changed bool
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range v {
+			if masked[k] {
+				v[k] = maskedValue
+				changed = true
+
+				continue
+			}
+
+			if maskSensitiveValue(fv, masked) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, ev := range v {
+			if maskSensitiveValue(ev, masked) {
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+func TestMaskSensitive(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		names    []string
+		body     string
+		expected string
+	}{
+		"top-level field": {
+			names:    []string{"password"},
+			body:     `{"username": "admin", "password": "hunter2"}`,
+			expected: `{"password":"***","username":"admin"}`,
+		},
+		"nested fields": {
+			names:    []string{"token"},
+			body:     `{"spec": [{"token": "abc", "count": 10}, {"token": {"value": "def"}}]}`,
+			expected: `{"spec":[{"count":10,"token":"***"},{"token":"***"}]}`,
+		},
+		"number precision": {
+			names:    []string{"secret"},
+			body:     `{"id": 12345678901234567890, "secret": 1}`,
+			expected: `{"id":12345678901234567890,"secret":"***"}`,
+		},
+		"no matching fields": {
+			names:    []string{"password"},
+			body:     `{"username": "admin"}`,
+			expected: `{"username": "admin"}`,
+		},
+		"no names": {
+			body:     `{"password": "hunter2"}`,
+			expected: `{"password": "hunter2"}`,
+		},
+		"not JSON": {
+			names:    []string{"password"},
+			body:     `password=hunter2`,
+			expected: `password=hunter2`,
+		},
+		"empty": {
+			names:    []string{"password"},
+			body:     ``,
+			expected: ``,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := string(logging.MaskSensitive(testCase.names...)([]byte(testCase.body)))
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewLoggingHTTPTransport_LogRedactor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "1", "token": "response-secret"}`))
+	}))
+	defer server.Close()
+
+	ctx, loggerOutput := setupRootLogger()
+	ctx = logging.ContextWithLogRedactor(ctx, logging.MaskSensitive("password", "token"))
+
+	transport := logging.NewLoggingHTTPTransport(http.DefaultTransport)
+	client := http.Client{
+		Transport: transport,
+		Timeout:   10 * time.Second,
+	}
+
+	req, _ := http.NewRequest("POST", server.URL, bytes.NewBufferString(`{"username": "admin", "password": "request-secret"}`))
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+
+	if diff := cmp.Diff(string(resBody), `{"id": "1", "token": "response-secret"}`); diff != "" {
+		t.Fatalf("expected response body to be unchanged: %s", diff)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(loggerOutput)
+	if err != nil {
+		t.Fatalf("log outtput parsing failed: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("unexpected amount of logs produced; expected 2, got %d", len(entries))
+	}
+
+	if diff := cmp.Diff(entries[0]["tf_http_req_body"], `{"password":"***","username":"admin"}`); diff != "" {
+		t.Errorf("unexpected difference for logging of the request body:\n%s", diff)
+	}
+
+	if diff := cmp.Diff(entries[1]["tf_http_res_body"], `{"id":"1","token":"***"}`); diff != "" {
+		t.Errorf("unexpected difference for logging of the response body:\n%s", diff)
+	}
+}
@@ -5,6 +5,7 @@ package logging
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -21,7 +22,7 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if IsDebugOrHigher() {
 		reqData, err := httputil.DumpRequestOut(req, true)
 		if err == nil {
-			log.Printf("[DEBUG] "+logReqMsg, t.name, prettyPrintJsonLines(reqData))
+			log.Printf("[DEBUG] "+logReqMsg, t.name, prettyPrintJsonLines(redactDump(req.Context(), reqData)))
 		} else {
 			log.Printf("[ERROR] %s API Request error: %#v", t.name, err)
 		}
@@ -35,7 +36,7 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if IsDebugOrHigher() {
 		respData, err := httputil.DumpResponse(resp, true)
 		if err == nil {
-			log.Printf("[DEBUG] "+logRespMsg, t.name, prettyPrintJsonLines(respData))
+			log.Printf("[DEBUG] "+logRespMsg, t.name, prettyPrintJsonLines(redactDump(req.Context(), respData)))
 		} else {
 			log.Printf("[ERROR] %s API Response error: %#v", t.name, err)
 		}
@@ -57,6 +58,24 @@ func NewTransport(name string, t http.RoundTripper) *transport {
 	return &transport{name, t}
 }
 
+// redactDump passes the body of a dumped HTTP request or response through the
+// log redactor of the context, if any, leaving the headers as-is.
+func redactDump(ctx context.Context, b []byte) []byte {
+	head, body, found := bytes.Cut(b, []byte("\r\n\r\n"))
+
+	if !found {
+		return b
+	}
+
+	var out bytes.Buffer
+
+	out.Write(head)
+	out.WriteString("\r\n\r\n")
+	out.Write(redactBody(ctx, body))
+
+	return out.Bytes()
+}
+
 // prettyPrintJsonLines iterates through a []byte line-by-line,
 // transforming any lines that are complete json into pretty-printed json.
 func prettyPrintJsonLines(b []byte) string {
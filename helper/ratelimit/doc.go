@@ -0,0 +1,14 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package ratelimit provides a token bucket rate limiter which providers can
+// share across all calls to a remote system.
+//
+// A Limiter can be set on the schema.Provider type RateLimiter field, in which
+// case the SDK waits on it before calling the create, read, update, delete,
+// import, and data source read implementations of every resource. The limit
+// then applies per operation requested by Terraform, not per remote system
+// API call, so an implementation which makes several API calls consumes a
+// single token. Providers can also call Limiter.Wait directly, for example
+// from an http.RoundTripper, to limit individual API calls instead.
+package ratelimit
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket rate limiter. The bucket holds up to burst tokens
+// and is refilled at a rate of r tokens per second. Each call to Wait consumes
+// a single token, blocking until one is available.
+//
+// A Limiter is safe for concurrent use.
+type Limiter struct {
+	rate  float64
+	burst int
+
+	mu      sync.Mutex
+	tokens  float64
+	last    time.Time
+	waiters []*waiter
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// NewLimiter returns a Limiter allowing r requests per second on average,
+// with bursts of up to burst requests. The bucket starts full. NewLimiter
+// panics if r is not positive or burst is less than one.
+func NewLimiter(r float64, burst int) *Limiter {
+	if r <= 0 {
+		panic(fmt.Sprintf("ratelimit: rate must be positive, got %v", r))
+	}
+
+	if burst < 1 {
+		panic(fmt.Sprintf("ratelimit: burst must be at least 1, got %d", burst))
+	}
+
+	return &Limiter{
+		rate:   r,
+		burst:  burst,
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Allow consumes a token and returns true if one is available immediately,
+// otherwise it returns false without consuming a token.
+func (l *Limiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance()
+
+	if l.tokens < 1 {
+		return false
+	}
+
+	l.tokens--
+
+	return true
+}
+
+// waiter is a call to Wait blocked on a reserved token.
+type waiter struct {
+	// at is when the reserved token becomes available. It moves earlier
+	// when a waiter ahead of it is cancelled.
+	at time.Time
+
+	// wake is signalled when at changes.
+	wake chan struct{}
+}
+
+// Wait blocks until a token is available, then consumes it. Concurrent
+// waiters are served in the order they called Wait. If the context is
+// cancelled or its deadline would pass before a token becomes available, Wait
+// returns an error without consuming a token, and the waiters behind it are
+// served correspondingly sooner.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.advance()
+
+	// Reserve the token up front, which may leave the bucket in debt, so
+	// concurrent waiters are served in order.
+	l.tokens--
+	delay := time.Duration(0)
+
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && l.now().Add(delay).After(deadline) {
+		l.tokens++
+		l.mu.Unlock()
+
+		return fmt.Errorf("ratelimit: waiting %s would exceed context deadline", delay)
+	}
+
+	if delay == 0 {
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	w := &waiter{
+		at:   l.now().Add(delay),
+		wake: make(chan struct{}, 1),
+	}
+	l.waiters = append(l.waiters, w)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			l.mu.Lock()
+			l.removeWaiter(w)
+			l.mu.Unlock()
+
+			return nil
+		case <-w.wake:
+			l.mu.Lock()
+			delay := w.at.Sub(l.now())
+			l.mu.Unlock()
+
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+
+			timer.Reset(delay)
+		case <-ctx.Done():
+			l.mu.Lock()
+			l.tokens++
+
+			// The refunded token moves each later reservation forward.
+			for _, later := range l.waiters[l.removeWaiter(w):] {
+				later.at = later.at.Add(-time.Duration(float64(time.Second) / l.rate))
+
+				select {
+				case later.wake <- struct{}{}:
+				default:
+				}
+			}
+
+			l.mu.Unlock()
+
+			return ctx.Err()
+		}
+	}
+}
+
+// removeWaiter removes w from the waiters and returns its former index. It
+// must be called with mu held.
+func (l *Limiter) removeWaiter(w *waiter) int {
+	i := slices.Index(l.waiters, w)
+	l.waiters = slices.Delete(l.waiters, i, i+1)
+
+	return i
+}
+
+// advance refills the bucket based on the time elapsed since it was last
+// refilled. It must be called with mu held.
+func (l *Limiter) advance() {
+	now := l.now()
+
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+
+		if l.tokens > float64(l.burst) {
+			l.tokens = float64(l.burst)
+		}
+	}
+
+	l.last = now
+}
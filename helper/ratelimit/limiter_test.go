@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	l := NewLimiter(2, 3)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Fatalf("expected burst request %d to be allowed", i)
+		}
+	}
+
+	if l.Allow() {
+		t.Fatal("expected request beyond burst to be limited")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+
+	if !l.Allow() {
+		t.Fatal("expected request to be allowed after refill")
+	}
+
+	if l.Allow() {
+		t.Fatal("expected only one token to be refilled")
+	}
+
+	// The bucket never holds more than burst tokens.
+	now = now.Add(time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Fatalf("expected burst request %d to be allowed", i)
+		}
+	}
+
+	if l.Allow() {
+		t.Fatal("expected request beyond burst to be limited")
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	l := NewLimiter(50, 1)
+
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// The first token is available immediately, the other two at 20ms each.
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("expected Wait to block for refill, took %s", elapsed)
+	}
+}
+
+func TestLimiterWait_cancelled(t *testing.T) {
+	l := NewLimiter(0.001, 1)
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected error when the wait would exceed the deadline")
+	}
+
+	// Failed waits do not consume tokens.
+	if l.tokens < -0.001 {
+		t.Fatalf("expected failed waits not to consume tokens, got %v tokens", l.tokens)
+	}
+}
+
+func TestLimiterWait_cancelledWaiter(t *testing.T) {
+	l := NewLimiter(2, 1)
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cancelled := make(chan error)
+
+	go func() {
+		cancelled <- l.Wait(ctx)
+	}()
+
+	waitForWaiters(t, l, 1)
+
+	start := time.Now()
+	done := make(chan error)
+
+	go func() {
+		done <- l.Wait(context.Background())
+	}()
+
+	waitForWaiters(t, l, 2)
+	cancel()
+
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The second waiter takes the token refunded by the cancelled one, at
+	// 500ms, rather than waiting for its own reservation at 1s.
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Fatalf("expected cancelled waiter's token to be reused, took %s", elapsed)
+	}
+}
+
+// waitForWaiters waits until the Limiter has the given number of blocked
+// waiters.
+func waitForWaiters(t *testing.T, l *Limiter, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+
+	for {
+		l.mu.Lock()
+		waiters := len(l.waiters)
+		l.mu.Unlock()
+
+		if waiters == n {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d waiters, got: %d", n, waiters)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNewLimiter_invalid(t *testing.T) {
+	for name, f := range map[string]func(){
+		"rate":  func() { NewLimiter(0, 1) },
+		"burst": func() { NewLimiter(1, 0) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected panic")
+				}
+			}()
+
+			f()
+		})
+	}
+}
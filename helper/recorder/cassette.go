@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Cassette is the set of interactions recorded to, or replayed from, a
+// cassette file. The file contains the Cassette encoded as JSON.
+type Cassette struct {
+	// Interactions are the recorded interactions, in the order they were
+	// made.
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded HTTP request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// loadCassette reads the cassette file at the given path.
+func loadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cassette
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("error reading cassette %s: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+// save writes the cassette to the file at the given path, creating any
+// missing parent directories.
+func (c *Cassette) save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("error encoding cassette %s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error writing cassette %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error writing cassette %s: %w", path, err)
+	}
+
+	return nil
+}
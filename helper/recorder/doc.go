@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package recorder provides an http.RoundTripper which records the HTTP
+// interactions of a provider to a cassette file, then replays them in later
+// runs, so that acceptance tests can run quickly and hermetically without
+// calling the remote system.
+//
+// Providers expose the seam by constructing their API client from an
+// http.RoundTripper that tests can replace, typically a field on the value
+// returned from ConfigureContextFunc (the provider meta), as for the
+// helper/resource APICallRecorder. Acceptance tests then wrap that transport
+// with a Recorder and set it on the TestStep, which saves the cassette at the
+// end of the TestStep:
+//
+//	rec, err := recorder.New("testdata/recordings/TestAccThing_basic.json", nil)
+//	if err != nil {
+//	  t.Fatal(err)
+//	}
+//
+//	resource.Test(t, resource.TestCase{
+//	  ProviderFactories: map[string]func() (*schema.Provider, error){
+//	    "example": func() (*schema.Provider, error) {
+//	      p := Provider()
+//	      p.ConfigureContextFunc = configureWithTransport(rec)
+//	      return p, nil
+//	    },
+//	  },
+//	  Steps: []resource.TestStep{
+//	    {
+//	      Config:   `resource "example_thing" "test" {}`,
+//	      Recorder: rec,
+//	    },
+//	  },
+//	})
+//
+// By default, the cassette is recorded when its file does not exist and is
+// replayed otherwise. Delete the file to record it again.
+//
+// Recorded headers and bodies can contain secrets, so request headers commonly
+// carrying credentials are always scrubbed before interactions are stored,
+// and further Scrubbers can be configured.
+package recorder
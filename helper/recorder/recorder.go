@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recorder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Mode is whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeAuto replays the cassette if its file exists, otherwise records
+	// a new cassette.
+	ModeAuto Mode = iota
+
+	// ModeRecord sends requests with the wrapped transport and records the
+	// interactions, replacing any existing cassette when saved.
+	ModeRecord
+
+	// ModeReplay responds to requests from the cassette, which must exist,
+	// without sending them.
+	ModeReplay
+)
+
+// scrubbedValue replaces the values of scrubbed headers.
+const scrubbedValue = "REDACTED"
+
+// defaultScrubbedHeaders are the headers which are always scrubbed, as they
+// commonly carry credentials.
+var defaultScrubbedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// MatcherFunc reports whether a recorded request matches an outgoing request
+// during replay. The body of the outgoing request has already been read.
+type MatcherFunc func(r *http.Request, body []byte, recorded Request) bool
+
+// DefaultMatcher matches requests with the same method and URL. It is used
+// when Options does not set a Matcher.
+func DefaultMatcher(r *http.Request, _ []byte, recorded Request) bool {
+	return r.Method == recorded.Method && r.URL.String() == recorded.URL
+}
+
+// BodyMatcher matches requests with the same method, URL and body. Requests
+// whose recorded bodies are altered by Scrubbers no longer match.
+func BodyMatcher(r *http.Request, body []byte, recorded Request) bool {
+	return DefaultMatcher(r, body, recorded) && string(body) == recorded.Body
+}
+
+// ScrubFunc removes secrets from an interaction before it is stored.
+type ScrubFunc func(i *Interaction)
+
+// ScrubHeaders returns a ScrubFunc which replaces the values of the given
+// request and response headers.
+func ScrubHeaders(names ...string) ScrubFunc {
+	return func(i *Interaction) {
+		for _, name := range names {
+			scrubHeader(i.Request.Headers, name)
+			scrubHeader(i.Response.Headers, name)
+		}
+	}
+}
+
+// ScrubBodies returns a ScrubFunc which passes the request and response
+// bodies through the given function, such as the helper/logging
+// MaskSensitive redactor.
+func ScrubBodies(redact func(body []byte) []byte) ScrubFunc {
+	return func(i *Interaction) {
+		i.Request.Body = string(redact([]byte(i.Request.Body)))
+		i.Response.Body = string(redact([]byte(i.Response.Body)))
+	}
+}
+
+func scrubHeader(h http.Header, name string) {
+	if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+		h.Set(name, scrubbedValue)
+	}
+}
+
+// Options configures a Recorder.
+type Options struct {
+	// Mode is whether to record or replay, defaulting to ModeAuto.
+	Mode Mode
+
+	// Transport sends requests while recording, defaulting to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// Matcher selects the recorded interaction for each request during
+	// replay, defaulting to DefaultMatcher.
+	Matcher MatcherFunc
+
+	// Scrubbers remove secrets from each interaction while recording, after
+	// the credential headers which are always scrubbed.
+	Scrubbers []ScrubFunc
+}
+
+// Recorder is an http.RoundTripper which records interactions to a cassette
+// file, or replays them from it. While replaying, each request is answered by
+// the first matching interaction which has not yet been replayed, so
+// repeated requests, such as refreshes while waiting for a state change,
+// replay their recorded responses in order.
+//
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	matcher   MatcherFunc
+	scrubbers []ScrubFunc
+
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed []bool
+}
+
+// New returns a Recorder for the cassette file at the given path, which is
+// typically under the testdata directory and named after the test. It
+// returns an error if replaying and the cassette cannot be read.
+func New(path string, opts *Options) (*Recorder, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      opts.Mode,
+		transport: opts.Transport,
+		matcher:   opts.Matcher,
+		scrubbers: append([]ScrubFunc{ScrubHeaders(defaultScrubbedHeaders...)}, opts.Scrubbers...),
+		cassette:  &Cassette{},
+	}
+
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+
+	if r.matcher == nil {
+		r.matcher = DefaultMatcher
+	}
+
+	if r.mode == ModeAuto {
+		r.mode = ModeRecord
+
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("error reading cassette %s: %w", path, err)
+		}
+	}
+
+	if r.mode == ModeReplay {
+		c, err := loadCassette(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.cassette = c
+		r.replayed = make([]bool, len(c.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns whether the Recorder is recording or replaying. It is never
+// ModeAuto.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip records or replays the request. It implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+
+	if req.Body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.transport.RoundTrip(out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	i := &Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: req.Header.Clone(),
+			Body:    string(body),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	}
+
+	for _, scrub := range r.scrubbers {
+		scrub(i)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx, i := range r.cassette.Interactions {
+		if r.replayed[idx] || !r.matcher(req, body, i.Request) {
+			continue
+		}
+
+		r.replayed[idx] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Headers.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(i.Response.Body))),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no interaction in cassette %s matches request %s %s", r.path, req.Method, req.URL)
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing while replaying.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cassette.save(r.path)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"n":%d,"echo":%q,"token":"secret"}`, n, body)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "recordings", "TestRecorder.json")
+
+	rec, err := New(path, &Options{
+		Scrubbers: []ScrubFunc{
+			ScrubBodies(func(body []byte) []byte {
+				return bytes.ReplaceAll(body, []byte("secret"), []byte(scrubbedValue))
+			}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rec.Mode() != ModeRecord {
+		t.Fatalf("expected ModeRecord without cassette, got: %d", rec.Mode())
+	}
+
+	client := &http.Client{Transport: rec}
+
+	// The same request twice, to check the responses replay in order.
+	recorded := []string{
+		doRequest(t, client, server.URL+"/things", "first"),
+		doRequest(t, client, server.URL+"/things", "second"),
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("unexpected error saving: %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading cassette: %s", err)
+	}
+
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("expected secrets to be scrubbed from cassette, got:\n%s", data)
+	}
+
+	rec, err = New(path, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rec.Mode() != ModeReplay {
+		t.Fatalf("expected ModeReplay with cassette, got: %d", rec.Mode())
+	}
+
+	client = &http.Client{Transport: rec}
+	server.Close()
+
+	for i, expected := range recorded {
+		expected = strings.ReplaceAll(expected, "secret", scrubbedValue)
+
+		if got := doRequest(t, client, server.URL+"/things", ""); got != expected {
+			t.Errorf("response %d: expected %q, got: %q", i, expected, got)
+		}
+	}
+
+	if requests := atomic.LoadInt32(&requests); requests != 2 {
+		t.Errorf("expected 2 requests to the server, got: %d", requests)
+	}
+
+	// Both interactions have been replayed.
+	if _, err := client.Post(server.URL+"/things", "text/plain", nil); err == nil {
+		t.Error("expected error for request without unreplayed interaction")
+	}
+}
+
+func TestRecorder_replayMissingCassette(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), &Options{
+		Mode: ModeReplay,
+	})
+
+	if err == nil {
+		t.Fatal("expected error for missing cassette")
+	}
+}
+
+func TestRecorder_replayMatcher(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	cassette := &Cassette{
+		Interactions: []*Interaction{
+			{
+				Request:  Request{Method: http.MethodPost, URL: "https://example.com/things", Body: "a"},
+				Response: Response{StatusCode: http.StatusOK, Body: "response a"},
+			},
+			{
+				Request:  Request{Method: http.MethodPost, URL: "https://example.com/things", Body: "b"},
+				Response: Response{StatusCode: http.StatusOK, Body: "response b"},
+			},
+		},
+	}
+
+	if err := cassette.save(path); err != nil {
+		t.Fatalf("unexpected error saving: %s", err)
+	}
+
+	rec, err := New(path, &Options{
+		Mode:    ModeReplay,
+		Matcher: BodyMatcher,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	client := &http.Client{Transport: rec}
+
+	if got := doRequest(t, client, "https://example.com/things", "b"); got != "response b" {
+		t.Errorf("expected response b, got: %q", got)
+	}
+
+	if got := doRequest(t, client, "https://example.com/things", "a"); got != "response a" {
+		t.Errorf("expected response a, got: %q", got)
+	}
+}
+
+func TestScrubHeaders(t *testing.T) {
+	t.Parallel()
+
+	i := &Interaction{
+		Request: Request{
+			Headers: http.Header{
+				"Authorization": []string{"Bearer secret"},
+				"X-Api-Key":     []string{"secret"},
+				"Accept":        []string{"application/json"},
+			},
+		},
+		Response: Response{
+			Headers: http.Header{
+				"X-Api-Key": []string{"secret"},
+			},
+		},
+	}
+
+	ScrubHeaders(append(defaultScrubbedHeaders, "x-api-key")...)(i)
+
+	if got := i.Request.Headers.Get("Authorization"); got != scrubbedValue {
+		t.Errorf("expected Authorization to be scrubbed, got: %q", got)
+	}
+
+	if got := i.Request.Headers.Get("X-Api-Key"); got != scrubbedValue {
+		t.Errorf("expected request X-Api-Key to be scrubbed, got: %q", got)
+	}
+
+	if got := i.Response.Headers.Get("X-Api-Key"); got != scrubbedValue {
+		t.Errorf("expected response X-Api-Key to be scrubbed, got: %q", got)
+	}
+
+	if got := i.Request.Headers.Get("Accept"); got != "application/json" {
+		t.Errorf("expected Accept to be unchanged, got: %q", got)
+	}
+
+	if _, ok := i.Response.Headers["Set-Cookie"]; ok {
+		t.Error("expected absent header to remain absent")
+	}
+}
+
+func doRequest(t *testing.T, client *http.Client, url, body string) string {
+	t.Helper()
+
+	resp, err := client.Post(url, "text/plain", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error reading body: %s", err)
+	}
+
+	return string(data)
+}
@@ -80,6 +80,16 @@ type StateRefreshFunc = retry.StateRefreshFunc
 // testing to terraform-plugin-testing.
 type StateChangeConf = retry.StateChangeConf
 
+// WaitForAll waits for several objects, or several aspects of one object,
+// to each reach the Target state of their StateChangeConf, sharing the given
+// timeout.
+//
+// Deprecated: Use helper/retry package instead. This is required for migrating acceptance
+// testing to terraform-plugin-testing.
+func WaitForAll(ctx context.Context, timeout time.Duration, confs ...*StateChangeConf) ([]interface{}, error) {
+	return retry.WaitForAll(ctx, timeout, confs...)
+}
+
 // RetryFunc is the function retried until it succeeds.
 //
 // Deprecated: Use helper/retry package instead. This is required for migrating acceptance
@@ -98,6 +108,15 @@ func RetryContext(ctx context.Context, timeout time.Duration, f RetryFunc) error
 	return retry.RetryContext(ctx, timeout, f)
 }
 
+// RetryWithBackoff is RetryContext with a configurable wait between calls of
+// the function, such as to add jitter when many resources poll the same API.
+//
+// Deprecated: Use helper/retry package instead. This is required for migrating acceptance
+// testing to terraform-plugin-testing.
+func RetryWithBackoff(ctx context.Context, timeout time.Duration, policy retry.Policy, f RetryFunc) error {
+	return retry.RetryWithBackoff(ctx, timeout, policy, f)
+}
+
 // Retry is a basic wrapper around StateChangeConf that will just retry
 // a function until it no longer returns an error.
 //
@@ -32,4 +32,13 @@ const (
 	// type Config field includes a provider source, such as the terraform
 	// configuration block required_providers attribute.
 	EnvTfAccProviderNamespace = "TF_ACC_PROVIDER_NAMESPACE"
+
+	// Environment variable with the maximum number of acceptance tests, whose
+	// TestCase does not enable the IsUnitTest field, which run their steps
+	// concurrently. Defaults to unlimited, in which only the go test
+	// -parallel flag limits concurrency. Tests waiting for another to finish
+	// still count towards the -parallel limit, so this is only useful when
+	// below it, such as to avoid API rate limits while unit tests run with
+	// full parallelism.
+	EnvTfAccParallelism = "TF_ACC_PARALLELISM"
 )
@@ -0,0 +1,260 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// PlanAction describes the action Terraform plans to take for a resource.
+type PlanAction string
+
+const (
+	// PlanActionNoOp means the resource is unchanged.
+	PlanActionNoOp PlanAction = "no-op"
+
+	// PlanActionCreate means the resource will be created.
+	PlanActionCreate PlanAction = "create"
+
+	// PlanActionRead means the data source will be read during apply.
+	PlanActionRead PlanAction = "read"
+
+	// PlanActionUpdate means the resource will be updated in-place.
+	PlanActionUpdate PlanAction = "update"
+
+	// PlanActionReplace means the resource will be destroyed and created
+	// again, in either order.
+	PlanActionReplace PlanAction = "replace"
+
+	// PlanActionDelete means the resource will be destroyed.
+	PlanActionDelete PlanAction = "delete"
+)
+
+// PlanSummary is a stable representation of a Terraform plan for use with
+// TestStep.PlanCheck. It only exposes the information commonly needed for
+// test assertions, so it is unaffected by changes to the Terraform plan
+// format.
+type PlanSummary struct {
+	// ResourceChanges contains an entry for every resource and data source
+	// instance in the plan, including those without changes, sorted by
+	// Address.
+	ResourceChanges []PlanResourceChange
+}
+
+// PlanResourceChange is the planned change of a single resource or data
+// source instance within a PlanSummary.
+type PlanResourceChange struct {
+	// Address is the absolute address of the instance, such as
+	// "myprovider_thing.example", "myprovider_thing.example[0]",
+	// "data.myprovider_thing.example", or
+	// "module.child.myprovider_thing.example".
+	Address string
+
+	// Action is the planned action for the instance.
+	Action PlanAction
+
+	// ChangedAttributes contains the sorted names of top level attributes
+	// whose values differ between the prior state and the plan, including
+	// attributes whose values will not be known until apply.
+	ChangedAttributes []string
+
+	// UnknownAttributes contains the sorted flatmap keys, such as "id" or
+	// "rule.0.etag", of the attributes and nested values which will not be
+	// known until apply. Nested values of an unknown attribute are not
+	// included separately.
+	UnknownAttributes []string
+}
+
+// ResourceChange returns the planned change for the instance with the given
+// address, or nil if the instance is not in the plan.
+func (s *PlanSummary) ResourceChange(address string) *PlanResourceChange {
+	if s == nil {
+		return nil
+	}
+
+	for i := range s.ResourceChanges {
+		if s.ResourceChanges[i].Address == address {
+			return &s.ResourceChanges[i]
+		}
+	}
+
+	return nil
+}
+
+// PlanSummaryCheckFunc is the callback type used with TestStep.PlanCheck. It
+// receives the summary of the plan for the TestStep configuration and
+// returns an error to fail the TestStep.
+type PlanSummaryCheckFunc func(plan *PlanSummary) error
+
+// ComposePlanChecks lets you compose multiple PlanSummaryCheckFunc into a
+// single PlanSummaryCheckFunc for use with TestStep.PlanCheck. It runs all
+// of the checks and aggregates failures, like
+// ComposeAggregateTestCheckFunc.
+func ComposePlanChecks(fs ...PlanSummaryCheckFunc) PlanSummaryCheckFunc {
+	return func(plan *PlanSummary) error {
+		var result []error
+
+		for i, f := range fs {
+			if err := f(plan); err != nil {
+				result = append(result, fmt.Errorf("Plan check %d/%d error: %w", i+1, len(fs), err))
+			}
+		}
+
+		return errors.Join(result...)
+	}
+}
+
+// ExpectUnknownValue returns a PlanSummaryCheckFunc for use with
+// TestStep.PlanCheck which fails if the value at the given path of the
+// instance with the given address will be known before apply, such as a
+// Computed attribute which is incorrectly resolved during plan.
+func ExpectUnknownValue(address string, path cty.Path) PlanSummaryCheckFunc {
+	return func(plan *PlanSummary) error {
+		change := plan.ResourceChange(address)
+
+		if change == nil {
+			return fmt.Errorf("%s: not found in plan", address)
+		}
+
+		key, err := planAttributeKey(path)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", address, err)
+		}
+
+		if !change.isUnknown(key) {
+			return fmt.Errorf("%s: Attribute '%s' expected to be unknown until apply, got a known value", address, key)
+		}
+
+		return nil
+	}
+}
+
+// isUnknown returns whether the value with the given flatmap key, or any
+// value containing it, will not be known until apply.
+func (c *PlanResourceChange) isUnknown(key string) bool {
+	for _, unknown := range c.UnknownAttributes {
+		if key == unknown || strings.HasPrefix(key, unknown+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// planAttributeKey returns the flatmap key of the given path.
+func planAttributeKey(path cty.Path) (string, error) {
+	parts := make([]string, 0, len(path))
+
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			parts = append(parts, step.Name)
+		case cty.IndexStep:
+			switch step.Key.Type() {
+			case cty.String:
+				parts = append(parts, step.Key.AsString())
+			case cty.Number:
+				i, _ := step.Key.AsBigFloat().Int64()
+				parts = append(parts, strconv.FormatInt(i, 10))
+			default:
+				return "", fmt.Errorf("unsupported index type in path: %s", step.Key.Type().FriendlyName())
+			}
+		}
+	}
+
+	if len(parts) == 0 {
+		return "", fmt.Errorf("empty attribute path")
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// unknownPlanAttributes appends the flatmap keys of the values marked as
+// unknown in the given after_unknown value of a plan.
+func unknownPlanAttributes(prefix string, v interface{}, result []string) []string {
+	switch v := v.(type) {
+	case bool:
+		if v && prefix != "" {
+			result = append(result, prefix)
+		}
+	case map[string]interface{}:
+		for k, nested := range v {
+			result = unknownPlanAttributes(planAttributeKeyJoin(prefix, k), nested, result)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			result = unknownPlanAttributes(planAttributeKeyJoin(prefix, strconv.Itoa(i)), nested, result)
+		}
+	}
+
+	return result
+}
+
+func planAttributeKeyJoin(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+// newPlanSummary returns the PlanSummary of the given plan.
+func newPlanSummary(plan *tfjson.Plan) *PlanSummary {
+	summary := &PlanSummary{}
+
+	if plan == nil {
+		return summary
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		if rc == nil || rc.Change == nil {
+			continue
+		}
+
+		change := PlanResourceChange{
+			Address: rc.Address,
+			Action:  planAction(rc.Change.Actions),
+		}
+
+		if change.Action != PlanActionNoOp {
+			change.ChangedAttributes = changedPlanAttributeNames(rc.Change)
+		}
+
+		change.UnknownAttributes = unknownPlanAttributes("", rc.Change.AfterUnknown, nil)
+		sort.Strings(change.UnknownAttributes)
+
+		summary.ResourceChanges = append(summary.ResourceChanges, change)
+	}
+
+	sort.SliceStable(summary.ResourceChanges, func(i, j int) bool {
+		return summary.ResourceChanges[i].Address < summary.ResourceChanges[j].Address
+	})
+
+	return summary
+}
+
+func planAction(actions tfjson.Actions) PlanAction {
+	switch {
+	case actions.Replace():
+		return PlanActionReplace
+	case actions.Create():
+		return PlanActionCreate
+	case actions.Read():
+		return PlanActionRead
+	case actions.Update():
+		return PlanActionUpdate
+	case actions.Delete():
+		return PlanActionDelete
+	default:
+		return PlanActionNoOp
+	}
+}
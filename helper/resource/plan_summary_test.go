@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestNewPlanSummary(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.update",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before:  map[string]interface{}{"id": "1", "name": "a", "etag": "x"},
+					After:   map[string]interface{}{"id": "1", "name": "b"},
+					AfterUnknown: map[string]interface{}{
+						"etag": true,
+					},
+				},
+			},
+			{
+				Address: "test_resource.noop",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionNoop},
+					Before:  map[string]interface{}{"id": "2"},
+					After:   map[string]interface{}{"id": "2"},
+				},
+			},
+			{
+				Address: "module.child.test_resource.replace[0]",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate},
+					Before:  map[string]interface{}{"id": "3", "zone": "a"},
+					After:   map[string]interface{}{"zone": "b"},
+					AfterUnknown: map[string]interface{}{
+						"id": true,
+					},
+				},
+			},
+			{
+				Address: "test_resource.create",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After:   map[string]interface{}{"name": "c"},
+				},
+			},
+			{
+				Address: "data.test_data_source.read",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionRead},
+				},
+			},
+			{
+				Address: "test_resource.delete",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionDelete},
+					Before:  map[string]interface{}{"id": "4"},
+				},
+			},
+		},
+	}
+
+	expected := &PlanSummary{
+		ResourceChanges: []PlanResourceChange{
+			{
+				Address: "data.test_data_source.read",
+				Action:  PlanActionRead,
+			},
+			{
+				Address:           "module.child.test_resource.replace[0]",
+				Action:            PlanActionReplace,
+				ChangedAttributes: []string{"id", "zone"},
+				UnknownAttributes: []string{"id"},
+			},
+			{
+				Address:           "test_resource.create",
+				Action:            PlanActionCreate,
+				ChangedAttributes: []string{"name"},
+			},
+			{
+				Address:           "test_resource.delete",
+				Action:            PlanActionDelete,
+				ChangedAttributes: []string{"id"},
+			},
+			{
+				Address: "test_resource.noop",
+				Action:  PlanActionNoOp,
+			},
+			{
+				Address:           "test_resource.update",
+				Action:            PlanActionUpdate,
+				ChangedAttributes: []string{"etag", "name"},
+				UnknownAttributes: []string{"etag"},
+			},
+		},
+	}
+
+	summary := newPlanSummary(plan)
+
+	if diff := cmp.Diff(expected, summary); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	if got := summary.ResourceChange("test_resource.update"); got == nil || got.Action != PlanActionUpdate {
+		t.Errorf("expected update for test_resource.update, got: %v", got)
+	}
+
+	if got := summary.ResourceChange("test_resource.missing"); got != nil {
+		t.Errorf("expected nil for test_resource.missing, got: %v", got)
+	}
+}
+
+func TestExpectUnknownValue(t *testing.T) {
+	t.Parallel()
+
+	summary := newPlanSummary(&tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.test",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After: map[string]interface{}{
+						"name": "a",
+						"rule": []interface{}{
+							map[string]interface{}{"port": float64(80)},
+						},
+						"tags": map[string]interface{}{"env": "test"},
+					},
+					AfterUnknown: map[string]interface{}{
+						"id":      true,
+						"outputs": true,
+						"rule": []interface{}{
+							map[string]interface{}{"etag": true},
+						},
+						"tags": map[string]interface{}{},
+					},
+				},
+			},
+		},
+	})
+
+	testCases := map[string]struct {
+		address       string
+		path          cty.Path
+		expectedError error
+	}{
+		"unknown attribute": {
+			address: "test_resource.test",
+			path:    cty.GetAttrPath("id"),
+		},
+		"unknown nested value": {
+			address: "test_resource.test",
+			path:    cty.GetAttrPath("rule").IndexInt(0).GetAttr("etag"),
+		},
+		"nested value of unknown attribute": {
+			address: "test_resource.test",
+			path:    cty.GetAttrPath("outputs").IndexString("key"),
+		},
+		"known attribute": {
+			address:       "test_resource.test",
+			path:          cty.GetAttrPath("name"),
+			expectedError: fmt.Errorf("test_resource.test: Attribute 'name' expected to be unknown until apply, got a known value"),
+		},
+		"known nested value": {
+			address:       "test_resource.test",
+			path:          cty.GetAttrPath("rule").IndexInt(0).GetAttr("port"),
+			expectedError: fmt.Errorf("test_resource.test: Attribute 'rule.0.port' expected to be unknown until apply, got a known value"),
+		},
+		"resource not found": {
+			address:       "test_resource.missing",
+			path:          cty.GetAttrPath("id"),
+			expectedError: fmt.Errorf("test_resource.missing: not found in plan"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ExpectUnknownValue(testCase.address, testCase.path)(summary)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+
+				if !strings.Contains(err.Error(), testCase.expectedError.Error()) {
+					t.Fatalf("expected error %q, got: %s", testCase.expectedError, err)
+				}
+			}
+
+			if err == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestComposePlanChecks(t *testing.T) {
+	t.Parallel()
+
+	summary := newPlanSummary(&tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.test",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After: map[string]interface{}{
+						"name": "a",
+					},
+					AfterUnknown: map[string]interface{}{
+						"id": true,
+					},
+				},
+			},
+		},
+	})
+
+	testCases := map[string]struct {
+		checks        []PlanSummaryCheckFunc
+		expectedError string
+	}{
+		"none": {},
+		"pass": {
+			checks: []PlanSummaryCheckFunc{
+				ExpectUnknownValue("test_resource.test", cty.GetAttrPath("id")),
+				func(plan *PlanSummary) error {
+					if plan.ResourceChange("test_resource.test").Action != PlanActionCreate {
+						return fmt.Errorf("expected create")
+					}
+
+					return nil
+				},
+			},
+		},
+		"aggregate": {
+			checks: []PlanSummaryCheckFunc{
+				ExpectUnknownValue("test_resource.test", cty.GetAttrPath("name")),
+				ExpectUnknownValue("test_resource.test", cty.GetAttrPath("id")),
+				ExpectUnknownValue("test_resource.missing", cty.GetAttrPath("id")),
+			},
+			expectedError: "Plan check 1/3 error: test_resource.test: Attribute 'name' expected to be unknown until apply, got a known value\n" +
+				"Plan check 3/3 error: test_resource.missing: not found in plan",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ComposePlanChecks(testCase.checks...)(summary)
+
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil || err.Error() != testCase.expectedError {
+				t.Fatalf("expected error %q, got: %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
@@ -106,6 +106,72 @@ func (pf sdkProviderFactories) merge(otherPfs ...sdkProviderFactories) sdkProvid
 	return result
 }
 
+// ProviderFactories returns a map, suitable for TestCase.ProviderFactories or
+// TestStep.ProviderFactories, whose only entry starts the provider returned by
+// newProvider under the given name. newProvider is called each time the
+// factory is invoked, so that each Terraform command, and each test running
+// with ParallelTest, is served by its own provider instance. For example:
+//
+//	resource.Test(t, resource.TestCase{
+//	  ProviderFactories: resource.ProviderFactories("example", Provider),
+//	  // ...
+//	})
+func ProviderFactories(name string, newProvider func() *schema.Provider) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		name: func() (*schema.Provider, error) {
+			return newProvider(), nil
+		},
+	}
+}
+
+// MergeProviderFactories combines the given SDK provider factory maps into
+// a new map, such as to add shared external provider factories to those of
+// the provider under test.
+//
+// In case of an overlapping entry, the later entry will overwrite the previous
+// value.
+func MergeProviderFactories(maps ...map[string]func() (*schema.Provider, error)) map[string]func() (*schema.Provider, error) {
+	result := make(sdkProviderFactories)
+
+	for _, m := range maps {
+		result = result.merge(m)
+	}
+
+	return result
+}
+
+// MergeProtoV5ProviderFactories combines the given protocol version 5
+// provider factory maps, such as those of muxed providers, into a new map
+// suitable for TestCase.ProtoV5ProviderFactories.
+//
+// In case of an overlapping entry, the later entry will overwrite the previous
+// value.
+func MergeProtoV5ProviderFactories(maps ...map[string]func() (tfprotov5.ProviderServer, error)) map[string]func() (tfprotov5.ProviderServer, error) {
+	result := make(protov5ProviderFactories)
+
+	for _, m := range maps {
+		result = result.merge(m)
+	}
+
+	return result
+}
+
+// MergeProtoV6ProviderFactories combines the given protocol version 6
+// provider factory maps, such as those of muxed providers, into a new map
+// suitable for TestCase.ProtoV6ProviderFactories.
+//
+// In case of an overlapping entry, the later entry will overwrite the previous
+// value.
+func MergeProtoV6ProviderFactories(maps ...map[string]func() (tfprotov6.ProviderServer, error)) map[string]func() (tfprotov6.ProviderServer, error) {
+	result := make(protov6ProviderFactories)
+
+	for _, m := range maps {
+		result = result.merge(m)
+	}
+
+	return result
+}
+
 type providerFactories struct {
 	legacy  sdkProviderFactories
 	protov5 protov5ProviderFactories
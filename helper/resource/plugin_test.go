@@ -242,6 +242,152 @@ func TestSdkProviderFactoriesMerge(t *testing.T) {
 	}
 }
 
+func TestProviderFactories(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+
+	factories := ProviderFactories("test", func() *schema.Provider {
+		calls++
+
+		return &schema.Provider{}
+	})
+
+	if len(factories) != 1 {
+		t.Fatalf("expected 1 provider factory, got %d", len(factories))
+	}
+
+	first, err := factories["test"]()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	second, err := factories["test"]()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected provider constructor to be called 2 times, got %d", calls)
+	}
+
+	if first == second {
+		t.Errorf("expected each provider factory call to return a new provider")
+	}
+}
+
+func TestMergeProviderFactories(t *testing.T) {
+	t.Parallel()
+
+	p1 := &schema.Provider{}
+	p2 := &schema.Provider{}
+	p3 := &schema.Provider{}
+
+	got := MergeProviderFactories(
+		ProviderFactories("test1", func() *schema.Provider { return p1 }),
+		ProviderFactories("test2", func() *schema.Provider { return p2 }),
+		nil,
+		ProviderFactories("test2", func() *schema.Provider { return p3 }),
+	)
+
+	expected := map[string]*schema.Provider{
+		"test1": p1,
+		"test2": p3,
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d provider factories, got %d", len(expected), len(got))
+	}
+
+	for name, want := range expected {
+		p, err := got[name]()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if p != want {
+			t.Errorf("unexpected provider for %s", name)
+		}
+	}
+}
+
+func TestMergeProtoV5ProviderFactories(t *testing.T) {
+	t.Parallel()
+
+	testProviderFactory1 := func() (tfprotov5.ProviderServer, error) {
+		return nil, nil
+	}
+	testProviderFactory2 := func() (tfprotov5.ProviderServer, error) {
+		return nil, nil
+	}
+
+	transformer := cmp.Transformer(
+		"protov5ProviderFactory",
+		func(pf func() (tfprotov5.ProviderServer, error)) string {
+			return fmt.Sprintf("%p", pf)
+		},
+	)
+
+	got := MergeProtoV5ProviderFactories(
+		map[string]func() (tfprotov5.ProviderServer, error){
+			"test1": testProviderFactory1,
+			"test2": testProviderFactory1,
+		},
+		map[string]func() (tfprotov5.ProviderServer, error){
+			"test2": testProviderFactory2,
+		},
+	)
+
+	expected := map[string]func() (tfprotov5.ProviderServer, error){
+		"test1": testProviderFactory1,
+		"test2": testProviderFactory2,
+	}
+
+	if diff := cmp.Diff(got, expected, transformer); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestMergeProtoV6ProviderFactories(t *testing.T) {
+	t.Parallel()
+
+	testProviderFactory1 := func() (tfprotov6.ProviderServer, error) {
+		return nil, nil
+	}
+	testProviderFactory2 := func() (tfprotov6.ProviderServer, error) {
+		return nil, nil
+	}
+
+	transformer := cmp.Transformer(
+		"protov6ProviderFactory",
+		func(pf func() (tfprotov6.ProviderServer, error)) string {
+			return fmt.Sprintf("%p", pf)
+		},
+	)
+
+	got := MergeProtoV6ProviderFactories(
+		map[string]func() (tfprotov6.ProviderServer, error){
+			"test1": testProviderFactory1,
+			"test2": testProviderFactory1,
+		},
+		map[string]func() (tfprotov6.ProviderServer, error){
+			"test2": testProviderFactory2,
+		},
+	)
+
+	expected := map[string]func() (tfprotov6.ProviderServer, error){
+		"test1": testProviderFactory1,
+		"test2": testProviderFactory2,
+	}
+
+	if diff := cmp.Diff(got, expected, transformer); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
 func TestRunProviderCommand(t *testing.T) {
 	currentDir, err := os.Getwd()
 
@@ -11,15 +11,18 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/mitchellh/go-testing-interface"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/recorder"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/addrs"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/logging"
@@ -52,6 +55,10 @@ var flagSweepAllowFailures = flag.Bool("sweep-allow-failures", false, "Enable to
 var flagSweepRun = flag.String("sweep-run", "", "Comma seperated list of Sweeper Tests to run")
 var sweeperFuncs map[string]*Sweeper
 
+// providerSweeperNames tracks the sweepers registered by
+// AddTestSweepersFromProvider, which AddTestSweepers may override.
+var providerSweeperNames map[string]bool
+
 // SweeperFunc is a signature for a function that acts as a sweeper. It
 // accepts a string for the region that the sweeper is to be ran in. This
 // function must be able to construct a valid client for that region.
@@ -73,6 +80,7 @@ type Sweeper struct {
 
 func init() {
 	sweeperFuncs = make(map[string]*Sweeper)
+	providerSweeperNames = make(map[string]bool)
 }
 
 // AddTestSweepers function adds a given name and Sweeper configuration
@@ -80,14 +88,81 @@ func init() {
 // resource sweeper to be available for running when the -sweep flag is used
 // with `go test`. Sweeper names must be unique to help ensure a given sweeper
 // is only ran once per run.
+//
+// A sweeper registered by AddTestSweepersFromProvider can be overridden by
+// adding a sweeper with the same name, the resource type.
 func AddTestSweepers(name string, s *Sweeper) {
-	if _, ok := sweeperFuncs[name]; ok {
+	if _, ok := sweeperFuncs[name]; ok && !providerSweeperNames[name] {
 		log.Fatalf("[ERR] Error adding (%s) to sweeperFuncs: function already exists in map", name)
 	}
 
+	delete(providerSweeperNames, name)
+
 	sweeperFuncs[name] = s
 }
 
+// AddTestSweepersFromProvider registers a sweeper, named after the resource
+// type, for every managed resource type in the provider ResourcesMap. Each
+// sweeper calls sweepFn with the sweep region, its resource type, and the
+// provider meta, as returned by Provider.Meta when the sweeper runs. The
+// provider must be configured before sweeping, such as in TestMain before
+// calling resource.TestMain, otherwise the sweepers return an error.
+//
+// The dependencies map lists, for each resource type, the names of other
+// sweepers, such as other resource types, which must be ran before that
+// resource type is swept, as in Sweeper.Dependencies. It may be nil.
+//
+// Resource types with a sweeper already registered via AddTestSweepers are
+// skipped, and AddTestSweepers may be called afterwards to replace the
+// sweeper of any resource type with a custom one. Sweepers, including custom
+// ones, can order themselves after any of these resource type sweepers by
+// listing them in Sweeper.Dependencies, for example:
+//
+//	func init() {
+//	  resource.AddTestSweepersFromProvider(Provider(), map[string][]string{
+//	    "example_subnet": {"example_instance"},
+//	  }, sweepByType)
+//
+//	  resource.AddTestSweepers("example_network", &resource.Sweeper{
+//	    Name:         "example_network",
+//	    Dependencies: []string{"example_instance", "example_subnet"},
+//	    F:            sweepNetworks,
+//	  })
+//	}
+func AddTestSweepersFromProvider(p *schema.Provider, dependencies map[string][]string, sweepFn func(region, resourceType string, meta interface{}) error) {
+	resourceTypes := make([]string, 0, len(p.ResourcesMap))
+
+	for resourceType := range p.ResourcesMap {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	slices.Sort(resourceTypes)
+
+	for _, resourceType := range resourceTypes {
+		if _, ok := sweeperFuncs[resourceType]; ok {
+			log.Printf("[DEBUG] Sweeper (%s) already exists, skipping provider sweeper", resourceType)
+			continue
+		}
+
+		resourceType := resourceType
+
+		sweeperFuncs[resourceType] = &Sweeper{
+			Name:         resourceType,
+			Dependencies: dependencies[resourceType],
+			F: func(region string) error {
+				meta := p.Meta()
+
+				if meta == nil {
+					return fmt.Errorf("provider is not configured, configure it before sweeping %s", resourceType)
+				}
+
+				return sweepFn(region, resourceType, meta)
+			},
+		}
+		providerSweeperNames[resourceType] = true
+	}
+}
+
 // TestMain adds sweeper functionality to the "go test" command, otherwise
 // tests are executed as normal. Most provider acceptance tests are written
 // using the Test() function of this package, which imposes its own
@@ -294,6 +369,62 @@ type ImportStateCheckFunc func([]*terraform.InstanceState) error
 // generation for ImportState tests.
 type ImportStateIdFunc func(*terraform.State) (string, error)
 
+// ImportStateIdByAttribute returns an ImportStateIdFunc which uses the value
+// of the given attribute of the named resource in the prior state as the
+// import identifier, such as for resources which are imported by name or
+// ARN rather than by ID.
+//
+// The key parameter is an attribute path in flatmap syntax. Use the sentinel
+// value '*' to replace the element indexing into a list or set, in which
+// case all matching elements must have the same value. An error is returned
+// if the attribute is absent or empty.
+func ImportStateIdByAttribute(name, key string) ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		is, err := primaryInstanceState(s, name)
+		if err != nil {
+			return "", err
+		}
+
+		keyParts := strings.Split(key, ".")
+
+		var id string
+
+		for k, v := range is.Attributes {
+			if v == "" || !flatmapKeyMatches(strings.Split(k, "."), keyParts) {
+				continue
+			}
+
+			if id != "" && id != v {
+				return "", fmt.Errorf("%s: Attribute '%s' matches multiple values, %q and %q", name, key, id, v)
+			}
+
+			id = v
+		}
+
+		if id == "" {
+			return "", fmt.Errorf("%s: Attribute '%s' not found or empty", name, key)
+		}
+
+		return id, nil
+	}
+}
+
+// flatmapKeyMatches returns whether the parts of a flatmap key match the
+// parts of a pattern, where the sentinel value '*' matches any part.
+func flatmapKeyMatches(keyParts, patternParts []string) bool {
+	if len(keyParts) != len(patternParts) {
+		return false
+	}
+
+	for i, part := range patternParts {
+		if part != sentinelIndex && part != keyParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // ErrorCheckFunc is a function providers can use to handle errors.
 type ErrorCheckFunc func(error) error
 
@@ -493,11 +624,42 @@ type TestStep struct {
 	// looking to verify that a diff occurs
 	ExpectNonEmptyPlan bool
 
+	// PostApplyPlanChecks are called with each plan created after the Config
+	// is applied to detect perpetual differences: first the plan created
+	// directly after the apply, then the plan created after a refresh. If
+	// any returns an error, the TestStep fails. They are called before each
+	// plan is checked against ExpectNonEmptyPlan, so they can report a more
+	// specific error, such as TestCheckNoPerpetualDiff.
+	//
+	// This is only valid with Config and not with ImportState.
+	PostApplyPlanChecks []PostApplyPlanCheckFunc
+
+	// PlanCheck is called with a summary of the plan for the Config, before
+	// it is applied. For PlanOnly steps, it is called with the plan which is
+	// checked against ExpectNonEmptyPlan instead. If an error is returned,
+	// the TestStep fails.
+	//
+	// Unlike PostApplyPlanChecks, the PlanSummary is owned by this package
+	// and does not depend on the Terraform plan format. Use
+	// ComposePlanChecks to combine several checks, such as
+	// ExpectUnknownValue.
+	//
+	// This is only valid with Config and not with ImportState.
+	PlanCheck PlanSummaryCheckFunc
+
 	// ExpectError allows the construction of test cases that we expect to fail
 	// with an error. The specified regexp must match against the error for the
 	// test to pass.
 	ExpectError *regexp.Regexp
 
+	// ExpectErrorPhase, if set, requires the error matching ExpectError to
+	// be returned by the Terraform CLI command run during the given phase,
+	// so that, for example, a plan error does not satisfy an error expected
+	// during apply. Errors from checks, such as Check or a non-empty plan,
+	// are not produced by any phase. This is only valid with ExpectError and
+	// not with ImportState.
+	ExpectErrorPhase TestStepPhase
+
 	// PlanOnly can be set to only run `plan` with this configuration, and not
 	// actually apply it. This is useful for ensuring config changes result in
 	// no-op plans
@@ -582,6 +744,17 @@ type TestStep struct {
 	// at the end of the test step that is verifying import behavior.
 	ImportStatePersist bool
 
+	// ImportStateVerifyAndPlan, if true, will run a plan with the step
+	// configuration after the import and fail if it plans any change to the
+	// resource named by ResourceName. This is stronger than
+	// ImportStateVerify, confirming that the imported state produces no
+	// changes against the configuration rather than only that its
+	// attributes match, and so respects DiffSuppressFunc and CustomizeDiff.
+	// Changes to other resources are ignored, since unless
+	// ImportStatePersist is enabled the import state contains only the
+	// imported resource. Only valid with ImportState.
+	ImportStateVerifyAndPlan bool
+
 	//---------------------------------------------------------------
 	// RefreshState testing
 	//---------------------------------------------------------------
@@ -598,6 +771,41 @@ type TestStep struct {
 	// with ImportState.
 	RefreshState bool
 
+	//---------------------------------------------------------------
+	// API call testing
+	//---------------------------------------------------------------
+
+	// APICallRecorder is the recorder through which the provider under test
+	// sends its outgoing HTTP requests. It is reset at the start of the
+	// TestStep. Refer to the APICallRecorder type documentation for how to
+	// inject it into a provider.
+	APICallRecorder *APICallRecorder
+
+	// ExpectAPICalls, if non-nil, is the exact sequence of requests the
+	// provider is expected to make during this TestStep, as captured by
+	// APICallRecorder. The TestStep fails with a difference if any expected
+	// call is missing or any unexpected call was made. This catches
+	// over-fetching introduced by refactors. An empty, non-nil slice asserts
+	// that no requests were made.
+	//
+	// APICallRecorder must be set when ExpectAPICalls is set.
+	ExpectAPICalls []APICall
+
+	// ExpectAPICallsIgnoreOrder, if true, compares ExpectAPICalls against
+	// the recorded requests as a multiset rather than a sequence. This is
+	// useful when Terraform operates on multiple resources concurrently.
+	ExpectAPICallsIgnoreOrder bool
+
+	// Recorder, if set, is the helper/recorder Recorder through which the
+	// provider under test sends its outgoing HTTP requests, recording them to
+	// or replaying them from a cassette file. When recording, the cassette is
+	// saved at the end of the TestStep, so TestStep sharing a Recorder build
+	// up a single cassette, and again after the post-test destroy and
+	// CheckDestroy, so their requests can also be replayed. Refer to the
+	// helper/recorder package documentation for how to inject it into a
+	// provider.
+	Recorder *recorder.Recorder
+
 	// ProviderFactories can be specified for the providers that are valid for
 	// this TestStep. When providers are specified at the TestStep level, all
 	// TestStep within a TestCase must declare providers.
@@ -669,7 +877,8 @@ type TestStep struct {
 
 // ParallelTest performs an acceptance test on a resource, allowing concurrency
 // with other ParallelTest. The number of concurrent tests is controlled by the
-// "go test" command -parallel flag.
+// "go test" command -parallel flag, and can be further limited with the
+// TF_ACC_PARALLELISM environment variable.
 //
 // Tests will fail if they do not properly handle conditions to allow multiple
 // tests to occur against the same resource or service (e.g. random naming).
@@ -710,6 +919,9 @@ func ParallelTest(t testing.T, c TestCase) {
 //     CLI binary based on the operating system PATH. If not found, the
 //     latest available Terraform CLI binary is installed.
 //
+// The TF_ACC_PARALLELISM environment variable can limit how many acceptance
+// tests run their steps at once, such as those started with ParallelTest.
+//
 // Refer to the Env prefixed constants for additional details about these
 // environment variables, and others, that control testing functionality.
 func Test(t testing.T, c TestCase) {
@@ -738,6 +950,18 @@ func Test(t testing.T, c TestCase) {
 		return
 	}
 
+	// Limit the number of acceptance tests which create real resources at
+	// once, if requested. Unit tests are not limited.
+	if !c.IsUnitTest {
+		release, err := acquireAccParallelism()
+
+		if err != nil {
+			t.Fatalf("Test validation error: %s", err)
+		}
+
+		defer release()
+	}
+
 	// Copy any explicitly passed providers to factories, this is for backwards compatibility.
 	if len(c.Providers) > 0 {
 		c.ProviderFactories = map[string]func() (*schema.Provider, error){}
@@ -1051,6 +1275,138 @@ func testCheckResourceAttr(is *terraform.InstanceState, name string, key string,
 	return nil
 }
 
+// TestCheckResourceAttrCount ensures the list, set, or map attribute at the
+// given name and key combination has the expected number of elements. The
+// key is the attribute path without a count suffix, as the .# suffix of
+// lists and sets or the .% suffix of maps is chosen based on the state.
+//
+// For example, both of these check example_thing.test has two tags:
+//
+//	resource.TestCheckResourceAttr("example_thing.test", "tags.%", "2")
+//	resource.TestCheckResourceAttrCount("example_thing.test", "tags", 2)
+//
+// Empty collections may be elided from the state, so an expected count of
+// zero also passes when the attribute is not found.
+func TestCheckResourceAttrCount(name, key string, expected int) TestCheckFunc {
+	return checkIfIndexesIntoTypeSet(key, func(s *terraform.State) error {
+		is, err := primaryInstanceState(s, name)
+		if err != nil {
+			return err
+		}
+
+		return testCheckResourceAttrCount(is, name, key, expected)
+	})
+}
+
+func testCheckResourceAttrCount(is *terraform.InstanceState, name string, key string, expected int) error {
+	for _, countKey := range []string{key + ".#", key + ".%"} {
+		v, ok := is.Attributes[countKey]
+
+		if !ok {
+			continue
+		}
+
+		if v != strconv.Itoa(expected) {
+			return fmt.Errorf(
+				"%s: Attribute '%s' expected %d elements, got %s",
+				name,
+				key,
+				expected,
+				v)
+		}
+
+		return nil
+	}
+
+	if expected == 0 {
+		return nil
+	}
+
+	if _, ok := is.Attributes[key]; ok {
+		return fmt.Errorf("%s: Attribute '%s' is not a list, set, or map", name, key)
+	}
+
+	return fmt.Errorf("%s: Attribute '%s' not found", name, key)
+}
+
+// TestCheckResourceAttrTags ensures the map attribute at the given name and
+// key combination, typically "tags", contains exactly the expected keys and
+// values. Any missing, extra, or different tags are reported together.
+//
+//	resource.TestCheckResourceAttrTags("example_thing.test", "tags", map[string]string{
+//	  "Environment": "test",
+//	  "Name":        "example",
+//	})
+//
+// Use TestCheckResourceAttrTagsContain to allow tags which are not expected,
+// such as those added by the remote system or by default tags.
+func TestCheckResourceAttrTags(name, tagsKey string, expected map[string]string) TestCheckFunc {
+	return func(s *terraform.State) error {
+		is, err := primaryInstanceState(s, name)
+		if err != nil {
+			return err
+		}
+
+		return testCheckResourceAttrTags(is, name, tagsKey, expected, true)
+	}
+}
+
+// TestCheckResourceAttrTagsContain ensures the map attribute at the given
+// name and key combination contains at least the expected keys and values,
+// as per TestCheckResourceAttrTags but ignoring extra tags.
+func TestCheckResourceAttrTagsContain(name, tagsKey string, expected map[string]string) TestCheckFunc {
+	return func(s *terraform.State) error {
+		is, err := primaryInstanceState(s, name)
+		if err != nil {
+			return err
+		}
+
+		return testCheckResourceAttrTags(is, name, tagsKey, expected, false)
+	}
+}
+
+func testCheckResourceAttrTags(is *terraform.InstanceState, name string, tagsKey string, expected map[string]string, exact bool) error {
+	prefix := tagsKey + "."
+	actual := make(map[string]string)
+
+	for k, v := range is.Attributes {
+		if !strings.HasPrefix(k, prefix) || k == tagsKey+".%" {
+			continue
+		}
+
+		actual[strings.TrimPrefix(k, prefix)] = v
+	}
+
+	var problems []string
+
+	for k, v := range expected {
+		got, ok := actual[k]
+
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("missing tag %q", k))
+		case got != v:
+			problems = append(problems, fmt.Sprintf("tag %q expected %q, got %q", k, v, got))
+		}
+	}
+
+	if exact {
+		for k := range actual {
+			if _, ok := expected[k]; !ok {
+				problems = append(problems, fmt.Sprintf("unexpected tag %q", k))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	slices.Sort(problems)
+
+	return fmt.Errorf("%s: Attribute '%s' tags do not match: %s", name, tagsKey, strings.Join(problems, ", "))
+}
+
 // CheckResourceAttrWithFunc is the callback type used to apply a custom checking logic
 // when using TestCheckResourceAttrWith and a value is found for the given name and key.
 //
@@ -1416,6 +1772,187 @@ func testCheckResourceAttrPair(isFirst *terraform.InstanceState, nameFirst strin
 	return nil
 }
 
+// TestCheckResourceMatchesDataSource is a TestCheckFunc which ensures that
+// the given attributes of a managed resource and a data source for the same
+// entity are equal, such as:
+//
+//	resource.TestCheckResourceMatchesDataSource("example_thing.test", "data.example_thing.test", "name", "tags")
+//
+// Each key is an attribute path in flatmap syntax, as with
+// TestCheckResourceAttrPair, and also matches all nested values below it, so
+// "tags" compares every element of a tags map. As with
+// TestCheckResourceAttrPair, unset and zero container counts are considered
+// equal. All differing attributes are reported in a single error.
+func TestCheckResourceMatchesDataSource(resourceAddr, dataSourceAddr string, keys ...string) TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(keys) == 0 {
+			return fmt.Errorf("no attributes given to compare %s and %s", resourceAddr, dataSourceAddr)
+		}
+
+		resourceState, err := primaryInstanceState(s, resourceAddr)
+		if err != nil {
+			return err
+		}
+
+		dataSourceState, err := primaryInstanceState(s, dataSourceAddr)
+		if err != nil {
+			return err
+		}
+
+		resourceAttrs := matchingAttributes(resourceState.Attributes, keys)
+		dataSourceAttrs := matchingAttributes(dataSourceState.Attributes, keys)
+
+		if diff := cmp.Diff(resourceAttrs, dataSourceAttrs); diff != "" {
+			return fmt.Errorf("%s and %s attributes differ. Difference is shown below. The - symbol indicates resource values, the + symbol indicates data source values.\n\n%s", resourceAddr, dataSourceAddr, diff)
+		}
+
+		return nil
+	}
+}
+
+// matchingAttributes returns the flatmap attributes which are, or are nested
+// below, any of the keys. Zero container counts are omitted, as they are not
+// reliably maintained by helper/schema.
+func matchingAttributes(attributes map[string]string, keys []string) map[string]string {
+	result := make(map[string]string)
+
+	for k, v := range attributes {
+		if (strings.HasSuffix(k, ".#") || strings.HasSuffix(k, ".%")) && (v == "0" || v == "") {
+			continue
+		}
+
+		for _, key := range keys {
+			if k == key || strings.HasPrefix(k, key+".") {
+				result[k] = v
+
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// TestCheckResourcesInvariant is a TestCheckFunc which runs an arbitrary
+// check against the whole state, for assertions spanning several resources
+// or needing a transformation which TestCheckResourceAttrPair cannot express.
+// Use InstanceState within the check to look up resource instances by
+// address. For example:
+//
+//	resource.TestCheckResourcesInvariant(func(s *terraform.State) error {
+//	  vpc, err := resource.InstanceState(s, "example_vpc.test")
+//	  if err != nil {
+//	    return err
+//	  }
+//
+//	  subnet, err := resource.InstanceState(s, "module.network.example_subnet.test")
+//	  if err != nil {
+//	    return err
+//	  }
+//
+//	  if subnet.Attributes["vpc_id"] != vpc.ID {
+//	    return fmt.Errorf("subnet vpc_id %q does not match vpc id %q", subnet.Attributes["vpc_id"], vpc.ID)
+//	  }
+//
+//	  return nil
+//	})
+//
+// Any error returned by the check fails the TestStep.
+func TestCheckResourcesInvariant(check func(s *terraform.State) error) TestCheckFunc {
+	return func(s *terraform.State) error {
+		if s == nil {
+			return errors.New("resources invariant: state is nil")
+		}
+
+		if err := check(s); err != nil {
+			return fmt.Errorf("resources invariant: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// InstanceState returns the primary instance state of the resource at the
+// given address. The address is the resource name as used by the other
+// TestCheck functions, such as "myprovider_thing.example" or
+// "data.myprovider_thing.example", optionally prefixed with one or more
+// "module.NAME." segments for resources in non-root modules, such as
+// "module.child.myprovider_thing.example".
+func InstanceState(s *terraform.State, addr string) (*terraform.InstanceState, error) {
+	if s == nil {
+		return nil, errors.New("state is nil")
+	}
+
+	var mp []string
+	name := addr
+
+	for strings.HasPrefix(name, "module.") {
+		parts := strings.SplitN(strings.TrimPrefix(name, "module."), ".", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Invalid resource address: %s", addr)
+		}
+
+		mp = append(mp, parts[0])
+		name = parts[1]
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("Invalid resource address: %s", addr)
+	}
+
+	if len(mp) == 0 {
+		return primaryInstanceState(s, name)
+	}
+
+	return modulePathPrimaryInstanceState(s, addrs.Module(mp).UnkeyedInstanceShim(), name)
+}
+
+// TestCheckDestroyExhaustive returns a TestCheckFunc, intended for
+// TestCase.CheckDestroy, which fails if any remote resource created by the
+// test still exists after destroy. Unlike a CheckDestroy which only looks up
+// the resources recorded in the state, this also catches resources which
+// were partially created, such as by a failed apply, and never made it into
+// the state.
+//
+// It relies on every resource created by the test being named with a common
+// prefix which is unique to the test, typically generated once per test with
+// acctest.RandomWithPrefix, for example:
+//
+//	prefix := acctest.RandomWithPrefix("tf-acc-test")
+//
+//	resource.Test(t, resource.TestCase{
+//	  CheckDestroy: resource.TestCheckDestroyExhaustive(func() ([]string, error) {
+//	    // List the names of all remote things starting with prefix.
+//	    return listThingNames(client, prefix)
+//	  }),
+//	  Steps: []resource.TestStep{
+//	    {
+//	      Config: testAccThingConfig(prefix),
+//	    },
+//	  },
+//	})
+//
+// The listFn function should return the names or identifiers of all remote
+// resources matching that prefix, regardless of the state. Any returned name
+// fails the check.
+func TestCheckDestroyExhaustive(listFn func() ([]string, error)) TestCheckFunc {
+	return func(_ *terraform.State) error {
+		names, err := listFn()
+		if err != nil {
+			return fmt.Errorf("error listing resources after destroy: %w", err)
+		}
+
+		if len(names) == 0 {
+			return nil
+		}
+
+		names = slices.Clone(names)
+		slices.Sort(names)
+
+		return fmt.Errorf("%d resource(s) still exist after destroy: %s", len(names), strings.Join(names, ", "))
+	}
+}
+
 // TestCheckOutput checks an output in the Terraform configuration
 func TestCheckOutput(name, value string) TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/recorder"
+)
+
+// APICall describes a single outgoing HTTP request made by a provider during
+// a TestStep, as captured by an APICallRecorder.
+type APICall struct {
+	// Method is the HTTP method of the request, such as "GET".
+	Method string
+
+	// Path is the URL path of the request, without scheme, host, or query.
+	Path string
+}
+
+// String returns the APICall in "METHOD /path" form.
+func (c APICall) String() string {
+	return c.Method + " " + c.Path
+}
+
+// APICallRecorder is an http.RoundTripper which records the method and path
+// of every request that passes through it before delegating to the wrapped
+// transport. It is the seam used by TestStep.ExpectAPICalls.
+//
+// Providers expose the seam by constructing their API client from an
+// http.RoundTripper that tests can replace, typically a field on the value
+// returned from ConfigureContextFunc (the provider meta). Acceptance tests
+// then wrap that transport with the recorder when building the provider in
+// ProviderFactories:
+//
+//	recorder := resource.NewAPICallRecorder(http.DefaultTransport)
+//
+//	resource.Test(t, resource.TestCase{
+//	  ProviderFactories: map[string]func() (*schema.Provider, error){
+//	    "example": func() (*schema.Provider, error) {
+//	      p := Provider()
+//	      p.ConfigureContextFunc = configureWithTransport(recorder)
+//	      return p, nil
+//	    },
+//	  },
+//	  Steps: []resource.TestStep{
+//	    {
+//	      Config:          `resource "example_thing" "test" {}`,
+//	      APICallRecorder: recorder,
+//	      ExpectAPICalls: []resource.APICall{
+//	        {Method: "POST", Path: "/things"},
+//	        {Method: "GET", Path: "/things/1"},
+//	      },
+//	    },
+//	  },
+//	})
+//
+// An APICallRecorder is safe for concurrent use.
+type APICallRecorder struct {
+	transport http.RoundTripper
+
+	mu    sync.Mutex
+	calls []APICall
+}
+
+// NewAPICallRecorder returns an APICallRecorder wrapping the given transport.
+// If t is nil, http.DefaultTransport is used.
+func NewAPICallRecorder(t http.RoundTripper) *APICallRecorder {
+	if t == nil {
+		t = http.DefaultTransport
+	}
+
+	return &APICallRecorder{
+		transport: t,
+	}
+}
+
+// RoundTrip records the request method and path, then performs the request
+// with the wrapped transport. It implements http.RoundTripper.
+func (r *APICallRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, APICall{
+		Method: req.Method,
+		Path:   req.URL.Path,
+	})
+	r.mu.Unlock()
+
+	return r.transport.RoundTrip(req)
+}
+
+// Calls returns a copy of the requests recorded since the recorder was
+// created or last reset, in the order they were made.
+func (r *APICallRecorder) Calls() []APICall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := make([]APICall, len(r.calls))
+	copy(calls, r.calls)
+
+	return calls
+}
+
+// Reset discards all recorded requests.
+func (r *APICallRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+// testStepCheckAPICalls compares the requests captured by the TestStep
+// APICallRecorder against ExpectAPICalls, returning an error containing the
+// difference if they do not match.
+func testStepCheckAPICalls(step TestStep) error {
+	if step.APICallRecorder == nil || step.ExpectAPICalls == nil {
+		return nil
+	}
+
+	expected := make([]APICall, len(step.ExpectAPICalls))
+	copy(expected, step.ExpectAPICalls)
+	actual := step.APICallRecorder.Calls()
+
+	if step.ExpectAPICallsIgnoreOrder {
+		sortAPICalls(expected)
+		sortAPICalls(actual)
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		return fmt.Errorf("API calls not equivalent. Difference is shown below. The - symbol indicates expected calls that were not made, the + symbol indicates unexpected calls.\n\n%s", diff)
+	}
+
+	return nil
+}
+
+// testStepSaveRecorder saves the cassette of the TestStep Recorder, if any.
+func testStepSaveRecorder(step TestStep) error {
+	if step.Recorder == nil {
+		return nil
+	}
+
+	return step.Recorder.Save()
+}
+
+// testCaseSaveRecorders saves the cassettes of every distinct TestStep
+// Recorder, such as after the post-test destroy, whose requests are made
+// after the last TestStep has saved its cassette.
+func testCaseSaveRecorders(c TestCase) error {
+	var saved []*recorder.Recorder
+
+	for _, step := range c.Steps {
+		if step.Recorder == nil || slices.Contains(saved, step.Recorder) {
+			continue
+		}
+
+		if err := step.Recorder.Save(); err != nil {
+			return err
+		}
+
+		saved = append(saved, step.Recorder)
+	}
+
+	return nil
+}
+
+func sortAPICalls(calls []APICall) {
+	sort.SliceStable(calls, func(i, j int) bool {
+		if calls[i].Path != calls[j].Path {
+			return calls[i].Path < calls[j].Path
+		}
+
+		return calls[i].Method < calls[j].Method
+	})
+}
@@ -0,0 +1,284 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/recorder"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAPICallRecorder(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	recorder := NewAPICallRecorder(nil)
+	client := &http.Client{Transport: recorder}
+
+	for _, req := range []struct{ method, path string }{
+		{http.MethodPost, "/things"},
+		{http.MethodGet, "/things/1?view=full"},
+	} {
+		r, err := http.NewRequest(req.method, server.URL+req.path, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resp, err := client.Do(r)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resp.Body.Close()
+	}
+
+	expected := []APICall{
+		{Method: http.MethodPost, Path: "/things"},
+		{Method: http.MethodGet, Path: "/things/1"},
+	}
+
+	if diff := cmp.Diff(expected, recorder.Calls()); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	recorder.Reset()
+
+	if got := recorder.Calls(); len(got) != 0 {
+		t.Errorf("expected no calls after Reset, got: %v", got)
+	}
+}
+
+func TestTestStepCheckAPICalls(t *testing.T) {
+	t.Parallel()
+
+	newRecorder := func(calls ...APICall) *APICallRecorder {
+		r := NewAPICallRecorder(nil)
+		r.calls = calls
+
+		return r
+	}
+
+	get := APICall{Method: http.MethodGet, Path: "/things/1"}
+	post := APICall{Method: http.MethodPost, Path: "/things"}
+
+	tests := map[string]struct {
+		step          TestStep
+		expectedError string
+	}{
+		"no-expectation": {
+			step: TestStep{
+				APICallRecorder: newRecorder(get),
+			},
+		},
+		"match": {
+			step: TestStep{
+				APICallRecorder: newRecorder(post, get),
+				ExpectAPICalls:  []APICall{post, get},
+			},
+		},
+		"empty-match": {
+			step: TestStep{
+				APICallRecorder: newRecorder(),
+				ExpectAPICalls:  []APICall{},
+			},
+		},
+		"unexpected-call": {
+			step: TestStep{
+				APICallRecorder: newRecorder(post, get, get),
+				ExpectAPICalls:  []APICall{post, get},
+			},
+			expectedError: "API calls not equivalent",
+		},
+		"missing-call": {
+			step: TestStep{
+				APICallRecorder: newRecorder(post),
+				ExpectAPICalls:  []APICall{post, get},
+			},
+			expectedError: "API calls not equivalent",
+		},
+		"order-mismatch": {
+			step: TestStep{
+				APICallRecorder: newRecorder(get, post),
+				ExpectAPICalls:  []APICall{post, get},
+			},
+			expectedError: "API calls not equivalent",
+		},
+		"order-ignored": {
+			step: TestStep{
+				APICallRecorder:           newRecorder(get, post),
+				ExpectAPICalls:            []APICall{post, get},
+				ExpectAPICallsIgnoreOrder: true,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testStepCheckAPICalls(test.step)
+
+			if test.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error containing %q, got none", test.expectedError)
+			}
+
+			if !strings.Contains(err.Error(), test.expectedError) {
+				t.Fatalf("expected error containing %q, got: %s", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestTest_TestCase_Recorder(t *testing.T) {
+	t.Parallel()
+
+	var deleted atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted.Store(true)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		if deleted.Load() {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		fmt.Fprint(w, "thing")
+	}))
+	defer server.Close()
+
+	url := server.URL + "/things/1"
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	testCase := func(mode recorder.Mode) TestCase {
+		rec, err := recorder.New(path, &recorder.Options{Mode: mode})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		client := &http.Client{Transport: rec}
+
+		request := func(ctx context.Context, method string) (int, error) {
+			req, err := http.NewRequestWithContext(ctx, method, url, nil)
+			if err != nil {
+				return 0, err
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close()
+
+			_, err = io.ReadAll(resp.Body)
+
+			return resp.StatusCode, err
+		}
+
+		return TestCase{
+			ProviderFactories: map[string]func() (*schema.Provider, error){
+				"examplecloud": func() (*schema.Provider, error) { //nolint:unparam // required signature
+					return &schema.Provider{
+						ResourcesMap: map[string]*schema.Resource{
+							"examplecloud_thing": {
+								CreateContext: func(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+									if _, err := request(ctx, http.MethodPost); err != nil {
+										return diag.FromErr(err)
+									}
+
+									d.SetId("1")
+
+									return nil
+								},
+								ReadContext: func(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+									status, err := request(ctx, http.MethodGet)
+									if err != nil {
+										return diag.FromErr(err)
+									}
+
+									if status == http.StatusNotFound {
+										d.SetId("")
+									}
+
+									return nil
+								},
+								DeleteContext: func(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+									if _, err := request(ctx, http.MethodDelete); err != nil {
+										return diag.FromErr(err)
+									}
+
+									return nil
+								},
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Computed: true,
+										Type:     schema.TypeString,
+									},
+								},
+							},
+						},
+					}, nil
+				},
+			},
+			CheckDestroy: func(_ *terraform.State) error {
+				status, err := request(context.Background(), http.MethodGet)
+				if err != nil {
+					return err
+				}
+
+				if status != http.StatusNotFound {
+					return fmt.Errorf("expected thing to be destroyed, got status: %d", status)
+				}
+
+				return nil
+			},
+			Steps: []TestStep{
+				{
+					Config:   `resource "examplecloud_thing" "test" {}`,
+					Recorder: rec,
+				},
+			},
+		}
+	}
+
+	UnitTest(t, testCase(recorder.ModeRecord))
+
+	// Replay without the server, including the post-test destroy and
+	// CheckDestroy requests.
+	server.Close()
+
+	UnitTest(t, testCase(recorder.ModeReplay))
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"errors"
+)
+
+// TestStepPhase is the Terraform CLI command run during a TestStep, for use
+// with TestStep ExpectErrorPhase.
+type TestStepPhase string
+
+const (
+	// TestStepPhasePlan is any plan run during the TestStep, including the
+	// plans checking for differences after apply or refresh.
+	TestStepPhasePlan TestStepPhase = "plan"
+
+	// TestStepPhaseApply is the apply, or destroy, of the TestStep
+	// configuration.
+	TestStepPhaseApply TestStepPhase = "apply"
+
+	// TestStepPhaseRefresh is any refresh run during the TestStep.
+	TestStepPhaseRefresh TestStepPhase = "refresh"
+)
+
+// phaseError is an error returned by the Terraform CLI command run during the
+// given phase of a TestStep.
+type phaseError struct {
+	phase TestStepPhase
+	err   error
+}
+
+func (e *phaseError) Error() string {
+	return e.err.Error()
+}
+
+func (e *phaseError) Unwrap() error {
+	return e.err
+}
+
+// withPhase returns the error annotated with the phase which produced it.
+func withPhase(phase TestStepPhase, err error) error {
+	return &phaseError{
+		phase: phase,
+		err:   err,
+	}
+}
+
+// errorPhase returns the phase which produced the error, or an empty string
+// if the error was not produced by a Terraform CLI command, such as a failed
+// Check.
+func errorPhase(err error) TestStepPhase {
+	var pe *phaseError
+
+	if errors.As(err, &pe) {
+		return pe.phase
+	}
+
+	return ""
+}
+
+// describeErrorPhase returns the phase for use in test failure messages.
+func describeErrorPhase(phase TestStepPhase) string {
+	if phase == "" {
+		return "no phase"
+	}
+
+	return string(phase)
+}
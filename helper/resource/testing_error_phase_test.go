@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorPhase(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err      error
+		expected TestStepPhase
+	}{
+		"no phase": {
+			err:      errors.New("Check failed"),
+			expected: "",
+		},
+		"phase": {
+			err:      withPhase(TestStepPhaseApply, errors.New("Error running apply")),
+			expected: TestStepPhaseApply,
+		},
+		"wrapped": {
+			err:      fmt.Errorf("wrapped: %w", withPhase(TestStepPhasePlan, errors.New("Error running pre-apply plan"))),
+			expected: TestStepPhasePlan,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := errorPhase(testCase.err); got != testCase.expected {
+				t.Errorf("expected phase %q, got: %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithPhase_error(t *testing.T) {
+	t.Parallel()
+
+	inner := errors.New("Error running apply: exit status 1")
+	err := withPhase(TestStepPhaseApply, inner)
+
+	if err.Error() != inner.Error() {
+		t.Errorf("expected message %q, got: %q", inner, err)
+	}
+
+	if !errors.Is(err, inner) {
+		t.Error("expected error to wrap inner error")
+	}
+}
@@ -86,6 +86,16 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			}
 		}
 
+		// The post-test destroy and CheckDestroy requests are recorded after
+		// the last TestStep saved its cassette.
+		if err := testCaseSaveRecorders(c); err != nil {
+			logging.HelperResourceError(ctx,
+				"TestCase recorder save error",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Fatalf("Error saving recorder cassette after post-test destroy: %s", err)
+		}
+
 		wd.Close()
 	}()
 
@@ -203,6 +213,10 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			}
 		}
 
+		if step.APICallRecorder != nil {
+			step.APICallRecorder.Reset()
+		}
+
 		if step.ImportState {
 			logging.HelperResourceTrace(ctx, "TestStep is ImportState mode")
 
@@ -237,6 +251,22 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 				}
 			}
 
+			if err := testStepCheckAPICalls(step); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep API call check error",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %d/%d error checking API calls: %s", stepNumber, len(c.Steps), err)
+			}
+
+			if err := testStepSaveRecorder(step); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep recorder save error",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %d/%d error saving recorder cassette: %s", stepNumber, len(c.Steps), err)
+			}
+
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
 			continue
@@ -261,6 +291,13 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 					)
 					t.Fatalf("Step %d/%d error running refresh, expected an error with pattern (%s), no match on: %s", stepNumber, len(c.Steps), step.ExpectError.String(), err)
 				}
+				if phase := errorPhase(err); step.ExpectErrorPhase != "" && phase != step.ExpectErrorPhase {
+					logging.HelperResourceError(ctx,
+						fmt.Sprintf("Error running refresh: expected an error during %s", step.ExpectErrorPhase),
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %d/%d error running refresh, expected an error during %s, got error during %s: %s", stepNumber, len(c.Steps), step.ExpectErrorPhase, describeErrorPhase(phase), err)
+				}
 			} else {
 				if err != nil && c.ErrorCheck != nil {
 					logging.HelperResourceDebug(ctx, "Calling TestCase ErrorCheck")
@@ -276,6 +313,22 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 				}
 			}
 
+			if err := testStepCheckAPICalls(step); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep API call check error",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %d/%d error checking API calls: %s", stepNumber, len(c.Steps), err)
+			}
+
+			if err := testStepSaveRecorder(step); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep recorder save error",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %d/%d error saving recorder cassette: %s", stepNumber, len(c.Steps), err)
+			}
+
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
 			continue
@@ -301,6 +354,13 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 					)
 					t.Fatalf("Step %d/%d, expected an error with pattern, no match on: %s", stepNumber, len(c.Steps), err)
 				}
+				if phase := errorPhase(err); step.ExpectErrorPhase != "" && phase != step.ExpectErrorPhase {
+					logging.HelperResourceError(ctx,
+						fmt.Sprintf("Expected an error during %s", step.ExpectErrorPhase),
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %d/%d, expected an error during %s, got error during %s: %s", stepNumber, len(c.Steps), step.ExpectErrorPhase, describeErrorPhase(phase), err)
+				}
 			} else {
 				if err != nil && c.ErrorCheck != nil {
 					logging.HelperResourceDebug(ctx, "Calling TestCase ErrorCheck")
@@ -320,6 +380,22 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 
 			appliedCfg = step.mergedConfig(ctx, c)
 
+			if err := testStepCheckAPICalls(step); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep API call check error",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %d/%d error checking API calls: %s", stepNumber, len(c.Steps), err)
+			}
+
+			if err := testStepSaveRecorder(step); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep recorder save error",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %d/%d error saving recorder cassette: %s", stepNumber, len(c.Steps), err)
+			}
+
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
 			continue
@@ -30,7 +30,7 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		return wd.Refresh(ctx)
 	}, wd, providers)
 	if err != nil {
-		return fmt.Errorf("Error running pre-apply refresh: %w", err)
+		return withPhase(TestStepPhaseRefresh, fmt.Errorf("Error running pre-apply refresh: %w", err))
 	}
 
 	// If this step is a PlanOnly step, skip over this first Plan and
@@ -47,7 +47,25 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 			return wd.CreatePlan(ctx)
 		}, wd, providers)
 		if err != nil {
-			return fmt.Errorf("Error running pre-apply plan: %w", err)
+			return withPhase(TestStepPhasePlan, fmt.Errorf("Error running pre-apply plan: %w", err))
+		}
+
+		if step.PlanCheck != nil {
+			logging.HelperResourceTrace(ctx, "Using TestStep PlanCheck")
+
+			var plan *tfjson.Plan
+			err = runProviderCommand(ctx, t, func() error {
+				var err error
+				plan, err = wd.SavedPlan(ctx)
+				return err
+			}, wd, providers)
+			if err != nil {
+				return fmt.Errorf("Error retrieving pre-apply plan: %w", err)
+			}
+
+			if err := step.PlanCheck(newPlanSummary(plan)); err != nil {
+				return fmt.Errorf("Plan check failed: %w", err)
+			}
 		}
 
 		// We need to keep a copy of the state prior to destroying such
@@ -71,9 +89,9 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		}, wd, providers)
 		if err != nil {
 			if step.Destroy {
-				return fmt.Errorf("Error running destroy: %w", err)
+				return withPhase(TestStepPhaseApply, fmt.Errorf("Error running destroy: %w", err))
 			}
-			return fmt.Errorf("Error running apply: %w", err)
+			return withPhase(TestStepPhaseApply, fmt.Errorf("Error running apply: %w", err))
 		}
 
 		// Get the new state
@@ -117,7 +135,7 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		return wd.CreatePlan(ctx)
 	}, wd, providers)
 	if err != nil {
-		return fmt.Errorf("Error running post-apply plan: %w", err)
+		return withPhase(TestStepPhasePlan, fmt.Errorf("Error running post-apply plan: %w", err))
 	}
 
 	var plan *tfjson.Plan
@@ -130,6 +148,20 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		return fmt.Errorf("Error retrieving post-apply plan: %w", err)
 	}
 
+	if step.PlanOnly && step.PlanCheck != nil {
+		logging.HelperResourceTrace(ctx, "Using TestStep PlanCheck")
+
+		if err := step.PlanCheck(newPlanSummary(plan)); err != nil {
+			return fmt.Errorf("Plan check failed: %w", err)
+		}
+	}
+
+	// Run the checks before the plan is checked, so they can report a more
+	// specific error for a difference which persists without a refresh.
+	if err := testStepPostApplyPlanChecks(step, plan); err != nil {
+		return err
+	}
+
 	if !planIsEmpty(plan) && !step.ExpectNonEmptyPlan {
 		var stdout string
 		err = runProviderCommand(ctx, t, func() error {
@@ -149,7 +181,7 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 			return wd.Refresh(ctx)
 		}, wd, providers)
 		if err != nil {
-			return fmt.Errorf("Error running post-apply refresh: %w", err)
+			return withPhase(TestStepPhaseRefresh, fmt.Errorf("Error running post-apply refresh: %w", err))
 		}
 	}
 
@@ -161,7 +193,7 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		return wd.CreatePlan(ctx)
 	}, wd, providers)
 	if err != nil {
-		return fmt.Errorf("Error running second post-apply plan: %w", err)
+		return withPhase(TestStepPhasePlan, fmt.Errorf("Error running second post-apply plan: %w", err))
 	}
 
 	err = runProviderCommand(ctx, t, func() error {
@@ -173,6 +205,10 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		return fmt.Errorf("Error retrieving second post-apply plan: %w", err)
 	}
 
+	if err := testStepPostApplyPlanChecks(step, plan); err != nil {
+		return err
+	}
+
 	// check if plan is empty
 	if !planIsEmpty(plan) && !step.ExpectNonEmptyPlan {
 		var stdout string
@@ -242,3 +278,15 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 
 	return nil
 }
+
+// testStepPostApplyPlanChecks calls each of the TestStep PostApplyPlanChecks
+// with the given post-apply plan.
+func testStepPostApplyPlanChecks(step TestStep, plan *tfjson.Plan) error {
+	for _, check := range step.PostApplyPlanChecks {
+		if err := check(plan); err != nil {
+			return fmt.Errorf("Post-apply plan check failed: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -4,8 +4,12 @@
 package resource
 
 import (
+	"context"
 	"regexp"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestTest_TestStep_ExpectError_NewConfig(t *testing.T) {
@@ -29,3 +33,51 @@ func TestTest_TestStep_ExpectError_NewConfig(t *testing.T) {
 		},
 	})
 }
+
+func TestTest_TestStep_PostApplyPlanChecks_PerpetualDiff(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"examplecloud": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return &schema.Provider{
+					ResourcesMap: map[string]*schema.Resource{
+						"examplecloud_thing": {
+							CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								d.SetId("resource-test")
+
+								return nil
+							},
+							DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							ReadContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								// The remote system always normalizes the name.
+								_ = d.Set("name", "normalized")
+
+								return nil
+							},
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Optional: true,
+									Type:     schema.TypeString,
+								},
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "examplecloud_thing" "test" {
+					name = "testvalue"
+				}`,
+				PostApplyPlanChecks: []PostApplyPlanCheckFunc{
+					TestCheckNoPerpetualDiff("examplecloud_thing.test"),
+				},
+				ExpectError: regexp.MustCompile(`examplecloud_thing.test: perpetual difference detected, planned action \[update\]`),
+			},
+		},
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/mitchellh/go-testing-interface"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/logging"
@@ -277,5 +278,56 @@ func testStepNewImportState(ctx context.Context, t testing.T, helper *plugintest
 		}
 	}
 
+	if step.ImportStateVerifyAndPlan {
+		logging.HelperResourceTrace(ctx, "Using TestStep ImportStateVerifyAndPlan")
+
+		err = runProviderCommand(ctx, t, func() error {
+			return importWd.CreatePlan(ctx)
+		}, importWd, providers)
+		if err != nil {
+			return fmt.Errorf("Error running post-import plan: %w", err)
+		}
+
+		var plan *tfjson.Plan
+		err = runProviderCommand(ctx, t, func() error {
+			var err error
+			plan, err = importWd.SavedPlan(ctx)
+			return err
+		}, importWd, providers)
+		if err != nil {
+			return fmt.Errorf("Error retrieving post-import plan: %w", err)
+		}
+
+		// Only the imported resource is checked, as other resources in the
+		// configuration are planned for creation unless ImportStatePersist
+		// is enabled.
+		if changes := importPlanChanges(plan, step.ResourceName); changes != "" {
+			return fmt.Errorf("ImportStateVerifyAndPlan plan was not empty after import:\n\n%s", changes)
+		}
+	}
+
 	return nil
 }
+
+// importPlanChanges describes the planned changes of the resource with the
+// given address, one line for each change with the changed attribute paths.
+// It returns an empty string if the resource has no changes.
+func importPlanChanges(plan *tfjson.Plan, address string) string {
+	var b strings.Builder
+
+	for _, rc := range newPlanSummary(plan).ResourceChanges {
+		if rc.Address != address || rc.Action == PlanActionNoOp {
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s: %s", rc.Address, rc.Action)
+
+		if len(rc.ChangedAttributes) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(rc.ChangedAttributes, ", "))
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
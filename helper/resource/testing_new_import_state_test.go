@@ -9,6 +9,8 @@ import (
 	"regexp"
 	"testing"
 
+	tfjson "github.com/hashicorp/terraform-json"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -198,6 +200,139 @@ func TestTest_TestStep_ImportStateVerifyIgnore(t *testing.T) {
 	})
 }
 
+func TestTest_TestStep_ImportStateVerifyAndPlan(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"examplecloud": importStateVerifyAndPlanProvider("testvalue"),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "examplecloud_thing" "test" {
+					name = "testvalue"
+				}
+
+				resource "examplecloud_thing" "other" {
+					name = "testvalue"
+				}`,
+			},
+			{
+				ResourceName:             "examplecloud_thing.test",
+				ImportState:              true,
+				ImportStateVerifyAndPlan: true,
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ImportStateVerifyAndPlan_Error(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"examplecloud": importStateVerifyAndPlanProvider("remotevalue"),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "examplecloud_thing" "test" {
+					name = "testvalue"
+				}
+
+				resource "examplecloud_thing" "other" {
+					name = "testvalue"
+				}`,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				ResourceName:             "examplecloud_thing.test",
+				ImportState:              true,
+				ImportStateVerifyAndPlan: true,
+				ExpectError:              regexp.MustCompile(`ImportStateVerifyAndPlan plan was not empty after import:\s+examplecloud_thing.test: update \(name\)`),
+			},
+		},
+	})
+}
+
+// importStateVerifyAndPlanProvider returns a provider factory whose resource
+// always reads the given name, such as one differing from the configuration.
+func importStateVerifyAndPlanProvider(name string) func() (*schema.Provider, error) {
+	return func() (*schema.Provider, error) { //nolint:unparam // required signature
+		return &schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"examplecloud_thing": {
+					CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+						d.SetId("resource-test")
+
+						return nil
+					},
+					DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+						return nil
+					},
+					ReadContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+						_ = d.Set("name", name)
+
+						return nil
+					},
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Optional: true,
+							Type:     schema.TypeString,
+						},
+					},
+					Importer: &schema.ResourceImporter{
+						StateContext: schema.ImportStatePassthroughContext,
+					},
+				},
+			},
+		}, nil
+	}
+}
+
+func TestImportPlanChanges(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.unchanged",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionNoop},
+				},
+			},
+			{
+				Address: "test_resource.updated",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before:  map[string]interface{}{"id": "a", "name": "old", "size": float64(1)},
+					After:   map[string]interface{}{"id": "a", "name": "new", "size": float64(2)},
+				},
+			},
+			{
+				Address: "test_resource.created",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After:   map[string]interface{}{"name": "new"},
+				},
+			},
+		},
+	}
+
+	expected := "test_resource.updated: update (name, size)\n"
+
+	if got := importPlanChanges(plan, "test_resource.updated"); got != expected {
+		t.Errorf("expected %q, got: %q", expected, got)
+	}
+
+	if got := importPlanChanges(plan, "test_resource.unchanged"); got != "" {
+		t.Errorf("expected no changes, got: %q", got)
+	}
+
+	if got := importPlanChanges(plan, "test_resource.created"); got != "test_resource.created: create (name)\n" {
+		t.Errorf("expected create, got: %q", got)
+	}
+}
+
 func TestTest_TestStep_ExpectError_ImportState(t *testing.T) {
 	t.Parallel()
 
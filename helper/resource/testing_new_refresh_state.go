@@ -35,7 +35,7 @@ func testStepNewRefreshState(ctx context.Context, t testing.T, wd *plugintest.Wo
 		return wd.Refresh(ctx)
 	}, wd, providers)
 	if err != nil {
-		return err
+		return withPhase(TestStepPhaseRefresh, err)
 	}
 
 	var refreshState *terraform.State
@@ -66,7 +66,7 @@ func testStepNewRefreshState(ctx context.Context, t testing.T, wd *plugintest.Wo
 		return wd.CreatePlan(ctx)
 	}, wd, providers)
 	if err != nil {
-		return fmt.Errorf("Error running post-apply plan: %w", err)
+		return withPhase(TestStepPhasePlan, fmt.Errorf("Error running post-apply plan: %w", err))
 	}
 
 	var plan *tfjson.Plan
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+var (
+	accParallelismOnce sync.Once
+	accParallelism     parallelismLimiter
+	accParallelismErr  error
+)
+
+// parallelismLimiter bounds the number of concurrently running acceptance
+// tests. A nil limiter is unlimited.
+type parallelismLimiter chan struct{}
+
+// newParallelismLimiter returns the limiter for the given EnvTfAccParallelism
+// value, which is unlimited if empty.
+func newParallelismLimiter(value string) (parallelismLimiter, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(value)
+
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("%s must be a positive integer, got: %q", EnvTfAccParallelism, value)
+	}
+
+	return make(parallelismLimiter, n), nil
+}
+
+// acquire waits until fewer than the maximum number of tests are running,
+// then returns the function to call once the test has finished.
+func (l parallelismLimiter) acquire() func() {
+	if l == nil {
+		return func() {}
+	}
+
+	l <- struct{}{}
+
+	return func() { <-l }
+}
+
+// acquireAccParallelism waits for the EnvTfAccParallelism limit, which is
+// read once for all tests, then returns the function to call once the test
+// has finished.
+func acquireAccParallelism() (func(), error) {
+	accParallelismOnce.Do(func() {
+		accParallelism, accParallelismErr = newParallelismLimiter(os.Getenv(EnvTfAccParallelism))
+	})
+
+	if accParallelismErr != nil {
+		return nil, accParallelismErr
+	}
+
+	return accParallelism.acquire(), nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewParallelismLimiter(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value         string
+		expectedCap   int
+		expectedError string
+	}{
+		"unset": {
+			value: "",
+		},
+		"valid": {
+			value:       "3",
+			expectedCap: 3,
+		},
+		"zero": {
+			value:         "0",
+			expectedError: "TF_ACC_PARALLELISM must be a positive integer",
+		},
+		"invalid": {
+			value:         "many",
+			expectedError: "TF_ACC_PARALLELISM must be a positive integer",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := newParallelismLimiter(testCase.value)
+
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Fatalf("expected error %q, got: %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if cap(got) != testCase.expectedCap {
+				t.Errorf("expected limit %d, got: %d", testCase.expectedCap, cap(got))
+			}
+		})
+	}
+}
+
+func TestParallelismLimiter_acquire(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := newParallelismLimiter("2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			release := limiter.acquire()
+			defer release()
+
+			n := atomic.AddInt32(&running, 1)
+
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+
+	wg.Wait()
+
+	if maxRunning != 2 {
+		t.Errorf("expected at most 2 concurrent tests, got: %d", maxRunning)
+	}
+}
+
+func TestParallelismLimiter_unlimited(t *testing.T) {
+	t.Parallel()
+
+	var limiter parallelismLimiter
+
+	// Acquiring an unlimited limiter never blocks.
+	for i := 0; i < 10; i++ {
+		defer limiter.acquire()()
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// PostApplyPlanCheckFunc is the callback type used with
+// TestStep.PostApplyPlanChecks. It receives the plan created after the
+// TestStep configuration is applied and refreshed, and returns an error to
+// fail the TestStep.
+type PostApplyPlanCheckFunc func(plan *tfjson.Plan) error
+
+// TestCheckNoPerpetualDiff returns a PostApplyPlanCheckFunc which fails if the given
+// resource has any planned change after the TestStep configuration is
+// applied and refreshed, reporting each attribute that differs. Use it in
+// TestStep.PostApplyPlanChecks to pinpoint a perpetual difference in a large
+// configuration, optionally together with ExpectNonEmptyPlan when other
+// resources are expected to have changes.
+//
+// The name is the resource address as shown in the plan, such as
+// "myprovider_thing.example", "myprovider_thing.example[0]", or
+// "module.child.myprovider_thing.example".
+func TestCheckNoPerpetualDiff(name string) PostApplyPlanCheckFunc {
+	return func(plan *tfjson.Plan) error {
+		if plan == nil {
+			return fmt.Errorf("%s: plan is nil", name)
+		}
+
+		for _, rc := range plan.ResourceChanges {
+			if rc.Address != name {
+				continue
+			}
+
+			if rc.Change == nil || rc.Change.Actions.NoOp() {
+				return nil
+			}
+
+			return fmt.Errorf(
+				"%s: perpetual difference detected, planned action %v after apply and refresh:\n\n%s",
+				name,
+				rc.Change.Actions,
+				strings.Join(changedPlanAttributes(rc.Change), "\n"),
+			)
+		}
+
+		return fmt.Errorf("%s: not found in plan", name)
+	}
+}
+
+// changedPlanAttributes returns a description of each top level attribute
+// which differs between the before and after values of the change. Sensitive
+// values are not included.
+func changedPlanAttributes(change *tfjson.Change) []string {
+	before, _ := change.Before.(map[string]interface{})
+	after, _ := change.After.(map[string]interface{})
+	afterUnknown, _ := change.AfterUnknown.(map[string]interface{})
+	beforeSensitive, _ := change.BeforeSensitive.(map[string]interface{})
+	afterSensitive, _ := change.AfterSensitive.(map[string]interface{})
+
+	var result []string
+
+	for _, k := range changedPlanAttributeNames(change) {
+		beforeValue := planAttributeValue(before[k], beforeSensitive[k], false)
+		afterValue := planAttributeValue(after[k], afterSensitive[k], isPlanValueMarked(afterUnknown[k]))
+
+		result = append(result, fmt.Sprintf("  %s: %s => %s", k, beforeValue, afterValue))
+	}
+
+	return result
+}
+
+// changedPlanAttributeNames returns the sorted names of each top level
+// attribute which differs between the before and after values of the change,
+// including attributes which are unknown until apply.
+func changedPlanAttributeNames(change *tfjson.Change) []string {
+	before, _ := change.Before.(map[string]interface{})
+	after, _ := change.After.(map[string]interface{})
+	afterUnknown, _ := change.AfterUnknown.(map[string]interface{})
+
+	keys := make(map[string]struct{})
+
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+
+	for k := range after {
+		keys[k] = struct{}{}
+	}
+
+	for k := range afterUnknown {
+		keys[k] = struct{}{}
+	}
+
+	var result []string
+
+	for k := range keys {
+		if !isPlanValueMarked(afterUnknown[k]) && reflect.DeepEqual(before[k], after[k]) {
+			continue
+		}
+
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// isPlanValueMarked returns true if the given after_unknown or sensitive
+// value from a plan marks the attribute or any of its nested values. Marks
+// are true for the whole attribute or a collection of marks for nested
+// values, which Terraform also includes, such as [] or [{}], for nested
+// values which are not marked.
+func isPlanValueMarked(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case map[string]interface{}:
+		for _, nested := range v {
+			if isPlanValueMarked(nested) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, nested := range v {
+			if isPlanValueMarked(nested) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func planAttributeValue(v interface{}, sensitive interface{}, unknown bool) string {
+	switch {
+	case unknown:
+		return "(known after apply)"
+	case isPlanValueMarked(sensitive):
+		return "(sensitive value)"
+	case v == nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestTestCheckNoPerpetualDiff(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.noop",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionNoop},
+					Before:  map[string]interface{}{"name": "a"},
+					After:   map[string]interface{}{"name": "a"},
+				},
+			},
+			{
+				Address: "module.child.test_resource.update",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before: map[string]interface{}{
+						"id":       "1",
+						"name":     "a",
+						"password": "old",
+						"tags":     map[string]interface{}{"a": "b"},
+					},
+					After: map[string]interface{}{
+						"id":       "1",
+						"name":     "b",
+						"password": "new",
+						"tags":     map[string]interface{}{"a": "b"},
+					},
+					AfterUnknown:    map[string]interface{}{"etag": true},
+					BeforeSensitive: map[string]interface{}{"password": true},
+					AfterSensitive:  map[string]interface{}{"password": true},
+				},
+			},
+			{
+				Address: "test_resource.nested",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before: map[string]interface{}{
+						"name":  "a",
+						"rule":  []interface{}{map[string]interface{}{"port": float64(80)}},
+						"ports": []interface{}{float64(80)},
+					},
+					After: map[string]interface{}{
+						"name":  "b",
+						"rule":  []interface{}{map[string]interface{}{"port": float64(80)}},
+						"ports": []interface{}{float64(80)},
+					},
+					AfterUnknown: map[string]interface{}{
+						"rule":  []interface{}{map[string]interface{}{}},
+						"ports": []interface{}{false},
+						"tags":  []interface{}{},
+					},
+					BeforeSensitive: map[string]interface{}{"rule": []interface{}{map[string]interface{}{}}},
+					AfterSensitive:  map[string]interface{}{"rule": []interface{}{map[string]interface{}{"port": false}}},
+				},
+			},
+			{
+				Address: "test_resource.nested_unknown",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before: map[string]interface{}{
+						"rule": []interface{}{map[string]interface{}{"port": float64(80)}},
+					},
+					After: map[string]interface{}{
+						"rule": []interface{}{map[string]interface{}{}},
+					},
+					AfterUnknown: map[string]interface{}{
+						"rule": []interface{}{map[string]interface{}{"port": true}},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		name          string
+		expectedError string
+	}{
+		"noop": {
+			name: "test_resource.noop",
+		},
+		"update": {
+			name: "module.child.test_resource.update",
+			expectedError: "module.child.test_resource.update: perpetual difference detected, planned action [update] after apply and refresh:\n\n" +
+				"  etag: null => (known after apply)\n" +
+				"  name: \"a\" => \"b\"\n" +
+				"  password: (sensitive value) => (sensitive value)",
+		},
+		"nested": {
+			name: "test_resource.nested",
+			expectedError: "test_resource.nested: perpetual difference detected, planned action [update] after apply and refresh:\n\n" +
+				"  name: \"a\" => \"b\"",
+		},
+		"nested-unknown": {
+			name: "test_resource.nested_unknown",
+			expectedError: "test_resource.nested_unknown: perpetual difference detected, planned action [update] after apply and refresh:\n\n" +
+				"  rule: []interface {}{map[string]interface {}{\"port\":80}} => (known after apply)",
+		},
+		"not-found": {
+			name:          "test_resource.missing",
+			expectedError: "test_resource.missing: not found in plan",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := TestCheckNoPerpetualDiff(testCase.name)(plan)
+
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if got := err.Error(); got != testCase.expectedError {
+				t.Fatalf("unexpected error\ngot:  %s\nwant: %s", got, testCase.expectedError)
+			}
+		})
+	}
+}
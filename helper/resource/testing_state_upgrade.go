@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestStateUpgrade runs the StateUpgraders of the given Resource against a
+// raw JSON state from fromVersion up to the current SchemaVersion, the same
+// as Terraform does when the prior state was written by an older version of
+// the provider, and fails the test with a difference if the result does not
+// equal expected. This allows state upgrade logic to be unit tested without
+// creating real infrastructure.
+//
+// The rawState and expected values are in the form passed to and returned
+// from StateUpgradeFunc, such as decoded from the JSON state. The
+// StateUpgradeFunc meta parameter is nil.
+//
+//	func TestThingStateUpgradeV0(t *testing.T) {
+//	  resource.TestStateUpgrade(t, resourceThing(), 0,
+//	    map[string]interface{}{"id": "thing-1", "size": "10"},
+//	    map[string]interface{}{"id": "thing-1", "size": 10},
+//	  )
+//	}
+func TestStateUpgrade(t testing.T, r *schema.Resource, fromVersion int, rawState map[string]interface{}, expected map[string]interface{}) {
+	t.Helper()
+
+	actual, err := testStateUpgrade(context.Background(), r, fromVersion, rawState)
+	if err != nil {
+		t.Fatalf("Error upgrading state: %s", err)
+		return
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Upgraded state from version %d not equivalent. Difference is shown below. The - symbol indicates expected values, the + symbol indicates actual values.\n\n%s", fromVersion, diff)
+	}
+}
+
+// testStateUpgrade returns the state upgraded from fromVersion to the current
+// SchemaVersion of the Resource.
+func testStateUpgrade(ctx context.Context, r *schema.Resource, fromVersion int, rawState map[string]interface{}) (map[string]interface{}, error) {
+	if r == nil {
+		return nil, fmt.Errorf("resource is nil")
+	}
+
+	if fromVersion < 0 || fromVersion >= r.SchemaVersion {
+		return nil, fmt.Errorf("fromVersion %d must be less than the resource SchemaVersion %d", fromVersion, r.SchemaVersion)
+	}
+
+	state := rawState
+
+	for version := fromVersion; version < r.SchemaVersion; version++ {
+		var upgrader *schema.StateUpgrader
+
+		for i := range r.StateUpgraders {
+			if r.StateUpgraders[i].Version == version {
+				upgrader = &r.StateUpgraders[i]
+				break
+			}
+		}
+
+		if upgrader == nil {
+			return nil, fmt.Errorf("missing StateUpgrader for version %d", version)
+		}
+
+		if upgrader.Upgrade == nil {
+			return nil, fmt.Errorf("StateUpgrader %d missing StateUpgradeFunc", version)
+		}
+
+		var err error
+
+		state, err = upgrader.Upgrade(ctx, state, nil)
+		if err != nil {
+			return nil, fmt.Errorf("StateUpgrader %d: %w", version, err)
+		}
+	}
+
+	return state, nil
+}
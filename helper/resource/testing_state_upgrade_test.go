@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testStateUpgradeResource() *schema.Resource {
+	return &schema.Resource{
+		SchemaVersion: 2,
+		Schema: map[string]*schema.Schema{
+			"size": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Upgrade: func(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+					size, err := strconv.Atoi(rawState["size"].(string))
+					if err != nil {
+						return nil, err
+					}
+
+					rawState["size"] = size
+
+					return rawState, nil
+				},
+			},
+			{
+				Version: 1,
+				Upgrade: func(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+					rawState["name"] = strings.ToLower(rawState["name"].(string))
+
+					return rawState, nil
+				},
+			},
+		},
+	}
+}
+
+func TestTestStateUpgrade(t *testing.T) {
+	t.Parallel()
+
+	TestStateUpgrade(t, testStateUpgradeResource(), 0,
+		map[string]interface{}{"id": "test", "size": "10", "name": "TEST"},
+		map[string]interface{}{"id": "test", "size": 10, "name": "test"},
+	)
+
+	TestStateUpgrade(t, testStateUpgradeResource(), 1,
+		map[string]interface{}{"id": "test", "size": 10, "name": "TEST"},
+		map[string]interface{}{"id": "test", "size": 10, "name": "test"},
+	)
+}
+
+func TestTestStateUpgrade_errors(t *testing.T) {
+	t.Parallel()
+
+	missingUpgrader := testStateUpgradeResource()
+	missingUpgrader.StateUpgraders = missingUpgrader.StateUpgraders[1:]
+
+	failingUpgrader := testStateUpgradeResource()
+	failingUpgrader.StateUpgraders[0].Upgrade = func(_ context.Context, _ map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+		return nil, errors.New("test error")
+	}
+
+	testCases := map[string]struct {
+		resource      *schema.Resource
+		fromVersion   int
+		expectedError string
+	}{
+		"current-version": {
+			resource:      testStateUpgradeResource(),
+			fromVersion:   2,
+			expectedError: "fromVersion 2 must be less than the resource SchemaVersion 2",
+		},
+		"missing-upgrader": {
+			resource:      missingUpgrader,
+			fromVersion:   0,
+			expectedError: "missing StateUpgrader for version 0",
+		},
+		"upgrader-error": {
+			resource:      failingUpgrader,
+			fromVersion:   0,
+			expectedError: "StateUpgrader 0: test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := testStateUpgrade(context.Background(), testCase.resource, testCase.fromVersion, map[string]interface{}{"size": "10", "name": "TEST"})
+
+			if err == nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+				t.Fatalf("unexpected error difference: %s", diff)
+			}
+		})
+	}
+}
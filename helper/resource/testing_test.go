@@ -858,6 +858,101 @@ func TestRunSweepers(t *testing.T) {
 	}
 }
 
+func TestAddTestSweepersFromProvider(t *testing.T) {
+	originalSweeperFuncs, originalProviderSweeperNames := sweeperFuncs, providerSweeperNames
+	defer func() {
+		sweeperFuncs, providerSweeperNames = originalSweeperFuncs, originalProviderSweeperNames
+	}()
+
+	sweeperFuncs = make(map[string]*Sweeper)
+	providerSweeperNames = make(map[string]bool)
+
+	p := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"example_custom":   {},
+			"example_instance": {},
+			"example_network":  {},
+		},
+	}
+	p.SetMeta("test-meta")
+
+	AddTestSweepers("example_custom", &Sweeper{
+		Name: "example_custom",
+		F:    mockSweeperFunc,
+	})
+
+	var swept []string
+
+	AddTestSweepersFromProvider(p, map[string][]string{
+		"example_instance": {"example_custom"},
+	}, func(region, resourceType string, meta interface{}) error {
+		if meta != "test-meta" {
+			t.Errorf("unexpected meta for %s: %#v", resourceType, meta)
+		}
+
+		swept = append(swept, region+" "+resourceType)
+
+		return nil
+	})
+
+	// Override a provider sweeper to order it after another.
+	AddTestSweepers("example_network", &Sweeper{
+		Name:         "example_network",
+		Dependencies: []string{"example_instance"},
+		F: func(region string) error {
+			swept = append(swept, region+" custom example_network")
+
+			return nil
+		},
+	})
+
+	if _, err := runSweepers([]string{"test"}, filterSweepers("example_network", sweeperFuncs), false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"test example_instance", "test custom example_network"}
+
+	if !reflect.DeepEqual(swept, expected) {
+		t.Errorf("Expected sweeps mismatch, expected:\n%#v\ngot:\n%#v\n", expected, swept)
+	}
+
+	if sweeperFuncs["example_custom"].F == nil || providerSweeperNames["example_custom"] {
+		t.Errorf("expected existing example_custom sweeper to be kept")
+	}
+
+	if expected := []string{"example_custom"}; !reflect.DeepEqual(sweeperFuncs["example_instance"].Dependencies, expected) {
+		t.Errorf("Expected dependencies mismatch, expected:\n%#v\ngot:\n%#v\n", expected, sweeperFuncs["example_instance"].Dependencies)
+	}
+}
+
+func TestAddTestSweepersFromProvider_unconfigured(t *testing.T) {
+	originalSweeperFuncs, originalProviderSweeperNames := sweeperFuncs, providerSweeperNames
+	defer func() {
+		sweeperFuncs, providerSweeperNames = originalSweeperFuncs, originalProviderSweeperNames
+	}()
+
+	sweeperFuncs = make(map[string]*Sweeper)
+	providerSweeperNames = make(map[string]bool)
+
+	p := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"example_instance": {},
+		},
+	}
+
+	AddTestSweepersFromProvider(p, nil, func(_, resourceType string, _ interface{}) error {
+		t.Errorf("unexpected sweep of %s with unconfigured provider", resourceType)
+
+		return nil
+	})
+
+	err := sweeperFuncs["example_instance"].F("test")
+
+	if err == nil || !strings.Contains(err.Error(), "provider is not configured") {
+		t.Errorf("expected unconfigured provider error, got: %v", err)
+	}
+}
+
 func mockFailingSweeperFunc(s string) error {
 	return errors.New("failing sweeper")
 }
@@ -1425,6 +1520,210 @@ func TestTestCheckResourceAttr(t *testing.T) {
 	}
 }
 
+func TestTestCheckResourceAttrCount(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		attributes    map[string]string
+		key           string
+		expected      int
+		expectedError error
+	}{
+		"list match": {
+			attributes: map[string]string{
+				"test_list.#": "2",
+				"test_list.0": "a",
+				"test_list.1": "b",
+			},
+			key:      "test_list",
+			expected: 2,
+		},
+		"list mismatch": {
+			attributes: map[string]string{
+				"test_list.#": "1",
+				"test_list.0": "a",
+			},
+			key:           "test_list",
+			expected:      2,
+			expectedError: fmt.Errorf("Attribute 'test_list' expected 2 elements, got 1"),
+		},
+		"map match": {
+			attributes: map[string]string{
+				"test_map.%":   "1",
+				"test_map.key": "value",
+			},
+			key:      "test_map",
+			expected: 1,
+		},
+		"map mismatch": {
+			attributes: map[string]string{
+				"test_map.%":   "1",
+				"test_map.key": "value",
+			},
+			key:           "test_map",
+			expected:      0,
+			expectedError: fmt.Errorf("Attribute 'test_map' expected 0 elements, got 1"),
+		},
+		"empty collection elided": {
+			attributes: map[string]string{},
+			key:        "test_list",
+			expected:   0,
+		},
+		"attribute not found": {
+			attributes:    map[string]string{},
+			key:           "test_list",
+			expected:      1,
+			expectedError: fmt.Errorf("Attribute 'test_list' not found"),
+		},
+		"not a collection": {
+			attributes: map[string]string{
+				"test_string": "value",
+			},
+			key:           "test_string",
+			expected:      1,
+			expectedError: fmt.Errorf("Attribute 'test_string' is not a list, set, or map"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := &terraform.State{
+				IsBinaryDrivenTest: true, // Always true now
+				Modules: []*terraform.ModuleState{
+					{
+						Path: []string{"root"},
+						Resources: map[string]*terraform.ResourceState{
+							"test_resource": {
+								Primary: &terraform.InstanceState{
+									Attributes: testCase.attributes,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := TestCheckResourceAttrCount("test_resource", testCase.key, testCase.expected)(state)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+
+				if !strings.Contains(err.Error(), testCase.expectedError.Error()) {
+					t.Fatalf("expected error %q, got: %s", testCase.expectedError, err)
+				}
+			}
+
+			if err == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestImportStateIdByAttribute(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		attributes    map[string]string
+		key           string
+		expected      string
+		expectedError error
+	}{
+		"attribute": {
+			attributes: map[string]string{
+				"id":   "123",
+				"name": "example",
+			},
+			key:      "name",
+			expected: "example",
+		},
+		"set nested attribute": {
+			attributes: map[string]string{
+				"id":                  "123",
+				"identity.#":          "1",
+				"identity.12345.arn":  "arn:example",
+				"identity.12345.name": "example",
+			},
+			key:      "identity.*.arn",
+			expected: "arn:example",
+		},
+		"set nested attribute multiple values": {
+			attributes: map[string]string{
+				"identity.#":         "2",
+				"identity.12345.arn": "arn:first",
+				"identity.67890.arn": "arn:second",
+			},
+			key:           "identity.*.arn",
+			expectedError: fmt.Errorf("test_resource: Attribute 'identity.*.arn' matches multiple values"),
+		},
+		"attribute not found": {
+			attributes: map[string]string{
+				"id": "123",
+			},
+			key:           "name",
+			expectedError: fmt.Errorf("test_resource: Attribute 'name' not found or empty"),
+		},
+		"attribute empty": {
+			attributes: map[string]string{
+				"id":   "123",
+				"name": "",
+			},
+			key:           "name",
+			expectedError: fmt.Errorf("test_resource: Attribute 'name' not found or empty"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := &terraform.State{
+				IsBinaryDrivenTest: true, // Always true now
+				Modules: []*terraform.ModuleState{
+					{
+						Path: []string{"root"},
+						Resources: map[string]*terraform.ResourceState{
+							"test_resource": {
+								Primary: &terraform.InstanceState{
+									Attributes: testCase.attributes,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			got, err := ImportStateIdByAttribute("test_resource", testCase.key)(state)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+
+				if !strings.Contains(err.Error(), testCase.expectedError.Error()) {
+					t.Fatalf("expected error %q, got: %s", testCase.expectedError, err)
+				}
+			}
+
+			if err == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got: %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
 func TestTestCheckResourceAttrWith(t *testing.T) {
 	t.Parallel()
 
@@ -2379,6 +2678,266 @@ func TestTestCheckResourceAttrPair(t *testing.T) {
 	}
 }
 
+func TestTestCheckResourceMatchesDataSource(t *testing.T) {
+	state := func(dataSourceAttrs map[string]string) *terraform.State {
+		return &terraform.State{
+			Modules: []*terraform.ModuleState{
+				{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.ResourceState{
+						"test_thing.a": {
+							Primary: &terraform.InstanceState{
+								Attributes: map[string]string{
+									"id":          "thing-1",
+									"name":        "boop",
+									"description": "managed only",
+									"tags.%":      "2",
+									"tags.env":    "test",
+									"tags.team":   "sdk",
+									"rules.#":     "0",
+								},
+							},
+						},
+						"data.test_thing.a": {
+							Primary: &terraform.InstanceState{
+								Attributes: dataSourceAttrs,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		keys            []string
+		dataSourceAttrs map[string]string
+		wantErr         string
+	}{
+		"match": {
+			keys: []string{"id", "name", "tags", "rules"},
+			dataSourceAttrs: map[string]string{
+				"id":        "thing-1",
+				"name":      "boop",
+				"tags.%":    "2",
+				"tags.env":  "test",
+				"tags.team": "sdk",
+			},
+		},
+		"unlisted attributes ignored": {
+			keys: []string{"name"},
+			dataSourceAttrs: map[string]string{
+				"name":        "boop",
+				"description": "other",
+			},
+		},
+		"mismatch": {
+			keys: []string{"name", "tags"},
+			dataSourceAttrs: map[string]string{
+				"name":      "beep",
+				"tags.%":    "1",
+				"tags.env":  "test",
+				"tags.team": "sdk",
+			},
+			wantErr: "test_thing.a and data.test_thing.a attributes differ",
+		},
+		"missing": {
+			keys: []string{"description"},
+			dataSourceAttrs: map[string]string{
+				"name": "boop",
+			},
+			wantErr: "test_thing.a and data.test_thing.a attributes differ",
+		},
+		"no keys": {
+			dataSourceAttrs: map[string]string{},
+			wantErr:         "no attributes given to compare test_thing.a and data.test_thing.a",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fn := TestCheckResourceMatchesDataSource("test_thing.a", "data.test_thing.a", test.keys...)
+			err := fn(state(test.dataSourceAttrs))
+
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("succeeded; want error\nwant: %s", test.wantErr)
+				}
+				if got, want := err.Error(), test.wantErr; !strings.HasPrefix(got, want) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed; want success\ngot: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestTestCheckResourcesInvariant(t *testing.T) {
+	state := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_vpc.a": {
+						Primary: &terraform.InstanceState{
+							ID: "vpc-1",
+						},
+					},
+				},
+			},
+			{
+				Path: []string{"root", "network"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_subnet.a": {
+						Primary: &terraform.InstanceState{
+							ID: "subnet-1",
+							Attributes: map[string]string{
+								"vpc_id": "vpc-1",
+							},
+						},
+					},
+					"test_subnet.b": {
+						Primary: &terraform.InstanceState{
+							ID: "subnet-2",
+							Attributes: map[string]string{
+								"vpc_id": "vpc-2",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	check := func(subnetAddr string) func(*terraform.State) error {
+		return func(s *terraform.State) error {
+			vpc, err := InstanceState(s, "test_vpc.a")
+			if err != nil {
+				return err
+			}
+
+			subnet, err := InstanceState(s, subnetAddr)
+			if err != nil {
+				return err
+			}
+
+			if subnet.Attributes["vpc_id"] != vpc.ID {
+				return fmt.Errorf("vpc_id %q does not match %q", subnet.Attributes["vpc_id"], vpc.ID)
+			}
+
+			return nil
+		}
+	}
+
+	tests := map[string]struct {
+		check   func(*terraform.State) error
+		state   *terraform.State
+		wantErr string
+	}{
+		"match": {
+			check: check("module.network.test_subnet.a"),
+			state: state,
+		},
+		"mismatch": {
+			check:   check("module.network.test_subnet.b"),
+			state:   state,
+			wantErr: `resources invariant: vpc_id "vpc-2" does not match "vpc-1"`,
+		},
+		"resource not found": {
+			check:   check("module.network.test_subnet.c"),
+			state:   state,
+			wantErr: `resources invariant: Not found: test_subnet.c in [root network]`,
+		},
+		"module not found": {
+			check:   check("module.compute.test_subnet.a"),
+			state:   state,
+			wantErr: `resources invariant: No module found at: module.compute`,
+		},
+		"invalid address": {
+			check:   check("module.network"),
+			state:   state,
+			wantErr: `resources invariant: Invalid resource address: module.network`,
+		},
+		"nil state": {
+			check:   check("module.network.test_subnet.a"),
+			wantErr: `resources invariant: state is nil`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := TestCheckResourcesInvariant(test.check)(test.state)
+
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("succeeded; want error\nwant: %s", test.wantErr)
+				}
+				if got, want := err.Error(), test.wantErr; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed; want success\ngot: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestTestCheckDestroyExhaustive(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		listFn  func() ([]string, error)
+		wantErr string
+	}{
+		"none remaining": {
+			listFn: func() ([]string, error) {
+				return nil, nil
+			},
+		},
+		"remaining": {
+			listFn: func() ([]string, error) {
+				return []string{"tf-acc-test-123-b", "tf-acc-test-123-a"}, nil
+			},
+			wantErr: "2 resource(s) still exist after destroy: tf-acc-test-123-a, tf-acc-test-123-b",
+		},
+		"list error": {
+			listFn: func() ([]string, error) {
+				return nil, errors.New("access denied")
+			},
+			wantErr: "error listing resources after destroy: access denied",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := TestCheckDestroyExhaustive(test.listFn)(&terraform.State{})
+
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("succeeded; want error\nwant: %s", test.wantErr)
+				}
+				if got, want := err.Error(), test.wantErr; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed; want success\ngot: %s", err.Error())
+			}
+		})
+	}
+}
+
 func TestTestCheckResourceAttrSet(t *testing.T) {
 	t.Parallel()
 
@@ -2518,3 +3077,86 @@ func TestTestCheckResourceAttrSet(t *testing.T) {
 		})
 	}
 }
+
+func TestTestCheckResourceAttrTags(t *testing.T) {
+	t.Parallel()
+
+	attributes := map[string]string{
+		"tags.%":           "2",
+		"tags.Name":        "example",
+		"tags.Environment": "test",
+	}
+
+	testCases := map[string]struct {
+		expected      map[string]string
+		exact         bool
+		expectedError error
+	}{
+		"exact match": {
+			expected: map[string]string{"Name": "example", "Environment": "test"},
+			exact:    true,
+		},
+		"exact extra": {
+			expected:      map[string]string{"Name": "example"},
+			exact:         true,
+			expectedError: fmt.Errorf(`Attribute 'tags' tags do not match: unexpected tag "Environment"`),
+		},
+		"exact missing and different": {
+			expected:      map[string]string{"Name": "other", "Environment": "test", "Owner": "me"},
+			exact:         true,
+			expectedError: fmt.Errorf(`Attribute 'tags' tags do not match: missing tag "Owner", tag "Name" expected "other", got "example"`),
+		},
+		"contain match": {
+			expected: map[string]string{"Name": "example"},
+		},
+		"contain missing": {
+			expected:      map[string]string{"Owner": "me"},
+			expectedError: fmt.Errorf(`Attribute 'tags' tags do not match: missing tag "Owner"`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := &terraform.State{
+				IsBinaryDrivenTest: true, // Always true now
+				Modules: []*terraform.ModuleState{
+					{
+						Path: []string{"root"},
+						Resources: map[string]*terraform.ResourceState{
+							"test_resource": {
+								Primary: &terraform.InstanceState{
+									Attributes: attributes,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			check := TestCheckResourceAttrTagsContain
+			if testCase.exact {
+				check = TestCheckResourceAttrTags
+			}
+
+			err := check("test_resource", "tags", testCase.expected)(state)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+
+				if !strings.Contains(err.Error(), testCase.expectedError.Error()) {
+					t.Fatalf("expected error %q, got: %s", testCase.expectedError, err)
+				}
+			}
+
+			if err == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
@@ -59,6 +59,11 @@ func (s TestStep) hasProviders(_ context.Context) bool {
 //   - No overlapping ExternalProviders and ProviderFactories entries
 //   - ResourceName is not empty when ImportState is true, ImportStateIdFunc
 //     is not set, and ImportStateId is not set.
+//   - ImportState is true when ImportStateVerifyAndPlan is true.
+//   - ExpectError is set and ImportState is not when ExpectErrorPhase is set.
+//   - APICallRecorder is set when ExpectAPICalls is set.
+//   - Config is set and ImportState is not when PostApplyPlanChecks is set.
+//   - Config is set and ImportState is not when PlanCheck is set.
 func (s TestStep) validate(ctx context.Context, req testStepValidateRequest) error {
 	ctx = logging.TestStepNumberContext(ctx, req.StepNumber)
 
@@ -124,5 +129,35 @@ func (s TestStep) validate(ctx context.Context, req testStepValidateRequest) err
 		}
 	}
 
+	if s.ImportStateVerifyAndPlan && !s.ImportState {
+		err := fmt.Errorf("TestStep ImportStateVerifyAndPlan must be specified with ImportState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ExpectErrorPhase != "" && (s.ExpectError == nil || s.ImportState) {
+		err := fmt.Errorf("TestStep ExpectErrorPhase must be specified with ExpectError and without ImportState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ExpectAPICalls != nil && s.APICallRecorder == nil {
+		err := fmt.Errorf("TestStep ExpectAPICalls must be specified with APICallRecorder")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.PostApplyPlanChecks) > 0 && (s.Config == "" || s.ImportState) {
+		err := fmt.Errorf("TestStep PostApplyPlanChecks must be specified with Config and without ImportState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.PlanCheck != nil && (s.Config == "" || s.ImportState) {
+		err := fmt.Errorf("TestStep PlanCheck must be specified with Config and without ImportState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
 	return nil
 }
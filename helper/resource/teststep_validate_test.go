@@ -120,6 +120,49 @@ func TestTestStepValidate(t *testing.T) {
 			testStepValidateRequest: testStepValidateRequest{},
 			expectedError:           fmt.Errorf("TestStep cannot have RefreshState and Destroy"),
 		},
+		"expectapicalls-missing-apicallrecorder": {
+			testStep: TestStep{
+				Config:         "# not empty",
+				ExpectAPICalls: []APICall{},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ExpectAPICalls must be specified with APICallRecorder"),
+		},
+		"importstateverifyandplan-missing-importstate": {
+			testStep: TestStep{
+				Config:                   "# not empty",
+				ImportStateVerifyAndPlan: true,
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ImportStateVerifyAndPlan must be specified with ImportState"),
+		},
+		"expecterrorphase-missing-expecterror": {
+			testStep: TestStep{
+				Config:           "# not empty",
+				ExpectErrorPhase: TestStepPhaseApply,
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ExpectErrorPhase must be specified with ExpectError and without ImportState"),
+		},
+		"postapplyplanchecks-importstate": {
+			testStep: TestStep{
+				ImportState:         true,
+				ImportStateId:       "test",
+				PostApplyPlanChecks: []PostApplyPlanCheckFunc{TestCheckNoPerpetualDiff("test.test")},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep PostApplyPlanChecks must be specified with Config and without ImportState"),
+		},
+		"plancheck-missing-config": {
+			testStep: TestStep{
+				RefreshState: true,
+				PlanCheck: func(_ *PlanSummary) error {
+					return nil
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{StepNumber: 2, TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep PlanCheck must be specified with Config and without ImportState"),
+		},
 		"externalproviders-overlapping-providerfactories": {
 			testStep: TestStep{
 				Config: "# not empty",
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"time"
+)
+
+// Default values for Policy fields which are unset.
+const (
+	defaultPolicyInitialBackoff = 100 * time.Millisecond
+	defaultPolicyMaxBackoff     = 10 * time.Second
+	defaultPolicyMultiplier     = 2
+)
+
+// errRetryWithoutBackoff can be returned to Do by the StateChangeConf refresh
+// loop to retry without increasing the wait, such as while waiting for the
+// target state to reoccur.
+var errRetryWithoutBackoff = errors.New("retry without backoff")
+
+// Policy describes how Do retries a function, such as the calls of an API
+// client:
+//
+//	err := retry.Do(ctx, retry.Policy{
+//	  MaxAttempts: 5,
+//	  Retryable: func(err error) bool {
+//	    var apiErr *APIError
+//	    return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+//	  },
+//	}, func(ctx context.Context) error {
+//	  return client.Do(ctx, req)
+//	})
+type Policy struct {
+	// MaxAttempts is the maximum number of times to call the function. If
+	// zero, the function is retried until the context is done.
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry, defaulting to 100
+	// milliseconds.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum wait between retries, defaulting to 10
+	// seconds.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor the wait is multiplied by after each retry,
+	// defaulting to 2. Use 1 to wait InitialBackoff between every retry.
+	Multiplier float64
+
+	// Jitter is the fraction of each wait, between 0 and 1, which is
+	// randomized, so that many callers retrying the same API spread out
+	// rather than retrying together. For example, 0.2 waits between 80% and
+	// 100% of the backoff. If zero, the wait is not randomized.
+	Jitter float64
+
+	// Retryable reports whether an error returned by the function should be
+	// retried. If nil, all errors are retried.
+	Retryable func(err error) bool
+}
+
+// backoff returns the wait before the given retry, starting from zero.
+func (p Policy) backoff(retry int) time.Duration {
+	initial := p.InitialBackoff
+
+	if initial <= 0 {
+		initial = defaultPolicyInitialBackoff
+	}
+
+	maxBackoff := p.MaxBackoff
+
+	if maxBackoff <= 0 {
+		maxBackoff = defaultPolicyMaxBackoff
+	}
+
+	multiplier := p.Multiplier
+
+	if multiplier <= 0 {
+		multiplier = defaultPolicyMultiplier
+	}
+
+	wait := float64(initial)
+
+	for i := 0; i < retry && wait < float64(maxBackoff); i++ {
+		wait *= multiplier
+	}
+
+	if wait > float64(maxBackoff) {
+		return maxBackoff
+	}
+
+	return time.Duration(wait)
+}
+
+// jittered returns the wait randomly reduced by up to the Jitter fraction.
+func (p Policy) jittered(wait time.Duration) time.Duration {
+	jitter := p.Jitter
+
+	if jitter <= 0 {
+		return wait
+	}
+
+	if jitter > 1 {
+		jitter = 1
+	}
+
+	return wait - time.Duration(jitter*rand.Float64()*float64(wait))
+}
+
+// retryable returns whether the error should be retried.
+func (p Policy) retryable(err error) bool {
+	if errors.Is(err, errRetryWithoutBackoff) || p.Retryable == nil {
+		return true
+	}
+
+	return p.Retryable(err)
+}
+
+// Do calls the function until it returns nil, waiting between calls with
+// exponential backoff as described by the Policy. It returns nil once the
+// function succeeds, or the error returned by the function if that error is
+// not retryable or MaxAttempts is exhausted.
+//
+// If the context is done before the function succeeds, the context error is
+// returned, joined with the last error returned by the function, if any. The
+// function is passed the same context, which it should use to stop early.
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) error {
+	var retries int
+
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := fn(ctx)
+
+		if err == nil {
+			return nil
+		}
+
+		if !policy.retryable(err) {
+			return err
+		}
+
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		// Repeat the previous wait, rather than increasing it, if requested.
+		if retries == 0 || !errors.Is(err, errRetryWithoutBackoff) {
+			retries++
+		}
+
+		wait := policy.jittered(policy.backoff(retries - 1))
+
+		log.Printf("[TRACE] Waiting %s before next try", wait)
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(ctx.Err(), err)
+		case <-time.After(wait):
+		}
+	}
+}
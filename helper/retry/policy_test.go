@@ -0,0 +1,314 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPolicy_backoff(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy   Policy
+		expected []time.Duration
+	}{
+		"defaults": {
+			policy: Policy{},
+			expected: []time.Duration{
+				100 * time.Millisecond,
+				200 * time.Millisecond,
+				400 * time.Millisecond,
+				800 * time.Millisecond,
+			},
+		},
+		"bounded": {
+			policy: Policy{
+				InitialBackoff: 4 * time.Second,
+			},
+			expected: []time.Duration{
+				4 * time.Second,
+				8 * time.Second,
+				10 * time.Second,
+				10 * time.Second,
+			},
+		},
+		"multiplier": {
+			policy: Policy{
+				InitialBackoff: time.Second,
+				MaxBackoff:     time.Minute,
+				Multiplier:     3,
+			},
+			expected: []time.Duration{
+				time.Second,
+				3 * time.Second,
+				9 * time.Second,
+				27 * time.Second,
+			},
+		},
+		"constant": {
+			policy: Policy{
+				InitialBackoff: time.Second,
+				Multiplier:     1,
+			},
+			expected: []time.Duration{
+				time.Second,
+				time.Second,
+				time.Second,
+				time.Second,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			for retry, expected := range testCase.expected {
+				if got := testCase.policy.backoff(retry); got != expected {
+					t.Errorf("retry %d: expected backoff %s, got: %s", retry, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicy_jittered(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy  Policy
+		minWait time.Duration
+	}{
+		"none": {
+			policy:  Policy{},
+			minWait: time.Second,
+		},
+		"half": {
+			policy:  Policy{Jitter: 0.5},
+			minWait: 500 * time.Millisecond,
+		},
+		"bounded": {
+			policy:  Policy{Jitter: 2},
+			minWait: 0,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 100; i++ {
+				if got := testCase.policy.jittered(time.Second); got < testCase.minWait || got > time.Second {
+					t.Fatalf("expected wait between %s and 1s, got: %s", testCase.minWait, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	var calls []time.Time
+
+	err := Do(context.Background(), Policy{
+		InitialBackoff: 50 * time.Millisecond,
+	}, func(context.Context) error {
+		calls = append(calls, time.Now())
+
+		if len(calls) < 3 {
+			return errors.New("retryable")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got: %d", len(calls))
+	}
+
+	// The wait doubles after each retry.
+	for i, minWait := range []time.Duration{50 * time.Millisecond, 100 * time.Millisecond} {
+		if wait := calls[i+1].Sub(calls[i]); wait < minWait {
+			t.Errorf("retry %d: expected wait of at least %s, got: %s", i, minWait, wait)
+		}
+	}
+}
+
+func TestDo_maxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+
+	err := Do(context.Background(), Policy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	}, func(context.Context) error {
+		attempts++
+
+		return &testError{attempt: attempts}
+	})
+
+	var testErr *testError
+	if !errors.As(err, &testErr) {
+		t.Fatalf("expected testError, got: %#v", err)
+	}
+
+	if testErr.attempt != 3 {
+		t.Fatalf("expected error from last attempt, got attempt: %d", testErr.attempt)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got: %d", attempts)
+	}
+}
+
+func TestDo_nonRetryable(t *testing.T) {
+	t.Parallel()
+
+	expected := errors.New("nope")
+
+	var attempts int
+
+	err := Do(context.Background(), Policy{
+		InitialBackoff: time.Millisecond,
+		Retryable: func(err error) bool {
+			return !errors.Is(err, expected)
+		},
+	}, func(context.Context) error {
+		attempts++
+
+		if attempts == 1 {
+			return errors.New("retryable")
+		}
+
+		return expected
+	})
+	if err != expected {
+		t.Fatalf("expected error %q, got: %#v", expected, err)
+	}
+
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got: %d", attempts)
+	}
+}
+
+func TestDo_cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	expected := errors.New("always")
+	start := time.Now()
+
+	err := Do(ctx, Policy{
+		InitialBackoff: time.Hour,
+	}, func(context.Context) error {
+		return expected
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded error, got: %#v", err)
+	}
+
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected last error to be included, got: %#v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("expected backoff to be cancelled, took: %s", elapsed)
+	}
+}
+
+func TestDo_cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Do(ctx, Policy{}, func(context.Context) error {
+		t.Fatal("function should not be called")
+
+		return nil
+	})
+
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled error, got: %#v", err)
+	}
+}
+
+func TestStateChangeConf_refreshPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		conf     *StateChangeConf
+		expected []time.Duration
+	}{
+		"defaults": {
+			conf: &StateChangeConf{},
+			expected: []time.Duration{
+				200 * time.Millisecond,
+				400 * time.Millisecond,
+				800 * time.Millisecond,
+			},
+		},
+		"MinTimeout": {
+			conf: &StateChangeConf{
+				MinTimeout: 500 * time.Millisecond,
+			},
+			expected: []time.Duration{
+				500 * time.Millisecond,
+				time.Second,
+				2 * time.Second,
+			},
+		},
+		"PollInterval": {
+			conf: &StateChangeConf{
+				MinTimeout:   500 * time.Millisecond,
+				PollInterval: 3 * time.Second,
+			},
+			expected: []time.Duration{
+				3 * time.Second,
+				3 * time.Second,
+				3 * time.Second,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			policy := testCase.conf.refreshPolicy()
+
+			for retry, expected := range testCase.expected {
+				if got := policy.backoff(retry); got != expected {
+					t.Errorf("retry %d: expected backoff %s, got: %s", retry, expected, got)
+				}
+			}
+		})
+	}
+}
+
+type testError struct {
+	attempt int
+}
+
+func (e *testError) Error() string {
+	return "test error"
+}
@@ -5,12 +5,18 @@ package retry
 
 import (
 	"context"
+	"errors"
 	"log"
+	"sync"
 	"time"
 )
 
 var refreshGracePeriod = 30 * time.Second
 
+// errRefreshPending is returned to Do by the StateChangeConf refresh loop to
+// refresh again after the backoff.
+var errRefreshPending = errors.New("refresh pending")
+
 // StateRefreshFunc is a function type used for StateChangeConf that is
 // responsible for refreshing the item being watched for a state change.
 //
@@ -36,8 +42,71 @@ type StateChangeConf struct {
 
 	// This is to work around inconsistent APIs
 	ContinuousTargetOccurence int // Number of times the Target state has to occur continuously
+
+	// backoff overrides the Policy for waiting between refreshes, such as
+	// for RetryWithBackoff.
+	backoff *Policy
 }
 
+// WaitForAll waits for several objects, or several aspects of one object,
+// to each reach the Target state of their StateChangeConf, such as an
+// instance running while its health checks pass and its DNS record
+// propagates. The configurations are waited on concurrently, sharing the
+// given timeout, which also caps any longer Timeout of an individual
+// configuration. The given configurations are not modified.
+//
+// It returns the results of each configuration in the same order, along
+// with all of their errors joined together. Once any configuration returns
+// an error, waiting for the others is cancelled, so the error is returned
+// promptly. The results of cancelled configurations are nil, and their
+// cancellation is not included in the errors.
+func WaitForAll(ctx context.Context, timeout time.Duration, confs ...*StateChangeConf) ([]interface{}, error) {
+	results := make([]interface{}, len(confs))
+	errs := make([]error, len(confs))
+
+	waitCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+
+	for i, conf := range confs {
+		c := *conf
+
+		if c.Timeout == 0 || c.Timeout > timeout {
+			c.Timeout = timeout
+		}
+
+		wg.Add(1)
+
+		go func(i int, c *StateChangeConf) {
+			defer wg.Done()
+
+			results[i], errs[i] = c.WaitForStateContext(waitCtx)
+
+			if errs[i] != nil {
+				cancel(errWaitForAllFailed)
+			}
+		}(i, &c)
+	}
+
+	wg.Wait()
+
+	// Drop the errors of configurations cancelled after another failed.
+	if errors.Is(context.Cause(waitCtx), errWaitForAllFailed) {
+		for i, err := range errs {
+			if errors.Is(err, context.Canceled) {
+				errs[i] = nil
+			}
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// errWaitForAllFailed is the cause of cancelling the remaining
+// configurations of WaitForAll after one returns an error.
+var errWaitForAllFailed = errors.New("another configuration failed")
+
 // WaitForStateContext watches an object and waits for it to achieve the state
 // specified in the configuration using the specified Refresh() func,
 // waiting the number of seconds specified in the timeout configuration.
@@ -92,24 +161,24 @@ func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface
 			return
 		}
 
-		// start with 0 delay for the first loop
-		var wait time.Duration
-
-		for {
-			// store the last result
-			resCh <- result
+		// stop retrying once the refresh loop is cancelled
+		refreshCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
-			// wait and watch for cancellation
+		go func() {
 			select {
 			case <-cancelCh:
-				return
-			case <-time.After(wait):
-				// first round had no wait
-				if wait == 0 {
-					wait = 100 * time.Millisecond
-				}
+				cancel()
+			case <-refreshCtx.Done():
 			}
+		}()
 
+		// store the initial empty result
+		resCh <- result
+
+		// The function returns nil once a final result has been sent, or a
+		// retryable error to refresh again after the backoff.
+		_ = Do(refreshCtx, conf.refreshPolicy(), func(context.Context) error {
 			res, currentState, err := conf.Refresh()
 			result = Result{
 				Result: res,
@@ -119,7 +188,7 @@ func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface
 
 			if err != nil {
 				resCh <- result
-				return
+				return nil
 			}
 
 			// If we're waiting for the absence of a thing, then return
@@ -128,9 +197,10 @@ func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface
 				if conf.ContinuousTargetOccurence == targetOccurence {
 					result.Done = true
 					resCh <- result
-					return
+					return nil
 				}
-				continue
+				resCh <- result
+				return errRetryWithoutBackoff
 			}
 
 			if res == nil {
@@ -143,7 +213,7 @@ func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface
 						Retries:   notfoundTick,
 					}
 					resCh <- result
-					return
+					return nil
 				}
 			} else {
 				// Reset the counter for when a resource isn't found
@@ -157,7 +227,7 @@ func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface
 						if conf.ContinuousTargetOccurence == targetOccurence {
 							result.Done = true
 							resCh <- result
-							return
+							return nil
 						}
 						continue
 					}
@@ -178,30 +248,21 @@ func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface
 						ExpectedState: conf.Target,
 					}
 					resCh <- result
-					return
+					return nil
 				}
 			}
 
+			// store the last result
+			resCh <- result
+
 			// Wait between refreshes using exponential backoff, except when
 			// waiting for the target state to reoccur.
-			if targetOccurence == 0 {
-				wait *= 2
+			if targetOccurence > 0 {
+				return errRetryWithoutBackoff
 			}
 
-			// If a poll interval has been specified, choose that interval.
-			// Otherwise bound the default value.
-			if conf.PollInterval > 0 && conf.PollInterval < 180*time.Second {
-				wait = conf.PollInterval
-			} else {
-				if wait < conf.MinTimeout {
-					wait = conf.MinTimeout
-				} else if wait > 10*time.Second {
-					wait = 10 * time.Second
-				}
-			}
-
-			log.Printf("[TRACE] Waiting %s before next try", wait)
-		}
+			return errRefreshPending
+		})
 	}()
 
 	// store the last value result from the refresh loop
@@ -273,6 +334,40 @@ func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface
 	}
 }
 
+// refreshPolicy returns the Policy for waiting between calls of Refresh. If
+// the Policy has been overridden, such as by RetryWithBackoff, it is used. If
+// a poll interval has been specified, that interval is used. Otherwise the
+// wait starts at MinTimeout, or 200 milliseconds, and is bounded to 10
+// seconds.
+func (conf *StateChangeConf) refreshPolicy() Policy {
+	if conf.backoff != nil {
+		return *conf.backoff
+	}
+
+	if conf.PollInterval > 0 && conf.PollInterval < 180*time.Second {
+		return Policy{
+			InitialBackoff: conf.PollInterval,
+			MaxBackoff:     conf.PollInterval,
+			Multiplier:     1,
+		}
+	}
+
+	policy := Policy{
+		InitialBackoff: 2 * defaultPolicyInitialBackoff,
+		MaxBackoff:     defaultPolicyMaxBackoff,
+	}
+
+	if conf.MinTimeout > policy.InitialBackoff {
+		policy.InitialBackoff = conf.MinTimeout
+	}
+
+	if conf.MinTimeout > policy.MaxBackoff {
+		policy.MaxBackoff = conf.MinTimeout
+	}
+
+	return policy
+}
+
 // WaitForState watches an object and waits for it to achieve the state
 // specified in the configuration using the specified Refresh() func,
 // waiting the number of seconds specified in the timeout configuration.
@@ -374,3 +374,115 @@ func TestWaitForStateContext_cancel(t *testing.T) {
 		t.Fatalf("Expected canceled context error, got: %s", err)
 	}
 }
+
+func TestWaitForAll_success(t *testing.T) {
+	confs := []*StateChangeConf{
+		{
+			Pending: []string{"pending"},
+			Target:  []string{"running"},
+			Refresh: SuccessfulStateRefreshFunc(),
+		},
+		{
+			Pending: []string{"pending"},
+			Target:  []string{"running"},
+			Refresh: SuccessfulStateRefreshFunc(),
+		},
+	}
+
+	results, err := WaitForAll(context.Background(), 10*time.Second, confs...)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(results) != 2 || results[0] == nil || results[1] == nil {
+		t.Fatalf("expected 2 results, got: %#v", results)
+	}
+
+	if confs[0].Timeout != 0 {
+		t.Fatalf("expected configurations not to be modified, got Timeout: %s", confs[0].Timeout)
+	}
+}
+
+func TestWaitForAll_failure(t *testing.T) {
+	results, err := WaitForAll(context.Background(), 10*time.Second,
+		&StateChangeConf{
+			Pending: []string{"pending"},
+			Target:  []string{"running"},
+			Refresh: SuccessfulStateRefreshFunc(),
+		},
+		&StateChangeConf{
+			Pending: []string{"pending"},
+			Target:  []string{"running"},
+			Refresh: FailedStateRefreshFunc(),
+		},
+		&StateChangeConf{
+			Pending: []string{"pending"},
+			Target:  []string{"running"},
+			Refresh: FailedStateRefreshFunc(),
+		},
+	)
+	if err == nil {
+		t.Fatal("Expected error. No error returned.")
+	}
+
+	// The second failure may be cancelled by the first, so it is not always
+	// aggregated.
+	if got := err.Error(); got != "failed" && got != "failed\nfailed" {
+		t.Fatalf("Expected failed errors to be aggregated, got: %q", got)
+	}
+
+	if results[1] != nil || results[2] != nil {
+		t.Fatalf("Expected no results of failed configurations, got: %#v", results)
+	}
+}
+
+func TestWaitForAll_failureCancels(t *testing.T) {
+	start := time.Now()
+
+	_, err := WaitForAll(context.Background(), time.Hour,
+		&StateChangeConf{
+			Pending: []string{"pending"},
+			Target:  []string{"running"},
+			Refresh: func() (interface{}, string, error) {
+				return struct{}{}, "pending", nil
+			},
+		},
+		&StateChangeConf{
+			Pending: []string{"pending"},
+			Target:  []string{"running"},
+			Refresh: FailedStateRefreshFunc(),
+		},
+	)
+
+	if err == nil || err.Error() != "failed" {
+		t.Fatalf("Expected only the failed error, got: %#v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Expected the failure to cancel waiting, took %s", elapsed)
+	}
+}
+
+func TestWaitForAll_timeout(t *testing.T) {
+	start := time.Now()
+
+	_, err := WaitForAll(context.Background(), 200*time.Millisecond,
+		&StateChangeConf{
+			Pending: []string{"pending"},
+			Target:  []string{"running"},
+			Refresh: func() (interface{}, string, error) {
+				return struct{}{}, "pending", nil
+			},
+			Timeout: time.Hour,
+		},
+	)
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected TimeoutError, got: %#v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Expected the shared timeout to apply, took %s", elapsed)
+	}
+}
@@ -16,16 +16,39 @@ import (
 // Cancellation from the passed in context will propagate through to the
 // underlying StateChangeConf
 func RetryContext(ctx context.Context, timeout time.Duration, f RetryFunc) error {
+	return RetryWithBackoff(ctx, timeout, Policy{
+		InitialBackoff: 500 * time.Millisecond,
+	}, f)
+}
+
+// RetryWithBackoff is RetryContext with a configurable wait between calls of
+// the function, such as to add jitter when many resources poll the same API:
+//
+//	err := retry.RetryWithBackoff(ctx, d.Timeout(schema.TimeoutCreate), retry.Policy{
+//	  InitialBackoff: time.Second,
+//	  MaxBackoff:     30 * time.Second,
+//	  Jitter:         0.5,
+//	}, func() *retry.RetryError {
+//	  ...
+//	})
+//
+// The RetryError returned by the function decides whether it is retried, so
+// the MaxAttempts and Retryable fields of the Policy are not used. The
+// timeout and the context bound the total time regardless of the backoff.
+func RetryWithBackoff(ctx context.Context, timeout time.Duration, policy Policy, f RetryFunc) error {
+	policy.MaxAttempts = 0
+	policy.Retryable = nil
+
 	// These are used to pull the error out of the function; need a mutex to
 	// avoid a data race.
 	var resultErr error
 	var resultErrMu sync.Mutex
 
 	c := &StateChangeConf{
-		Pending:    []string{"retryableerror"},
-		Target:     []string{"success"},
-		Timeout:    timeout,
-		MinTimeout: 500 * time.Millisecond,
+		Pending: []string{"retryableerror"},
+		Target:  []string{"success"},
+		Timeout: timeout,
+		backoff: &policy,
 		Refresh: func() (interface{}, string, error) {
 			rerr := f()
 
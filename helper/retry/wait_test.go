@@ -160,3 +160,88 @@ func TestRetryContext_deadline(t *testing.T) {
 		t.Fatalf("Expected context.DeadlineExceeded error, got: %s", err)
 	}
 }
+
+func TestRetryWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	var calls []time.Time
+
+	f := func() *RetryError {
+		calls = append(calls, time.Now())
+
+		if len(calls) == 3 {
+			return nil
+		}
+
+		return RetryableError(fmt.Errorf("error"))
+	}
+
+	err := RetryWithBackoff(context.Background(), 10*time.Second, Policy{
+		InitialBackoff: 50 * time.Millisecond,
+		Multiplier:     3,
+		Jitter:         0.5,
+	}, f)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got: %d", len(calls))
+	}
+
+	// The wait triples after each retry, reduced by up to half.
+	for i, minWait := range []time.Duration{25 * time.Millisecond, 75 * time.Millisecond} {
+		if wait := calls[i+1].Sub(calls[i]); wait < minWait {
+			t.Errorf("retry %d: expected wait of at least %s, got: %s", i, minWait, wait)
+		}
+	}
+}
+
+func TestRetryWithBackoff_nonRetryable(t *testing.T) {
+	t.Parallel()
+
+	tries := 0
+	f := func() *RetryError {
+		tries++
+
+		if tries == 1 {
+			return RetryableError(fmt.Errorf("retryable"))
+		}
+
+		return NonRetryableError(fmt.Errorf("fatal"))
+	}
+
+	err := RetryWithBackoff(context.Background(), 10*time.Second, Policy{
+		InitialBackoff: time.Millisecond,
+		MaxAttempts:    1,
+	}, f)
+	if err == nil || err.Error() != "fatal" {
+		t.Fatalf("expected fatal error, got: %v", err)
+	}
+
+	if tries != 2 {
+		t.Fatalf("expected 2 tries, got: %d", tries)
+	}
+}
+
+func TestRetryWithBackoff_timeout(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+
+	f := func() *RetryError {
+		return RetryableError(fmt.Errorf("always"))
+	}
+
+	err := RetryWithBackoff(context.Background(), 100*time.Millisecond, Policy{
+		InitialBackoff: time.Hour,
+		MaxBackoff:     time.Hour,
+	}, f)
+	if err == nil || err.Error() != "always" {
+		t.Fatalf("expected last error, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("expected timeout to bound the backoff, took: %s", elapsed)
+	}
+}
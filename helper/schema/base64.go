@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/helper/base64encoding"
+)
+
+// DecodeBase64 returns the decoded value of the given string attribute key,
+// which is expected to contain base64 data. Padded and unpadded input is
+// accepted, using the standard alphabet or, if allowURLEncoding is true, the
+// URL-safe alphabet. An empty or unset value decodes to no data.
+//
+// This is intended to be paired with validation.StringIsBase64Diag, given the
+// same allowURLEncoding, which then accepts exactly the same values.
+//
+//	data, diags := schema.DecodeBase64(d, "content_base64", false)
+//	if diags.HasError() {
+//	  return diags
+//	}
+func DecodeBase64(d *ResourceData, key string, allowURLEncoding bool) ([]byte, diag.Diagnostics) {
+	v, ok := d.Get(key).(string)
+	if !ok {
+		return nil, diag.Errorf("%s: expected string value to decode as base64, got %T", key, d.Get(key))
+	}
+
+	b, err := base64encoding.For(v, allowURLEncoding).DecodeString(v)
+	if err != nil {
+		var path cty.Path
+
+		// Only top level keys can be represented without the schema.
+		if !strings.Contains(key, ".") {
+			path = cty.GetAttrPath(key)
+		}
+
+		return nil, diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid base64 value",
+				Detail:        fmt.Sprintf("Unable to decode %s as base64: %s", key, err),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return b, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDecodeBase64(t *testing.T) {
+	cases := map[string]struct {
+		Value            string
+		AllowURLEncoding bool
+		Expected         []byte
+		Err              bool
+	}{
+		"empty": {
+			Value:    "",
+			Expected: []byte{},
+		},
+		"padded": {
+			Value:    "RG8naCE=",
+			Expected: []byte("Do'h!"),
+		},
+		"unpadded": {
+			Value:    "RG8naCE",
+			Expected: []byte("Do'h!"),
+		},
+		"url-padded": {
+			Value:            "-_8=",
+			AllowURLEncoding: true,
+			Expected:         []byte{0xfb, 0xff},
+		},
+		"url-unpadded": {
+			Value:            "-_8",
+			AllowURLEncoding: true,
+			Expected:         []byte{0xfb, 0xff},
+		},
+		"url-not-allowed": {
+			Value: "-_8=",
+			Err:   true,
+		},
+		"invalid": {
+			Value: "Do'h!",
+			Err:   true,
+		},
+		"mixed-alphabets": {
+			Value:            "+_8=",
+			AllowURLEncoding: true,
+			Err:              true,
+		},
+	}
+
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"content": {
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			d := r.Data(&terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"content": tc.Value,
+				},
+			})
+
+			got, diags := DecodeBase64(d, "content", tc.AllowURLEncoding)
+
+			if diags.HasError() != tc.Err {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if tc.Err {
+				if len(diags[0].AttributePath) != 1 {
+					t.Fatalf("expected attribute path, got: %#v", diags[0].AttributePath)
+				}
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, got)
+			}
+		})
+	}
+}
@@ -167,6 +167,8 @@ func (s *Schema) coreConfigSchemaAttribute() *configschema.Attribute {
 		Description:     desc,
 		DescriptionKind: descKind,
 		Deprecated:      s.Deprecated != "",
+		SupersededBy:    s.SupersededBy,
+		UpdateCost:      s.UpdateCost,
 	}
 }
 
@@ -188,6 +190,8 @@ func (s *Schema) coreConfigSchemaBlock() *configschema.NestedBlock {
 		ret.Block.Description = desc
 		ret.Block.DescriptionKind = descKind
 		ret.Block.Deprecated = s.Deprecated != ""
+		ret.Block.SupersededBy = s.SupersededBy
+		ret.Block.UpdateCost = s.UpdateCost
 	}
 	switch s.Type {
 	case TypeList:
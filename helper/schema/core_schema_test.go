@@ -97,6 +97,75 @@ func TestSchemaMapCoreConfigSchema(t *testing.T) {
 				BlockTypes: map[string]*configschema.NestedBlock{},
 			}),
 		},
+		"superseded": {
+			map[string]*Schema{
+				"name": {
+					Type:         TypeString,
+					Optional:     true,
+					Deprecated:   "Use settings.name instead",
+					SupersededBy: "settings.0.name",
+				},
+			},
+			testResource(&configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"name": {
+						Type:         cty.String,
+						Optional:     true,
+						Deprecated:   true,
+						SupersededBy: "settings.0.name",
+					},
+				},
+				BlockTypes: map[string]*configschema.NestedBlock{},
+			}),
+		},
+		"update cost": {
+			map[string]*Schema{
+				"size": {
+					Type:       TypeInt,
+					Optional:   true,
+					UpdateCost: 10,
+				},
+				"settings": {
+					Type:       TypeList,
+					Optional:   true,
+					UpdateCost: 5,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": {
+								Type:       TypeString,
+								Optional:   true,
+								UpdateCost: 1,
+							},
+						},
+					},
+				},
+			},
+			testResource(&configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"size": {
+						Type:       cty.Number,
+						Optional:   true,
+						UpdateCost: 10,
+					},
+				},
+				BlockTypes: map[string]*configschema.NestedBlock{
+					"settings": {
+						Nesting: configschema.NestingList,
+						Block: configschema.Block{
+							Attributes: map[string]*configschema.Attribute{
+								"name": {
+									Type:       cty.String,
+									Optional:   true,
+									UpdateCost: 1,
+								},
+							},
+							BlockTypes: map[string]*configschema.NestedBlock{},
+							UpdateCost: 5,
+						},
+					},
+				},
+			}),
+		},
 		"simple collections": {
 			map[string]*Schema{
 				"list": {
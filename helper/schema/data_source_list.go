@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// DataSourceListPageFunc returns a single page of items for a
+// DataSourceList. The token is empty for the first page and is otherwise the
+// next page token returned for the previous page. Returning an empty next
+// page token ends pagination.
+//
+// Each item is a map of attribute values matching the Elem schema of the
+// DataSourceList attribute.
+type DataSourceListPageFunc func(ctx context.Context, d *ResourceData, meta interface{}, token string) (items []map[string]interface{}, next string, err error)
+
+// DataSourceListFilterFunc reports whether an item returned by a
+// DataSourceListPageFunc should be included in the DataSourceList result,
+// such as by comparing it with filter arguments in the configuration.
+type DataSourceListFilterFunc func(d *ResourceData, item map[string]interface{}) bool
+
+// DataSourceList implements the "list items matching filters" data source
+// pattern. Its Read method can be used as the data source ReadContext: it
+// pages through all items with PageFunc, keeps those accepted by FilterFunc,
+// and sets the matches as the TypeList attribute named by Key.
+//
+// For example:
+//
+//	list := schema.DataSourceList{
+//	  Key:   "things",
+//	  IDKey: "thing_id",
+//	  PageFunc: func(ctx context.Context, d *schema.ResourceData, meta interface{}, token string) ([]map[string]interface{}, string, error) {
+//	    page, err := meta.(*Client).ListThings(ctx, token)
+//	    if err != nil {
+//	      return nil, "", err
+//	    }
+//	    return flattenThings(page.Things), page.NextToken, nil
+//	  },
+//	  FilterFunc: func(d *schema.ResourceData, item map[string]interface{}) bool {
+//	    return item["status"] == d.Get("status")
+//	  },
+//	}
+//
+//	return &schema.Resource{
+//	  ReadContext: list.Read,
+//	  // ...
+//	}
+type DataSourceList struct {
+	// Key is the name of the TypeList attribute to set the matching items
+	// to. It is required.
+	Key string
+
+	// IDKey is the item attribute which uniquely identifies each item,
+	// defaulting to "id". The data source ID is derived from the IDs of all
+	// matching items, so it is stable for the same result regardless of
+	// item order.
+	IDKey string
+
+	// PageFunc is called for each page of items. It is required.
+	PageFunc DataSourceListPageFunc
+
+	// FilterFunc is called for each item to decide whether it matches. If
+	// nil, all items match.
+	FilterFunc DataSourceListFilterFunc
+
+	// Single requires exactly one matching item, returning an error
+	// diagnostic if there are none or more than one. The data source ID is
+	// then the ID of the matching item.
+	Single bool
+}
+
+// Read lists, filters, and sets the matching items as described by the
+// DataSourceList. It has the signature of ReadContextFunc.
+func (l DataSourceList) Read(ctx context.Context, d *ResourceData, meta interface{}) diag.Diagnostics {
+	idKey := l.IDKey
+
+	if idKey == "" {
+		idKey = "id"
+	}
+
+	// A nil result would be stored as a null list, so start from an empty one.
+	items := make([]interface{}, 0)
+	ids := make([]string, 0)
+
+	var token string
+
+	for {
+		page, next, err := l.PageFunc(ctx, d, meta, token)
+
+		if err != nil {
+			return diag.Errorf("error listing %s: %s", l.Key, err)
+		}
+
+		for _, item := range page {
+			if l.FilterFunc != nil && !l.FilterFunc(d, item) {
+				continue
+			}
+
+			id, ok := item[idKey]
+
+			if !ok {
+				return diag.Errorf("error listing %s: item is missing %s attribute", l.Key, idKey)
+			}
+
+			items = append(items, item)
+			ids = append(ids, fmt.Sprintf("%v", id))
+		}
+
+		// Also stop if the token repeats, rather than looping forever.
+		if next == "" || next == token {
+			break
+		}
+
+		token = next
+	}
+
+	if l.Single {
+		switch len(items) {
+		case 0:
+			return diag.Errorf("no matching %s found", l.Key)
+		case 1:
+		default:
+			return diag.Errorf("%d matching %s found, use more specific filters to match a single result", len(items), l.Key)
+		}
+	}
+
+	if err := d.Set(l.Key, items); err != nil {
+		return diag.Errorf("error setting %s: %s", l.Key, err)
+	}
+
+	if l.Single {
+		d.SetId(ids[0])
+
+		return nil
+	}
+
+	sort.Strings(ids)
+	d.SetId(strconv.Itoa(HashString(strings.Join(ids, ","))))
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDataSourceListRead(t *testing.T) {
+	pages := map[string][]map[string]interface{}{
+		"": {
+			{"thing_id": "a", "status": "active"},
+			{"thing_id": "b", "status": "stopped"},
+		},
+		"page-2": {
+			{"thing_id": "c", "status": "active"},
+		},
+	}
+	nextTokens := map[string]string{
+		"": "page-2",
+	}
+
+	pageFunc := func(_ context.Context, _ *ResourceData, _ interface{}, token string) ([]map[string]interface{}, string, error) {
+		return pages[token], nextTokens[token], nil
+	}
+
+	statusFilter := func(status string) DataSourceListFilterFunc {
+		return func(_ *ResourceData, item map[string]interface{}) bool {
+			return item["status"] == status
+		}
+	}
+
+	cases := map[string]struct {
+		List        DataSourceList
+		ExpectedIDs []string
+		ExpectedID  string
+		Err         bool
+	}{
+		"all pages": {
+			List: DataSourceList{
+				PageFunc: pageFunc,
+			},
+			ExpectedIDs: []string{"a", "b", "c"},
+			ExpectedID:  strconv.Itoa(HashString("a,b,c")),
+		},
+		"filtered": {
+			List: DataSourceList{
+				PageFunc:   pageFunc,
+				FilterFunc: statusFilter("active"),
+			},
+			ExpectedIDs: []string{"a", "c"},
+			ExpectedID:  strconv.Itoa(HashString("a,c")),
+		},
+		"empty": {
+			List: DataSourceList{
+				PageFunc:   pageFunc,
+				FilterFunc: statusFilter("deleted"),
+			},
+			ExpectedIDs: []string{},
+			ExpectedID:  strconv.Itoa(HashString("")),
+		},
+		"single": {
+			List: DataSourceList{
+				PageFunc:   pageFunc,
+				FilterFunc: statusFilter("stopped"),
+				Single:     true,
+			},
+			ExpectedIDs: []string{"b"},
+			ExpectedID:  "b",
+		},
+		"single with none": {
+			List: DataSourceList{
+				PageFunc:   pageFunc,
+				FilterFunc: statusFilter("deleted"),
+				Single:     true,
+			},
+			Err: true,
+		},
+		"single with many": {
+			List: DataSourceList{
+				PageFunc:   pageFunc,
+				FilterFunc: statusFilter("active"),
+				Single:     true,
+			},
+			Err: true,
+		},
+		"missing id": {
+			List: DataSourceList{
+				IDKey:    "id",
+				PageFunc: pageFunc,
+			},
+			Err: true,
+		},
+		"page error": {
+			List: DataSourceList{
+				PageFunc: func(_ context.Context, _ *ResourceData, _ interface{}, _ string) ([]map[string]interface{}, string, error) {
+					return nil, "", errors.New("boom")
+				},
+			},
+			Err: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Resource{
+				Schema: map[string]*Schema{
+					"things": {
+						Type:     TypeList,
+						Computed: true,
+						Elem: &Resource{
+							Schema: map[string]*Schema{
+								"thing_id": {
+									Type:     TypeString,
+									Computed: true,
+								},
+								"status": {
+									Type:     TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			tc.List.Key = "things"
+			if tc.List.IDKey == "" {
+				tc.List.IDKey = "thing_id"
+			}
+
+			d := r.Data(&terraform.InstanceState{})
+
+			diags := tc.List.Read(context.Background(), d, nil)
+			if diags.HasError() != tc.Err {
+				t.Fatalf("unexpected diagnostics: %#v", diags)
+			}
+			if tc.Err {
+				return
+			}
+
+			if d.Id() != tc.ExpectedID {
+				t.Errorf("expected ID %q, got %q", tc.ExpectedID, d.Id())
+			}
+
+			things, ok := d.Get("things").([]interface{})
+			if !ok {
+				t.Fatalf("expected things to be a list, got %#v", d.Get("things"))
+			}
+
+			ids := make([]string, 0, len(things))
+			for _, thing := range things {
+				ids = append(ids, thing.(map[string]interface{})["thing_id"].(string))
+			}
+
+			if !reflect.DeepEqual(ids, tc.ExpectedIDs) {
+				t.Errorf("expected items %#v, got %#v", tc.ExpectedIDs, ids)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
+	helperlogging "github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/configs/configschema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/configs/hcl2shim"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/logging"
@@ -44,6 +45,43 @@ type GRPCProviderServer struct {
 	provider *Provider
 	stopCh   chan struct{}
 	stopMu   sync.Mutex
+
+	readBatchers   map[string]*readBatcher
+	readBatchersMu sync.Mutex
+}
+
+// waitRateLimiter waits on the Provider RateLimiter, if set.
+func (s *GRPCProviderServer) waitRateLimiter(ctx context.Context) error {
+	if s.provider.RateLimiter == nil {
+		return nil
+	}
+
+	if err := s.provider.RateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("waiting for provider rate limiter: %w", err)
+	}
+
+	return nil
+}
+
+// readBatcher returns the readBatcher which collects concurrent ReadResource
+// calls for the given resource type.
+func (s *GRPCProviderServer) readBatcher(typeName string) *readBatcher {
+	s.readBatchersMu.Lock()
+	defer s.readBatchersMu.Unlock()
+
+	if s.readBatchers == nil {
+		s.readBatchers = make(map[string]*readBatcher)
+	}
+
+	b, ok := s.readBatchers[typeName]
+	if !ok {
+		b = &readBatcher{
+			window: defaultReadBatchWindow,
+		}
+		s.readBatchers[typeName] = b
+	}
+
+	return b
 }
 
 // mergeStop is called in a goroutine and waits for the global stop signal
@@ -58,11 +96,23 @@ func mergeStop(ctx context.Context, cancel context.CancelFunc, stopCh chan struc
 	}
 }
 
+// initContext creates the SDK logger contexts for an RPC and attaches the
+// provider LogRedactor, if any, for use by the helper/logging transports.
+func (s *GRPCProviderServer) initContext(ctx context.Context) context.Context {
+	ctx = logging.InitContext(ctx)
+
+	if s.provider.LogRedactor != nil {
+		ctx = helperlogging.ContextWithLogRedactor(ctx, s.provider.LogRedactor)
+	}
+
+	return ctx
+}
+
 // StopContext derives a new context from the passed in grpc context.
 // It creates a goroutine to wait for the server stop and propagates
 // cancellation to the derived grpc context.
 func (s *GRPCProviderServer) StopContext(ctx context.Context) context.Context {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	s.stopMu.Lock()
 	defer s.stopMu.Unlock()
 
@@ -78,7 +128,7 @@ func (s *GRPCProviderServer) serverCapabilities() *tfprotov5.ServerCapabilities
 }
 
 func (s *GRPCProviderServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 
 	logging.HelperSchemaTrace(ctx, "Getting provider metadata")
 
@@ -105,7 +155,7 @@ func (s *GRPCProviderServer) GetMetadata(ctx context.Context, req *tfprotov5.Get
 }
 
 func (s *GRPCProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 
 	logging.HelperSchemaTrace(ctx, "Getting provider schema")
 
@@ -164,7 +214,7 @@ func (s *GRPCProviderServer) getDatasourceSchemaBlock(name string) *configschema
 }
 
 func (s *GRPCProviderServer) PrepareProviderConfig(ctx context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.PrepareProviderConfigResponse{}
 
 	logging.HelperSchemaTrace(ctx, "Preparing provider configuration")
@@ -271,7 +321,7 @@ func (s *GRPCProviderServer) PrepareProviderConfig(ctx context.Context, req *tfp
 }
 
 func (s *GRPCProviderServer) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.ValidateResourceTypeConfigResponse{}
 
 	schemaBlock := s.getResourceSchemaBlock(req.TypeName)
@@ -292,7 +342,7 @@ func (s *GRPCProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 }
 
 func (s *GRPCProviderServer) ValidateDataSourceConfig(ctx context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.ValidateDataSourceConfigResponse{}
 
 	schemaBlock := s.getDatasourceSchemaBlock(req.TypeName)
@@ -319,7 +369,7 @@ func (s *GRPCProviderServer) ValidateDataSourceConfig(ctx context.Context, req *
 }
 
 func (s *GRPCProviderServer) UpgradeResourceState(ctx context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.UpgradeResourceStateResponse{}
 
 	res, ok := s.provider.ResourcesMap[req.TypeName]
@@ -550,7 +600,7 @@ func (s *GRPCProviderServer) removeAttributes(ctx context.Context, v interface{}
 }
 
 func (s *GRPCProviderServer) StopProvider(ctx context.Context, _ *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 
 	logging.HelperSchemaTrace(ctx, "Stopping provider")
 
@@ -568,7 +618,7 @@ func (s *GRPCProviderServer) StopProvider(ctx context.Context, _ *tfprotov5.Stop
 }
 
 func (s *GRPCProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.ConfigureProviderResponse{}
 
 	schemaBlock := s.getProviderSchemaBlock()
@@ -642,7 +692,7 @@ func (s *GRPCProviderServer) ConfigureProvider(ctx context.Context, req *tfproto
 }
 
 func (s *GRPCProviderServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.ReadResourceResponse{
 		// helper/schema did previously handle private data during refresh, but
 		// core is now going to expect this to be maintained in order to
@@ -705,7 +755,12 @@ func (s *GRPCProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Re
 		instanceState.ProviderMeta = providerSchemaVal
 	}
 
-	newInstanceState, diags := res.RefreshWithoutUpgrade(ctx, instanceState, s.provider.Meta())
+	if err := s.waitRateLimiter(ctx); err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(ctx, resp.Diagnostics, err)
+		return resp, nil
+	}
+
+	newInstanceState, diags := res.refresh(ctx, instanceState, s.provider.Meta(), s.readBatcher(req.TypeName))
 	resp.Diagnostics = convert.AppendProtoDiag(ctx, resp.Diagnostics, diags)
 	if diags.HasError() {
 		return resp, nil
@@ -751,7 +806,7 @@ func (s *GRPCProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Re
 }
 
 func (s *GRPCProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.PlanResourceChangeResponse{}
 
 	res, ok := s.provider.ResourcesMap[req.TypeName]
@@ -1030,7 +1085,7 @@ func (s *GRPCProviderServer) PlanResourceChange(ctx context.Context, req *tfprot
 }
 
 func (s *GRPCProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.ApplyResourceChangeResponse{
 		// Start with the existing state as a fallback
 		NewState: req.PriorState,
@@ -1150,6 +1205,11 @@ func (s *GRPCProviderServer) ApplyResourceChange(ctx context.Context, req *tfpro
 		priorState.ProviderMeta = providerSchemaVal
 	}
 
+	if err := s.waitRateLimiter(ctx); err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(ctx, resp.Diagnostics, err)
+		return resp, nil
+	}
+
 	newInstanceState, diags := res.Apply(ctx, priorState, diff, s.provider.Meta())
 	resp.Diagnostics = convert.AppendProtoDiag(ctx, resp.Diagnostics, diags)
 
@@ -1213,7 +1273,7 @@ func (s *GRPCProviderServer) ApplyResourceChange(ctx context.Context, req *tfpro
 }
 
 func (s *GRPCProviderServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.ImportResourceStateResponse{}
 
 	info := &terraform.InstanceInfo{
@@ -1262,6 +1322,11 @@ func (s *GRPCProviderServer) ImportResourceState(ctx context.Context, req *tfpro
 		return resp, nil
 	}
 
+	if err := s.waitRateLimiter(ctx); err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(ctx, resp.Diagnostics, err)
+		return resp, nil
+	}
+
 	newInstanceStates, err := s.provider.ImportState(ctx, info, req.ID)
 	if err != nil {
 		resp.Diagnostics = convert.AppendProtoDiag(ctx, resp.Diagnostics, err)
@@ -1334,7 +1399,7 @@ func (s *GRPCProviderServer) MoveResourceState(ctx context.Context, req *tfproto
 		return nil, fmt.Errorf("MoveResourceState request is nil")
 	}
 
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 
 	logging.HelperSchemaTrace(ctx, "Returning error for MoveResourceState")
 
@@ -1366,7 +1431,7 @@ func (s *GRPCProviderServer) MoveResourceState(ctx context.Context, req *tfproto
 }
 
 func (s *GRPCProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 	resp := &tfprotov5.ReadDataSourceResponse{}
 
 	schemaBlock := s.getDatasourceSchemaBlock(req.TypeName)
@@ -1430,6 +1495,11 @@ func (s *GRPCProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.
 		diff.RawConfig = configVal
 	}
 
+	if err := s.waitRateLimiter(ctx); err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(ctx, resp.Diagnostics, err)
+		return resp, nil
+	}
+
 	// now we can get the new complete data source
 	newInstanceState, diags := res.ReadDataApply(ctx, diff, s.provider.Meta())
 	resp.Diagnostics = convert.AppendProtoDiag(ctx, resp.Diagnostics, diags)
@@ -1457,7 +1527,7 @@ func (s *GRPCProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.
 }
 
 func (s *GRPCProviderServer) CallFunction(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 
 	logging.HelperSchemaTrace(ctx, "Returning error for provider function call")
 
@@ -1471,7 +1541,7 @@ func (s *GRPCProviderServer) CallFunction(ctx context.Context, req *tfprotov5.Ca
 }
 
 func (s *GRPCProviderServer) GetFunctions(ctx context.Context, req *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
-	ctx = logging.InitContext(ctx)
+	ctx = s.initContext(ctx)
 
 	logging.HelperSchemaTrace(ctx, "Getting provider functions")
 
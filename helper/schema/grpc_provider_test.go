@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/ratelimit"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/plugin/convert"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -226,6 +227,49 @@ func TestGRPCProviderServerConfigureProvider(t *testing.T) {
 				},
 			},
 		},
+		"ConfigureContextFunc-NewWarning-AppendWarningf": {
+			server: NewGRPCProviderServer(&Provider{
+				ConfigureContextFunc: func(ctx context.Context, d *ResourceData) (any, diag.Diagnostics) {
+					diags := diag.Diagnostics{
+						diag.NewWarning("test warning summary", "test warning detail"),
+					}
+					diags.AppendWarningf("test warning %s", d.Get("test"))
+
+					return nil, diags
+				},
+				Schema: map[string]*Schema{
+					"test": {
+						Optional: true,
+						Type:     TypeString,
+					},
+				},
+			}),
+			req: &tfprotov5.ConfigureProviderRequest{
+				Config: &tfprotov5.DynamicValue{
+					MsgPack: mustMsgpackMarshal(
+						cty.Object(map[string]cty.Type{
+							"test": cty.String,
+						}),
+						cty.ObjectVal(map[string]cty.Value{
+							"test": cty.StringVal("test-value"),
+						}),
+					),
+				},
+			},
+			expected: &tfprotov5.ConfigureProviderResponse{
+				Diagnostics: []*tfprotov5.Diagnostic{
+					{
+						Severity: tfprotov5.DiagnosticSeverityWarning,
+						Summary:  "test warning summary",
+						Detail:   "test warning detail",
+					},
+					{
+						Severity: tfprotov5.DiagnosticSeverityWarning,
+						Summary:  "test warning test-value",
+					},
+				},
+			},
+		},
 		"ConfigureProvider-warning": {
 			server: NewGRPCProviderServer(&Provider{
 				ConfigureProvider: func(ctx context.Context, req ConfigureProviderRequest, resp *ConfigureProviderResponse) {
@@ -4290,6 +4334,108 @@ func TestPlanResourceChange(t *testing.T) {
 				UnsafeToUseLegacyTypeSystem: true,
 			},
 		},
+		"default-block": {
+			server: NewGRPCProviderServer(&Provider{
+				ResourcesMap: map[string]*Resource{
+					"test": {
+						Schema: map[string]*Schema{
+							"settings": {
+								Type:         TypeList,
+								Optional:     true,
+								MaxItems:     1,
+								DefaultBlock: map[string]interface{}{"mode": "auto"},
+								Elem: &Resource{
+									Schema: map[string]*Schema{
+										"mode": {
+											Type:     TypeString,
+											Optional: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+			req: &tfprotov5.PlanResourceChangeRequest{
+				TypeName: "test",
+				PriorState: &tfprotov5.DynamicValue{
+					MsgPack: mustMsgpackMarshal(
+						cty.Object(map[string]cty.Type{
+							"id": cty.String,
+							"settings": cty.List(cty.Object(map[string]cty.Type{
+								"mode": cty.String,
+							})),
+						}),
+						cty.NullVal(
+							cty.Object(map[string]cty.Type{
+								"id": cty.String,
+								"settings": cty.List(cty.Object(map[string]cty.Type{
+									"mode": cty.String,
+								})),
+							}),
+						),
+					),
+				},
+				ProposedNewState: &tfprotov5.DynamicValue{
+					MsgPack: mustMsgpackMarshal(
+						cty.Object(map[string]cty.Type{
+							"id": cty.String,
+							"settings": cty.List(cty.Object(map[string]cty.Type{
+								"mode": cty.String,
+							})),
+						}),
+						cty.ObjectVal(map[string]cty.Value{
+							"id": cty.UnknownVal(cty.String),
+							"settings": cty.ListValEmpty(cty.Object(map[string]cty.Type{
+								"mode": cty.String,
+							})),
+						}),
+					),
+				},
+				Config: &tfprotov5.DynamicValue{
+					MsgPack: mustMsgpackMarshal(
+						cty.Object(map[string]cty.Type{
+							"id": cty.String,
+							"settings": cty.List(cty.Object(map[string]cty.Type{
+								"mode": cty.String,
+							})),
+						}),
+						cty.ObjectVal(map[string]cty.Value{
+							"id": cty.NullVal(cty.String),
+							"settings": cty.ListValEmpty(cty.Object(map[string]cty.Type{
+								"mode": cty.String,
+							})),
+						}),
+					),
+				},
+			},
+			expected: &tfprotov5.PlanResourceChangeResponse{
+				PlannedState: &tfprotov5.DynamicValue{
+					MsgPack: mustMsgpackMarshal(
+						cty.Object(map[string]cty.Type{
+							"id": cty.String,
+							"settings": cty.List(cty.Object(map[string]cty.Type{
+								"mode": cty.String,
+							})),
+						}),
+						cty.ObjectVal(map[string]cty.Value{
+							"id": cty.UnknownVal(cty.String),
+							"settings": cty.ListVal([]cty.Value{
+								cty.ObjectVal(map[string]cty.Value{
+									"mode": cty.StringVal("auto"),
+								}),
+							}),
+						}),
+					),
+				},
+				RequiresReplace: []*tftypes.AttributePath{
+					tftypes.NewAttributePath().WithAttributeName("id"),
+				},
+				PlannedPrivate:              []byte(`{"_new_extra_shim":{}}`),
+				UnsafeToUseLegacyTypeSystem: true,
+			},
+		},
 		"basic-plan-EnableLegacyTypeSystemPlanErrors": {
 			server: NewGRPCProviderServer(&Provider{
 				ResourcesMap: map[string]*Resource{
@@ -6031,6 +6177,114 @@ func TestPrepareProviderConfig(t *testing.T) {
 	}
 }
 
+func TestPrepareProviderConfig_deprecated(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]*Schema{
+		"foo": {
+			Type:       TypeString,
+			Optional:   true,
+			Deprecated: "Use bar instead.",
+		},
+		"bar": {
+			Type:     TypeString,
+			Optional: true,
+		},
+		"block": {
+			Type:     TypeList,
+			Optional: true,
+			Elem: &Resource{
+				Schema: map[string]*Schema{
+					"baz": {
+						Type:       TypeString,
+						Optional:   true,
+						Deprecated: "Use qux instead.",
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		config   cty.Value
+		expected []*tfprotov5.Diagnostic
+	}{
+		"unset": {
+			config: cty.ObjectVal(map[string]cty.Value{
+				"foo":   cty.NullVal(cty.String),
+				"bar":   cty.StringVal("bar"),
+				"block": cty.NullVal(cty.List(cty.Object(map[string]cty.Type{"baz": cty.String}))),
+			}),
+		},
+		"attribute": {
+			config: cty.ObjectVal(map[string]cty.Value{
+				"foo":   cty.StringVal("foo"),
+				"bar":   cty.NullVal(cty.String),
+				"block": cty.NullVal(cty.List(cty.Object(map[string]cty.Type{"baz": cty.String}))),
+			}),
+			expected: []*tfprotov5.Diagnostic{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityWarning,
+					Summary:   "Argument is deprecated",
+					Detail:    "Use bar instead.",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("foo"),
+				},
+			},
+		},
+		"nested attribute": {
+			config: cty.ObjectVal(map[string]cty.Value{
+				"foo": cty.NullVal(cty.String),
+				"bar": cty.NullVal(cty.String),
+				"block": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"baz": cty.StringVal("baz"),
+					}),
+				}),
+			}),
+			expected: []*tfprotov5.Diagnostic{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityWarning,
+					Summary:   "Argument is deprecated",
+					Detail:    "Use qux instead.",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("block").WithElementKeyInt(0).WithAttributeName("baz"),
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := NewGRPCProviderServer(&Provider{
+				Schema: schema,
+			})
+
+			block := InternalMap(schema).CoreConfigSchema()
+
+			rawConfig, err := msgpack.Marshal(testCase.config, block.ImpliedType())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := server.PrepareProviderConfig(context.Background(), &tfprotov5.PrepareProviderConfigRequest{
+				Config: &tfprotov5.DynamicValue{
+					MsgPack: rawConfig,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, resp.Diagnostics, valueComparer); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
+
 func TestGetSchemaTimeouts(t *testing.T) {
 	r := &Resource{
 		SchemaVersion: 4,
@@ -6969,3 +7223,67 @@ func mustMsgpackUnmarshal(ty cty.Type, b []byte) cty.Value {
 
 	return result
 }
+
+func TestReadDataSource_rateLimiter(t *testing.T) {
+	t.Parallel()
+
+	var reads int
+
+	server := NewGRPCProviderServer(&Provider{
+		DataSourcesMap: map[string]*Resource{
+			"test": {
+				Schema: map[string]*Schema{
+					"id": {
+						Type:     TypeString,
+						Computed: true,
+					},
+				},
+				ReadContext: func(ctx context.Context, d *ResourceData, meta interface{}) diag.Diagnostics {
+					reads++
+					d.SetId("test-id")
+					return nil
+				},
+			},
+		},
+		RateLimiter: ratelimit.NewLimiter(0.001, 1),
+	})
+
+	req := &tfprotov5.ReadDataSourceRequest{
+		TypeName: "test",
+		Config: &tfprotov5.DynamicValue{
+			MsgPack: mustMsgpackMarshal(
+				cty.Object(map[string]cty.Type{
+					"id": cty.String,
+				}),
+				cty.NullVal(cty.Object(map[string]cty.Type{
+					"id": cty.String,
+				})),
+			),
+		},
+	}
+
+	resp, err := server.ReadDataSource(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Diagnostics) > 0 || reads != 1 {
+		t.Fatalf("expected first read to succeed, got %d reads: %#v", reads, resp.Diagnostics)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	resp, err = server.ReadDataSource(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != tfprotov5.DiagnosticSeverityError {
+		t.Fatalf("expected rate limiter error diagnostic, got: %#v", resp.Diagnostics)
+	}
+
+	if reads != 1 {
+		t.Fatalf("expected read not to be called while rate limited, got %d reads", reads)
+	}
+}
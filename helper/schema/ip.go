@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// NormalizeIP is a SchemaStateFunc which canonicalizes an IPv4 or IPv6
+// address, or an address in CIDR notation, so equivalent representations are
+// stored identically in the state. For example, "2001:0db8:0000::0001"
+// becomes "2001:db8::1" and "010.000.000.001" becomes "10.0.0.1". Leading
+// zeros in IPv4 addresses are read as decimal, not octal. The host bits of a
+// CIDR value are preserved. Values which cannot be parsed are returned
+// unchanged, so they can be reported by validation such as
+// validation.IsIPAddress or validation.IsCIDR.
+//
+// This is intended to be paired with SuppressEquivalentIPDiff.
+//
+//	"address": {
+//	  Type:             schema.TypeString,
+//	  Optional:         true,
+//	  ValidateFunc:     validation.IsIPv6Address,
+//	  StateFunc:        schema.NormalizeIP,
+//	  DiffSuppressFunc: schema.SuppressEquivalentIPDiff,
+//	},
+func NormalizeIP(v interface{}) string {
+	s, ok := v.(string)
+	if !ok {
+		return ""
+	}
+
+	if n, ok := normalizeIP(s); ok {
+		return n
+	}
+
+	return s
+}
+
+// SuppressEquivalentIPDiff is a SchemaDiffSuppressFunc which suppresses the
+// difference between two representations of the same IPv4 or IPv6 address,
+// or address in CIDR notation, such as "2001:db8::1" and
+// "2001:0db8:0000::0001", or "10.0.0.1" and "010.0.0.1". The difference is
+// retained if either value cannot be parsed.
+func SuppressEquivalentIPDiff(k, oldValue, newValue string, d *ResourceData) bool {
+	o, ok := normalizeIP(oldValue)
+	if !ok {
+		return false
+	}
+
+	n, ok := normalizeIP(newValue)
+	if !ok {
+		return false
+	}
+
+	return o == n
+}
+
+// normalizeIP returns the canonical form of an IP address or CIDR string and
+// whether it could be parsed.
+func normalizeIP(s string) (string, bool) {
+	if addr, ok := parseIP(s); ok {
+		return addr.String(), true
+	}
+
+	if prefix, err := netip.ParsePrefix(s); err == nil {
+		return prefix.String(), true
+	}
+
+	// netip does not accept IPv4 addresses with leading zeros.
+	if addrPart, bitsPart, ok := strings.Cut(s, "/"); ok {
+		addr, ok := parseIPv4LeadingZeros(addrPart)
+		if !ok {
+			return "", false
+		}
+
+		bits, err := strconv.Atoi(bitsPart)
+		if err != nil || bitsPart != strconv.Itoa(bits) {
+			return "", false
+		}
+
+		prefix := netip.PrefixFrom(addr, bits)
+		if !prefix.IsValid() {
+			return "", false
+		}
+
+		return prefix.String(), true
+	}
+
+	return "", false
+}
+
+// parseIP parses an IPv4 or IPv6 address, including IPv4 addresses with
+// leading zeros.
+func parseIP(s string) (netip.Addr, bool) {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return addr, true
+	}
+
+	return parseIPv4LeadingZeros(s)
+}
+
+// parseIPv4LeadingZeros parses a dotted-quad IPv4 address whose octets may
+// have leading zeros, such as "010.0.0.1", reading each octet as decimal.
+func parseIPv4LeadingZeros(s string) (netip.Addr, bool) {
+	parts := strings.Split(s, ".")
+
+	if len(parts) != 4 {
+		return netip.Addr{}, false
+	}
+
+	var octets [4]byte
+
+	for i, part := range parts {
+		if len(part) == 0 || len(part) > 3 {
+			return netip.Addr{}, false
+		}
+
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return netip.Addr{}, false
+			}
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil || n > 255 {
+			return netip.Addr{}, false
+		}
+
+		octets[i] = byte(n)
+	}
+
+	return netip.AddrFrom4(octets), true
+}
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"testing"
+)
+
+func TestNormalizeIP(t *testing.T) {
+	cases := map[string]struct {
+		Value    interface{}
+		Expected string
+	}{
+		"ipv4": {
+			Value:    "10.0.0.1",
+			Expected: "10.0.0.1",
+		},
+		"ipv4-leading-zeros": {
+			Value:    "010.000.000.001",
+			Expected: "10.0.0.1",
+		},
+		"ipv4-leading-zeros-invalid": {
+			Value:    "010.0.0.256",
+			Expected: "010.0.0.256",
+		},
+		"ipv4-leading-zeros-cidr-bits": {
+			Value:    "010.0.0.0/08",
+			Expected: "010.0.0.0/08",
+		},
+		"ipv4-leading-zeros-cidr-valid": {
+			Value:    "010.0.0.0/8",
+			Expected: "10.0.0.0/8",
+		},
+		"ipv4-leading-zeros-cidr-invalid": {
+			Value:    "010.0.0.0/33",
+			Expected: "010.0.0.0/33",
+		},
+		"ipv6-compressed": {
+			Value:    "2001:db8::1",
+			Expected: "2001:db8::1",
+		},
+		"ipv6-expanded": {
+			Value:    "2001:0db8:0000::0001",
+			Expected: "2001:db8::1",
+		},
+		"ipv6-uppercase": {
+			Value:    "2001:DB8:0:0:0:0:0:1",
+			Expected: "2001:db8::1",
+		},
+		"ipv4-cidr": {
+			Value:    "10.0.0.1/8",
+			Expected: "10.0.0.1/8",
+		},
+		"ipv6-cidr": {
+			Value:    "2001:0db8:0000::/32",
+			Expected: "2001:db8::/32",
+		},
+		"invalid": {
+			Value:    "not-an-ip",
+			Expected: "not-an-ip",
+		},
+		"empty": {
+			Value:    "",
+			Expected: "",
+		},
+		"non-string": {
+			Value:    1,
+			Expected: "",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if actual := NormalizeIP(tc.Value); actual != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestSuppressEquivalentIPDiff(t *testing.T) {
+	cases := map[string]struct {
+		Old, New string
+		Expected bool
+	}{
+		"ipv6-equivalent": {
+			Old:      "2001:db8::1",
+			New:      "2001:0db8:0000::0001",
+			Expected: true,
+		},
+		"ipv6-different": {
+			Old:      "2001:db8::1",
+			New:      "2001:db8::2",
+			Expected: false,
+		},
+		"ipv4-equal": {
+			Old:      "192.168.0.1",
+			New:      "192.168.0.1",
+			Expected: true,
+		},
+		"ipv4-leading-zeros": {
+			Old:      "10.0.0.1",
+			New:      "010.0.0.001",
+			Expected: true,
+		},
+		"ipv4-leading-zeros-different": {
+			Old:      "10.0.0.1",
+			New:      "010.0.0.002",
+			Expected: false,
+		},
+		"ipv4-leading-zeros-cidr": {
+			Old:      "10.0.0.0/8",
+			New:      "010.000.000.000/8",
+			Expected: true,
+		},
+		"cidr-equivalent": {
+			Old:      "2001:db8::/32",
+			New:      "2001:0DB8::/32",
+			Expected: true,
+		},
+		"cidr-different-length": {
+			Old:      "2001:db8::/32",
+			New:      "2001:db8::/48",
+			Expected: false,
+		},
+		"address-and-cidr": {
+			Old:      "10.0.0.1",
+			New:      "10.0.0.1/32",
+			Expected: false,
+		},
+		"invalid": {
+			Old:      "not-an-ip",
+			New:      "not-an-ip",
+			Expected: false,
+		},
+		"empty-old": {
+			Old:      "",
+			New:      "2001:db8::1",
+			Expected: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if actual := SuppressEquivalentIPDiff("address", tc.Old, tc.New, nil); actual != tc.Expected {
+				t.Fatalf("expected %t, got %t", tc.Expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Migration bundles the StateFunc, DiffSuppressFunc, and ValidateDiagFunc
+// needed to change the canonical form of a TypeString attribute, such as
+// from a name to an ARN, without breaking existing configurations.
+//
+// During the transition window, practitioners may configure either the
+// legacy or the new form. Both are validated, the new form is always stored
+// in the state, and no difference is planned between equivalent legacy and
+// new values, such as a legacy value in the state written by an earlier
+// provider version and the new value in the configuration. Once
+// configurations have been updated, the legacy form can be rejected by
+// removing the Migration and validating only the new form.
+//
+//	"role": schema.Migration{
+//	  Migrate: func(v string) (string, error) {
+//	    if strings.HasPrefix(v, "arn:") {
+//	      return v, nil
+//	    }
+//	    return "arn:aws:iam::123456789012:role/" + v, nil
+//	  },
+//	}.Apply(&schema.Schema{
+//	  Type:     schema.TypeString,
+//	  Required: true,
+//	}),
+type Migration struct {
+	// Migrate converts a value in either form to the new form. Values
+	// already in the new form must be returned unchanged. An error is
+	// returned as a validation error diagnostic for values in neither form.
+	Migrate func(v string) (string, error)
+
+	// Validate is an optional function to further validate the value after
+	// it has been converted to the new form.
+	Validate SchemaValidateDiagFunc
+}
+
+// Apply sets the StateFunc, DiffSuppressFunc, and ValidateDiagFunc of the
+// given TypeString schema, replacing any existing functions, and returns it.
+func (m Migration) Apply(s *Schema) *Schema {
+	s.StateFunc = m.StateFunc()
+	s.DiffSuppressFunc = m.DiffSuppressFunc()
+	s.ValidateDiagFunc = m.ValidateDiagFunc()
+
+	return s
+}
+
+// StateFunc returns a SchemaStateFunc which stores the new form of the
+// value. Values which cannot be converted are stored unchanged, so they can
+// be reported by validation.
+func (m Migration) StateFunc() SchemaStateFunc {
+	return func(v interface{}) string {
+		s, ok := v.(string)
+		if !ok {
+			return ""
+		}
+
+		if n, err := m.Migrate(s); err == nil {
+			return n
+		}
+
+		return s
+	}
+}
+
+// DiffSuppressFunc returns a SchemaDiffSuppressFunc which suppresses the
+// difference between values with the same new form. The difference is
+// retained if either value cannot be converted.
+func (m Migration) DiffSuppressFunc() SchemaDiffSuppressFunc {
+	return func(k, oldValue, newValue string, d *ResourceData) bool {
+		if oldValue == "" || newValue == "" {
+			return false
+		}
+
+		o, err := m.Migrate(oldValue)
+		if err != nil {
+			return false
+		}
+
+		n, err := m.Migrate(newValue)
+		if err != nil {
+			return false
+		}
+
+		return o == n
+	}
+}
+
+// ValidateDiagFunc returns a SchemaValidateDiagFunc which reports values
+// that cannot be converted to the new form, then calls Validate, if set,
+// with the new form.
+func (m Migration) ValidateDiagFunc() SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		s, ok := v.(string)
+		if !ok {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Bad value type",
+					Detail:        fmt.Sprintf("Expected value to be string, got %T", v),
+					AttributePath: path,
+				},
+			}
+		}
+
+		n, err := m.Migrate(s)
+		if err != nil {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Invalid value",
+					Detail:        err.Error(),
+					AttributePath: path,
+				},
+			}
+		}
+
+		if m.Validate == nil {
+			return nil
+		}
+
+		return m.Validate(n, path)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testMigrationSchema() map[string]*Schema {
+	return map[string]*Schema{
+		"role": Migration{
+			Migrate: func(v string) (string, error) {
+				if strings.HasPrefix(v, "arn:") {
+					return v, nil
+				}
+
+				if v == "" || strings.Contains(v, ":") {
+					return "", errors.New("expected a role name or ARN")
+				}
+
+				return "arn:example:role/" + v, nil
+			},
+			Validate: func(v interface{}, path cty.Path) diag.Diagnostics {
+				if strings.HasSuffix(v.(string), "/root") {
+					return diag.Diagnostics{{Severity: diag.Error, Summary: "root role", AttributePath: path}}
+				}
+
+				return nil
+			},
+		}.Apply(&Schema{
+			Type:     TypeString,
+			Required: true,
+		}),
+	}
+}
+
+func TestMigration_diff(t *testing.T) {
+	cases := map[string]struct {
+		State  *terraform.InstanceState
+		Config map[string]interface{}
+		Diff   *terraform.InstanceDiff
+	}{
+		"create with legacy form": {
+			Config: map[string]interface{}{
+				"role": "admin",
+			},
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"role": {
+						Old:      "",
+						New:      "arn:example:role/admin",
+						NewExtra: "admin",
+					},
+				},
+			},
+		},
+		"create with new form": {
+			Config: map[string]interface{}{
+				"role": "arn:example:role/admin",
+			},
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"role": {
+						Old:      "",
+						New:      "arn:example:role/admin",
+						NewExtra: "arn:example:role/admin",
+					},
+				},
+			},
+		},
+		"legacy form in state": {
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"role": "admin",
+				},
+			},
+			Config: map[string]interface{}{
+				"role": "arn:example:role/admin",
+			},
+		},
+		"legacy form in configuration": {
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"role": "arn:example:role/admin",
+				},
+			},
+			Config: map[string]interface{}{
+				"role": "admin",
+			},
+		},
+		"changed": {
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"role": "admin",
+				},
+			},
+			Config: map[string]interface{}{
+				"role": "reader",
+			},
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"role": {
+						Old:      "admin",
+						New:      "arn:example:role/reader",
+						NewExtra: "reader",
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := terraform.NewResourceConfigRaw(tc.Config)
+
+			d, err := schemaMap(testMigrationSchema()).Diff(context.Background(), tc.State, c, nil, nil, true)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(tc.Diff, d) {
+				t.Fatalf("expected:\n%#v\n\ngot:\n%#v", tc.Diff, d)
+			}
+		})
+	}
+}
+
+func TestMigration_validate(t *testing.T) {
+	cases := map[string]struct {
+		Value   string
+		Summary string
+	}{
+		"legacy form": {
+			Value: "admin",
+		},
+		"new form": {
+			Value: "arn:example:role/admin",
+		},
+		"neither form": {
+			Value:   "example:admin",
+			Summary: "Invalid value",
+		},
+		"Validate legacy form": {
+			Value:   "root",
+			Summary: "root role",
+		},
+		"Validate new form": {
+			Value:   "arn:example:role/root",
+			Summary: "root role",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"role": tc.Value,
+			})
+
+			diags := schemaMap(testMigrationSchema()).Validate(c)
+
+			if tc.Summary == "" {
+				if len(diags) > 0 {
+					t.Fatalf("unexpected diagnostics: %#v", diags)
+				}
+
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, got: %#v", diags)
+			}
+
+			if diags[0].Summary != tc.Summary {
+				t.Fatalf("expected summary %q, got: %q", tc.Summary, diags[0].Summary)
+			}
+
+			if !diags[0].AttributePath.Equals(cty.GetAttrPath("role")) {
+				t.Fatalf("expected role attribute path, got: %#v", diags[0].AttributePath)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// AttributePlanModifier is a function which can adjust the planned value of
+// an attribute, or require replacement of the resource, during planning.
+// Modifiers are set via Schema.PlanModifiers.
+//
+// Values use the same string representation as DiffSuppressFunc.
+type AttributePlanModifier func(ctx context.Context, req PlanModifierRequest, resp *PlanModifierResponse)
+
+// PlanModifierRequest is the input to an AttributePlanModifier.
+type PlanModifierRequest struct {
+	// Key is the address of the attribute in the flatmap syntax used by
+	// ResourceData, such as "name" or "block.0.name".
+	Key string
+
+	// StateValue is the prior state value of the attribute.
+	StateValue string
+
+	// StateValueNull is true if the attribute is not present in the prior
+	// state, such as when the resource is being created.
+	StateValueNull bool
+
+	// ConfigValue is the configuration value of the attribute.
+	ConfigValue string
+
+	// ConfigValueNull is true if the attribute is not set in the
+	// configuration.
+	ConfigValueNull bool
+
+	// ConfigValueUnknown is true if the configuration value will not be
+	// known until apply.
+	ConfigValueUnknown bool
+
+	// PlanValue is the planned value of the attribute, as adjusted by any
+	// previous modifiers.
+	PlanValue string
+
+	// PlanValueUnknown is true if the planned value will not be known until
+	// apply.
+	PlanValueUnknown bool
+
+	// ResourceData provides access to the prior state and configuration of
+	// the rest of the resource.
+	ResourceData *ResourceData
+}
+
+// PlanModifierResponse is the output of an AttributePlanModifier. It is
+// populated from the plan before the first modifier is called, so modifiers
+// only need to set the fields they change.
+type PlanModifierResponse struct {
+	// PlanValue is the planned value of the attribute.
+	PlanValue string
+
+	// PlanValueUnknown marks the planned value as unknown until apply.
+	PlanValueUnknown bool
+
+	// RequiresReplace marks the change to this attribute as requiring
+	// replacement of the resource, as with ForceNew.
+	RequiresReplace bool
+}
+
+// UseStateForUnknown returns an AttributePlanModifier which plans the prior
+// state value instead of an unknown value, unless the resource is being
+// created or the configuration value is itself unknown. This is intended for
+// Computed attributes which do not change once set, such as identifiers,
+// which would otherwise be shown as "(known after apply)" whenever another
+// attribute is updated.
+func UseStateForUnknown() AttributePlanModifier {
+	return func(_ context.Context, req PlanModifierRequest, resp *PlanModifierResponse) {
+		if req.StateValueNull || req.ConfigValueUnknown || !req.PlanValueUnknown {
+			return
+		}
+
+		resp.PlanValue = req.StateValue
+		resp.PlanValueUnknown = false
+	}
+}
+
+// applyPlanModifiers calls the PlanModifiers of each attribute with a
+// planned change in the diff, updating the diff in place. Attribute diffs
+// which the modifiers leave without a change are removed.
+func (m schemaMap) applyPlanModifiers(ctx context.Context, d *ResourceData, diff *terraform.InstanceDiff) {
+	keys := make([]string, 0, len(diff.Attributes))
+
+	for k := range diff.Attributes {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		attr := diff.Attributes[k]
+
+		if attr == nil || attr.NewRemoved {
+			continue
+		}
+
+		schemaList := addrToSchema(strings.Split(k, "."), m)
+		if len(schemaList) == 0 {
+			continue
+		}
+
+		schema := schemaList[len(schemaList)-1]
+		if len(schema.PlanModifiers) == 0 {
+			continue
+		}
+
+		if inSet(schemaList[:len(schemaList)-1]) {
+			continue
+		}
+
+		req := PlanModifierRequest{
+			Key:          k,
+			ResourceData: d,
+		}
+
+		var inState bool
+		if d.state != nil {
+			req.StateValue, inState = d.state.Attributes[k]
+		}
+		req.StateValueNull = !inState
+
+		config := d.getRaw(k, getSourceConfig|getSourceExact)
+		req.ConfigValueNull = !config.Exists
+		req.ConfigValueUnknown = config.Computed
+		if config.Exists && !config.Computed {
+			_ = mapstructure.WeakDecode(config.Value, &req.ConfigValue)
+		}
+
+		resp := &PlanModifierResponse{
+			PlanValue:        attr.New,
+			PlanValueUnknown: attr.NewComputed,
+			RequiresReplace:  attr.RequiresNew,
+		}
+
+		for _, modifier := range schema.PlanModifiers {
+			req.PlanValue = resp.PlanValue
+			req.PlanValueUnknown = resp.PlanValueUnknown
+
+			modifier(ctx, req, resp)
+		}
+
+		if resp.PlanValue == attr.New && resp.PlanValueUnknown == attr.NewComputed && resp.RequiresReplace == attr.RequiresNew {
+			continue
+		}
+
+		if !resp.PlanValueUnknown && !resp.RequiresReplace && resp.PlanValue == attr.Old {
+			logging.HelperSchemaDebug(ctx, "Ignoring change due to PlanModifiers", map[string]interface{}{logging.KeyAttributePath: k})
+			delete(diff.Attributes, k)
+			continue
+		}
+
+		attr.New = resp.PlanValue
+		attr.NewComputed = resp.PlanValueUnknown
+		attr.RequiresNew = resp.RequiresReplace
+	}
+}
+
+// inSet returns true if any of the given schemas, as returned by
+// addrToSchema, is a TypeSet.
+func inSet(schemaList []*Schema) bool {
+	for _, s := range schemaList {
+		if s.Type == TypeSet {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/configs/hcl2shim"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSchemaMap_DiffPlanModifiers(t *testing.T) {
+	requiresReplace := func(_ context.Context, req PlanModifierRequest, resp *PlanModifierResponse) {
+		if !req.StateValueNull && req.PlanValue != req.StateValue {
+			resp.RequiresReplace = true
+		}
+	}
+
+	cases := map[string]struct {
+		Schema        map[string]*Schema
+		State         *terraform.InstanceState
+		Config        map[string]interface{}
+		CustomizeDiff CustomizeDiffFunc
+		ExpectedDiff  *terraform.InstanceDiff
+	}{
+		"use state for unknown on update": {
+			Schema: map[string]*Schema{
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+				"etag": {
+					Type:          TypeString,
+					Computed:      true,
+					PlanModifiers: []AttributePlanModifier{UseStateForUnknown()},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"name": "old",
+					"etag": "abc",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "new",
+			},
+
+			CustomizeDiff: func(_ context.Context, d *ResourceDiff, meta interface{}) error {
+				return d.SetNewComputed("etag")
+			},
+
+			ExpectedDiff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old: "old",
+						New: "new",
+					},
+				},
+			},
+		},
+
+		"use state for unknown on create": {
+			Schema: map[string]*Schema{
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+				"etag": {
+					Type:          TypeString,
+					Computed:      true,
+					PlanModifiers: []AttributePlanModifier{UseStateForUnknown()},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "new",
+			},
+
+			ExpectedDiff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old: "",
+						New: "new",
+					},
+					"etag": {
+						Old:         "",
+						New:         "",
+						NewComputed: true,
+					},
+				},
+			},
+		},
+
+		"use state for unknown with unknown config": {
+			Schema: map[string]*Schema{
+				"value": {
+					Type:          TypeString,
+					Optional:      true,
+					Computed:      true,
+					PlanModifiers: []AttributePlanModifier{UseStateForUnknown()},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"value": "abc",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"value": hcl2shim.UnknownVariableValue,
+			},
+
+			ExpectedDiff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"value": {
+						Old:         "abc",
+						New:         hcl2shim.UnknownVariableValue,
+						NewComputed: true,
+					},
+				},
+			},
+		},
+
+		"requires replace": {
+			Schema: map[string]*Schema{
+				"name": {
+					Type:          TypeString,
+					Optional:      true,
+					PlanModifiers: []AttributePlanModifier{requiresReplace},
+				},
+				"etag": {
+					Type:          TypeString,
+					Computed:      true,
+					PlanModifiers: []AttributePlanModifier{UseStateForUnknown()},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"name": "old",
+					"etag": "abc",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "new",
+			},
+
+			ExpectedDiff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old:         "old",
+						New:         "new",
+						RequiresNew: true,
+					},
+					"etag": {
+						Old:         "abc",
+						New:         "",
+						NewComputed: true,
+					},
+				},
+			},
+		},
+
+		"modifiers compose in order": {
+			Schema: map[string]*Schema{
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+					PlanModifiers: []AttributePlanModifier{
+						func(_ context.Context, req PlanModifierRequest, resp *PlanModifierResponse) {
+							resp.PlanValue = req.PlanValue + "-suffix"
+						},
+						func(_ context.Context, req PlanModifierRequest, resp *PlanModifierResponse) {
+							if req.PlanValue == req.ConfigValue+"-suffix" {
+								resp.PlanValue = req.StateValue
+							}
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"name": "old",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "new",
+			},
+
+			ExpectedDiff: nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			c := terraform.NewResourceConfigRaw(tc.Config)
+
+			d, err := schemaMap(tc.Schema).Diff(context.Background(), tc.State, c, tc.CustomizeDiff, nil, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(tc.ExpectedDiff, d) {
+				t.Fatalf("expected:\n%#v\n\ngot:\n%#v", tc.ExpectedDiff, d)
+			}
+		})
+	}
+}
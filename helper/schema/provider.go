@@ -9,11 +9,13 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/ratelimit"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/configs/configschema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
@@ -62,6 +64,10 @@ type Provider struct {
 	//
 	// Provider automatically handles routing operations such as Apply,
 	// Diff, etc. to the proper resource.
+	//
+	// The available resources, and their schemas, must not depend on the
+	// provider configuration, as Terraform requests them before the provider
+	// is configured and requires them to be consistent for a provider version.
 	ResourcesMap map[string]*Resource
 
 	// DataSourcesMap is the collection of available data sources that
@@ -102,6 +108,29 @@ type Provider struct {
 	// Terraform sends a cancellation signal.
 	ConfigureProvider func(context.Context, ConfigureProviderRequest, *ConfigureProviderResponse)
 
+	// RateLimiter, if set, is waited on before each call to the create,
+	// read, update, delete, and import implementations of a managed resource
+	// and the read implementation of a data resource, limiting the rate of
+	// those operations across the whole provider. Each operation consumes a
+	// single token however many remote system API calls it makes. If the
+	// context is cancelled while waiting, the operation returns an error
+	// diagnostic without being called.
+	//
+	// Operations which make many remote system calls may prefer to wait on
+	// a ratelimit.Limiter per call instead, such as from an
+	// http.RoundTripper.
+	RateLimiter *ratelimit.Limiter
+
+	// LogRedactor, if set, is called with each HTTP request and response
+	// body before it is written to the logs by the helper/logging HTTP
+	// transports, so that secrets can be removed. It applies to requests
+	// made with the context passed to provider, resource, and data source
+	// functions.
+	//
+	// logging.MaskSensitive, combined with SensitiveAttributeNames, masks
+	// the JSON fields named after this provider's Sensitive attributes.
+	LogRedactor func(body []byte) []byte
+
 	// configured is enabled after a Configure() call
 	configured bool
 
@@ -241,6 +270,34 @@ func (p *Provider) SetMeta(v interface{}) {
 	p.meta = v
 }
 
+// Meta returns the given provider meta, as passed to resource and data
+// source functions, as type T, such as the API client returned by
+// ConfigureContextFunc. If the meta is not of type T, including when the
+// provider has not been configured, it returns the zero value of T and an
+// error diagnostic instead of panicking. For example:
+//
+//	client, diags := schema.Meta[*Client](meta)
+//	if diags.HasError() {
+//	  return diags
+//	}
+func Meta[T any](meta interface{}) (T, diag.Diagnostics) {
+	v, ok := meta.(T)
+
+	if !ok {
+		return v, diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "Unexpected Provider Meta Type",
+				Detail: fmt.Sprintf("Expected provider meta of type %s, got: %T. "+
+					"This is always an issue in the provider and should be reported to the provider developers.",
+					reflect.TypeOf((*T)(nil)).Elem(), meta),
+			},
+		}
+	}
+
+	return v, nil
+}
+
 // GetSchema returns the config schema for the main provider
 // configuration, as would appear in a "provider" block in the
 // configuration files.
@@ -515,6 +572,28 @@ func (p *Provider) ImportState(
 		}
 	}
 
+	// Results may be of other resource types than the requested one, which
+	// must be set with the ResourceData type SetType method. Results without
+	// a type are of the requested type.
+	for _, s := range states {
+		if s.Ephemeral.Type == "" {
+			s.Ephemeral.Type = info.Type
+		}
+
+		if _, ok := p.ResourcesMap[s.Ephemeral.Type]; !ok {
+			return nil, fmt.Errorf("The provider returned a resource of unknown type %q during ImportResourceState. "+
+				"This is generally a bug in the resource implementation for import. "+
+				"Resource import code should only return resources of types supported by the provider. "+
+				"Please report this to the provider developers.", s.Ephemeral.Type)
+		}
+	}
+
+	// Remove any attributes which the importer fetched but which must not be
+	// persisted.
+	for _, s := range states {
+		p.ResourcesMap[s.Ephemeral.Type].redactImportState(s)
+	}
+
 	return states, nil
 }
 
@@ -594,6 +673,46 @@ func (p *Provider) UserAgent(name, version string) string {
 	return ua
 }
 
+// SensitiveAttributeNames returns the sorted, unique names of all Sensitive
+// attributes of the provider, resource, and data source schemas, including
+// attributes nested within blocks. It is intended for use with
+// logging.MaskSensitive to populate LogRedactor.
+func (p *Provider) SensitiveAttributeNames() []string {
+	names := make(map[string]bool)
+
+	sensitiveAttributeNames(p.Schema, names)
+
+	for _, r := range p.ResourcesMap {
+		sensitiveAttributeNames(r.SchemaMap(), names)
+	}
+
+	for _, r := range p.DataSourcesMap {
+		sensitiveAttributeNames(r.SchemaMap(), names)
+	}
+
+	result := make([]string, 0, len(names))
+
+	for name := range names {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func sensitiveAttributeNames(m map[string]*Schema, names map[string]bool) {
+	for k, s := range m {
+		if s.Sensitive {
+			names[k] = true
+		}
+
+		if r, ok := s.Elem.(*Resource); ok {
+			sensitiveAttributeNames(r.SchemaMap(), names)
+		}
+	}
+}
+
 // GRPCProvider returns a gRPC server, for use with terraform-plugin-mux.
 func (p *Provider) GRPCProvider() tfprotov5.ProviderServer {
 	return NewGRPCProviderServer(p)
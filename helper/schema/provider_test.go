@@ -2243,6 +2243,119 @@ func TestProviderImportState(t *testing.T) {
 				},
 			},
 		},
+		"Importer-multiple-types": {
+			provider: &Provider{
+				ResourcesMap: map[string]*Resource{
+					"test_resource": {
+						Importer: &ResourceImporter{
+							StateContext: func(_ context.Context, d *ResourceData, _ interface{}) ([]*ResourceData, error) {
+								other := (&Resource{}).Data(nil)
+								other.SetId("other-id")
+								other.SetType("test_other")
+
+								return []*ResourceData{d, other}, nil
+							},
+						},
+					},
+					"test_other": {},
+				},
+			},
+			info: &terraform.InstanceInfo{
+				Type: "test_resource",
+			},
+			id: "test-id",
+			expectedStates: []*terraform.InstanceState{
+				{
+					Attributes: map[string]string{"id": "test-id"},
+					Ephemeral:  terraform.EphemeralState{Type: "test_resource"},
+					ID:         "test-id",
+					Meta:       map[string]interface{}{"schema_version": "0"},
+				},
+				{
+					Attributes: map[string]string{"id": "other-id"},
+					Ephemeral:  terraform.EphemeralState{Type: "test_other"},
+					ID:         "other-id",
+					Meta:       map[string]interface{}{"schema_version": "0"},
+				},
+			},
+		},
+		"error-unknown-result-type": {
+			provider: &Provider{
+				ResourcesMap: map[string]*Resource{
+					"test_resource": {
+						Importer: &ResourceImporter{
+							StateContext: func(_ context.Context, d *ResourceData, _ interface{}) ([]*ResourceData, error) {
+								d.SetType("test_missing")
+
+								return []*ResourceData{d}, nil
+							},
+						},
+					},
+				},
+			},
+			info: &terraform.InstanceInfo{
+				Type: "test_resource",
+			},
+			id:          "test-id",
+			expectedErr: fmt.Errorf("The provider returned a resource of unknown type \"test_missing\" during ImportResourceState."),
+		},
+		"Importer-ImportRedact": {
+			provider: &Provider{
+				ResourcesMap: map[string]*Resource{
+					"test_resource": {
+						Schema: map[string]*Schema{
+							"name": {
+								Type:     TypeString,
+								Computed: true,
+							},
+							"secret": {
+								Type:      TypeString,
+								Computed:  true,
+								Sensitive: true,
+							},
+							"credentials": {
+								Type:     TypeMap,
+								Computed: true,
+								Elem:     &Schema{Type: TypeString},
+							},
+						},
+						Importer: &ResourceImporter{
+							StateContext: func(_ context.Context, d *ResourceData, _ interface{}) ([]*ResourceData, error) {
+								if err := d.Set("name", "test-name"); err != nil {
+									return nil, err
+								}
+
+								if err := d.Set("secret", "test-secret"); err != nil {
+									return nil, err
+								}
+
+								if err := d.Set("credentials", map[string]interface{}{"key": "test-key"}); err != nil {
+									return nil, err
+								}
+
+								return []*ResourceData{d}, nil
+							},
+						},
+						ImportRedact: []string{"secret", "credentials"},
+					},
+				},
+			},
+			info: &terraform.InstanceInfo{
+				Type: "test_resource",
+			},
+			id: "test-id",
+			expectedStates: []*terraform.InstanceState{
+				{
+					Attributes: map[string]string{
+						"id":   "test-id",
+						"name": "test-name",
+					},
+					Ephemeral: terraform.EphemeralState{Type: "test_resource"},
+					ID:        "test-id",
+					Meta:      map[string]interface{}{"schema_version": "0"},
+				},
+			},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -2409,3 +2522,102 @@ func TestProviderUserAgentAppendViaEnvVar(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderSensitiveAttributeNames(t *testing.T) {
+	p := &Provider{
+		Schema: map[string]*Schema{
+			"api_key": {
+				Type:      TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"region": {
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+		ResourcesMap: map[string]*Resource{
+			"foo": {
+				Schema: map[string]*Schema{
+					"credentials": {
+						Type:     TypeList,
+						Optional: true,
+						Elem: &Resource{
+							Schema: map[string]*Schema{
+								"token": {
+									Type:      TypeString,
+									Optional:  true,
+									Sensitive: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourcesMap: map[string]*Resource{
+			"bar": {
+				Schema: map[string]*Schema{
+					"api_key": {
+						Type:      TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+					"password": {
+						Type:      TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+
+	expected := []string{"api_key", "password", "token"}
+
+	if diff := cmp.Diff(expected, p.SensitiveAttributeNames()); diff != "" {
+		t.Fatalf("unexpected difference: %s", diff)
+	}
+}
+
+func TestMeta(t *testing.T) {
+	type client struct {
+		name string
+	}
+
+	c := &client{name: "test"}
+
+	got, diags := Meta[*client](c)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %#v", diags)
+	}
+
+	if got != c {
+		t.Fatalf("expected %#v, got %#v", c, got)
+	}
+
+	for name, meta := range map[string]interface{}{
+		"nil":        nil,
+		"wrong-type": "test",
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, diags := Meta[*client](meta)
+
+			if !diags.HasError() {
+				t.Fatal("expected error, got none")
+			}
+
+			if got != nil {
+				t.Fatalf("expected zero value, got %#v", got)
+			}
+
+			expected := fmt.Sprintf("Expected provider meta of type *schema.client, got: %T. "+
+				"This is always an issue in the provider and should be reported to the provider developers.", meta)
+
+			if diags[0].Detail != expected {
+				t.Fatalf("expected detail %q, got %q", expected, diags[0].Detail)
+			}
+		})
+	}
+}
@@ -7,11 +7,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -73,6 +76,14 @@ type Resource struct {
 	// The keys of this map are the names used in a practitioner configuration,
 	// such as the attribute or block name. The values describe the structure
 	// and type information of that attribute or block.
+	//
+	// SchemaFunc cannot depend on provider configuration or the provider
+	// meta. Terraform requests schemas before the provider is configured, for
+	// example to decode the provider configuration itself, and expects the
+	// schema of a given provider version to be identical across requests and
+	// across plan and apply. Behavior which varies by remote system version
+	// should instead be implemented with optional or computed attributes,
+	// CustomizeDiff, or validation after ConfigureContextFunc.
 	SchemaFunc func() map[string]*Schema
 
 	// SchemaVersion is the version number for this resource's Schema
@@ -510,6 +521,64 @@ type Resource struct {
 	// combination and multiple of warning and/or error diagnostics.
 	DeleteWithoutTimeout DeleteContextFunc
 
+	// BatchReadFunc is called during refresh instead of the per-instance
+	// Read, ReadContext, or ReadWithoutTimeout implementation, allowing the
+	// provider to refresh many instances of this managed resource type with
+	// a single bulk remote system call. This field is only valid when the
+	// Resource is a managed resource.
+	//
+	// This implementation is optional. Terraform refreshes each instance with
+	// a separate request, usually concurrently. The SDK collects the
+	// instances of this type which are refreshed within a short window of
+	// each other and passes them to BatchReadFunc together. An instance which
+	// is refreshed while no batch of this type is being read is passed to
+	// BatchReadFunc on its own without waiting. If omitted, or
+	// outside of the plugin serving path such as in RefreshWithoutUpgrade,
+	// each instance is read with the per-instance Read implementation, which
+	// must still be set.
+	//
+	// The Context parameter stores SDK information, such as loggers and
+	// timeout deadlines. It is only cancelled once the refresh of every
+	// instance in the batch has been cancelled by Terraform, such as by a
+	// system or practitioner sending SIGINT (Ctrl-c).
+	//
+	// By default, BatchReadFunc has a 20 minute timeout. Use the Timeouts
+	// field to control the default duration or implement customizable
+	// timeouts. The timeout of the first instance in the batch applies.
+	//
+	// The []*ResourceData parameter contains the state data for each managed
+	// resource instance in the batch. Each should be updated as it would be
+	// in ReadContext, including calling the SetId method with an empty string
+	// ("") for instances which no longer exist.
+	//
+	// The interface{} parameter is the result of the Provider type
+	// ConfigureFunc field execution. If the Provider does not define
+	// a ConfigureFunc, this will be nil. This parameter is conventionally
+	// used to store API clients and other provider instance specific data.
+	//
+	// The diagnostics return parameter, if not nil, is reported for every
+	// instance in the batch, so error diagnostics should be reserved for
+	// failures of the batch as a whole.
+	BatchReadFunc BatchReadFunc
+
+	// WaitForReadableAfterCreate, if true, makes the SDK call the Read,
+	// ReadContext, or ReadWithoutTimeout implementation after a successful
+	// create until it succeeds, to wait out eventual consistency in remote
+	// systems where a newly created object is not immediately readable. A
+	// read is considered unsuccessful if it returns an error diagnostic or
+	// calls the SetId method with an empty string (""). This field is only
+	// valid when the Resource is a managed resource.
+	//
+	// Reads are retried with an increasing interval until the create timeout
+	// elapses, including the time already taken by create. Only the
+	// diagnostics of the successful read are returned, alongside those of the
+	// create. If the resource never becomes readable, the diagnostics of the
+	// last read are returned with an additional error diagnostic, and the
+	// created instance is saved to the state so Terraform can mark it as
+	// tainted. When this is enabled, create implementations should not call
+	// the read implementation themselves.
+	WaitForReadableAfterCreate bool
+
 	// CustomizeDiff is called after a difference (plan) has been generated
 	// for the Resource and allows for customizations, such as setting values
 	// not controlled by configuration, conditionally triggering resource
@@ -541,6 +610,9 @@ type Resource struct {
 	// ConfigureFunc field execution. If the Provider does not define
 	// a ConfigureFunc, this will be nil. This parameter is conventionally
 	// used to store API clients and other provider instance specific data.
+	// It may also be nil if Terraform plans the resource before configuring
+	// the provider, such as in unit tests calling the Resource type Diff
+	// method, so CustomizeDiff functions which use it should handle nil.
 	//
 	// The error return parameter, if not nil, will be converted into an error
 	// diagnostic when passed back to Terraform.
@@ -556,6 +628,16 @@ type Resource struct {
 	// by InternalValidate on Resource.
 	Importer *ResourceImporter
 
+	// ImportRedact is a set of top level attribute names which the Importer
+	// may set, such as a secret fetched only to confirm that the remote
+	// object exists, but which must not be persisted. These attributes,
+	// including any values nested within them, are removed from the imported
+	// state before it is returned to Terraform. Values later set by the Read
+	// or ReadContext function after import are unaffected.
+	//
+	// This field is only valid with Importer.
+	ImportRedact []string
+
 	// If non-empty, this string is emitted as the details of a warning
 	// diagnostic during validation (validate, plan, and apply operations).
 	// This field is only valid when the Resource is a managed resource or
@@ -580,6 +662,20 @@ type Resource struct {
 	// always overrides any default values set here, whether shorter or longer.
 	Timeouts *ResourceTimeout
 
+	// TimeoutFunc, if set, is called by the ResourceData Timeout method with
+	// the lowercased operation key, such as TimeoutCreate, to determine the
+	// timeout from the resource data. This allows timeouts to scale with
+	// configured values, such as the size of a volume. This field is only
+	// valid when the Resource is a managed resource or data resource.
+	//
+	// TimeoutFunc takes precedence over the Timeouts defaults, but not over
+	// the practitioner timeouts configuration, so it is only called for
+	// operations whose timeout, or the default timeout, is not set in the
+	// timeouts configuration block. When TimeoutFunc returns zero, the
+	// Timeout method falls back to the Timeouts defaults. It must not call
+	// the Timeout method itself.
+	TimeoutFunc func(op string, d *ResourceData) time.Duration
+
 	// Description is used as the description for docs, the language server and
 	// other user facing usage. It can be plain-text or markdown depending on the
 	// global DescriptionKind setting. This field is valid for any Resource.
@@ -681,6 +777,59 @@ func (r *Resource) SchemaMap() map[string]*Schema {
 	return r.Schema
 }
 
+// AttributePaths returns the path of every attribute and block in the
+// Resource schema, including those nested within blocks, for tooling such as
+// checking every attribute is documented or tested. The paths are sorted
+// depth-first by name, with each block before the attributes within it. The
+// implicit id attribute and timeouts block are not included.
+//
+// The paths within blocks use an unknown index as a placeholder for any
+// element: an unknown number for TypeList blocks, and cty.DynamicVal for
+// TypeSet blocks. For example, the path of attribute "name" within list
+// block "rule" is:
+//
+//	cty.GetAttrPath("rule").Index(cty.UnknownVal(cty.Number)).GetAttr("name")
+func (r *Resource) AttributePaths() []cty.Path {
+	return schemaMapAttributePaths(r.SchemaMap(), nil)
+}
+
+func schemaMapAttributePaths(m map[string]*Schema, prefix cty.Path) []cty.Path {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var paths []cty.Path
+
+	for _, k := range keys {
+		path := prefix.GetAttr(k)
+		paths = append(paths, path)
+
+		v := m[k]
+
+		elem, ok := v.Elem.(*Resource)
+		if !ok {
+			continue
+		}
+
+		switch v.Type {
+		case TypeList:
+			path = path.Index(cty.UnknownVal(cty.Number))
+		case TypeSet:
+			path = path.Index(cty.DynamicVal)
+		default:
+			continue
+		}
+
+		paths = append(paths, schemaMapAttributePaths(elem.SchemaMap(), path)...)
+	}
+
+	return paths
+}
+
 // ShimInstanceStateFromValue converts a cty.Value to a
 // terraform.InstanceState.
 func (r *Resource) ShimInstanceStateFromValue(state cty.Value) (*terraform.InstanceState, error) {
@@ -725,6 +874,9 @@ type CreateContextFunc func(context.Context, *ResourceData, interface{}) diag.Di
 // See Resource documentation.
 type ReadContextFunc func(context.Context, *ResourceData, interface{}) diag.Diagnostics
 
+// See Resource documentation.
+type BatchReadFunc func(context.Context, []*ResourceData, interface{}) diag.Diagnostics
+
 // See Resource documentation.
 type UpdateContextFunc func(context.Context, *ResourceData, interface{}) diag.Diagnostics
 
@@ -823,6 +975,43 @@ func (r *Resource) read(ctx context.Context, d *ResourceData, meta interface{})
 	return r.ReadContext(ctx, d, meta)
 }
 
+// waitForReadable calls read until it succeeds or the timeout elapses. See the
+// WaitForReadableAfterCreate field documentation for details.
+func (r *Resource) waitForReadable(ctx context.Context, d *ResourceData, meta interface{}, timeout time.Duration) diag.Diagnostics {
+	id := d.Id()
+
+	var diags diag.Diagnostics
+
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		logging.HelperSchemaTrace(ctx, "Calling downstream")
+		diags = r.read(ctx, d, meta)
+		logging.HelperSchemaTrace(ctx, "Called downstream")
+
+		if diags.HasError() {
+			return retry.RetryableError(errors.New("read returned an error"))
+		}
+
+		if d.Id() == "" {
+			d.SetId(id)
+
+			return retry.RetryableError(errors.New("read did not find the resource"))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Resource not readable after create",
+			Detail: fmt.Sprintf("The resource was created with ID %q, but could not be read before the create timeout: %s\n\n"+
+				"The resource will be marked as tainted, so that it is replaced on the next apply.", id, err),
+		})
+	}
+
+	return diags
+}
+
 func (r *Resource) update(ctx context.Context, d *ResourceData, meta interface{}) diag.Diagnostics {
 	if r.Update != nil {
 		if err := r.Update(d, meta); err != nil {
@@ -890,6 +1079,7 @@ func (r *Resource) Apply(
 		logging.HelperSchemaDebug(ctx, "No meta timeoutkey found in Apply()")
 	}
 	data.timeouts = &rt
+	data.timeoutFunc = r.TimeoutFunc
 
 	if s == nil {
 		// The Terraform API dictates that this should never happen, but
@@ -928,14 +1118,20 @@ func (r *Resource) Apply(
 
 		// data was reset, need to re-apply the parsed timeouts
 		data.timeouts = &rt
+		data.timeoutFunc = r.TimeoutFunc
 	}
 
 	if data.Id() == "" {
 		// We're creating, it is a new resource.
 		data.MarkNewResource()
+		start := time.Now()
 		logging.HelperSchemaTrace(ctx, "Calling downstream")
 		diags = append(diags, r.create(ctx, data, meta)...)
 		logging.HelperSchemaTrace(ctx, "Called downstream")
+
+		if r.WaitForReadableAfterCreate && !diags.HasError() && data.Id() != "" {
+			diags = append(diags, r.waitForReadable(ctx, data, meta, data.Timeout(TimeoutCreate)-time.Since(start))...)
+		}
 	} else {
 		if !r.updateFuncSet() {
 			return s, append(diags, diag.Diagnostic{
@@ -948,7 +1144,31 @@ func (r *Resource) Apply(
 		logging.HelperSchemaTrace(ctx, "Called downstream")
 	}
 
-	return r.recordCurrentSchemaVersion(data.State()), diags
+	return r.recordConfiguredMapKeys(r.recordCurrentSchemaVersion(data.State()), d.RawConfig), diags
+}
+
+// recordConfiguredMapKeys records the keys in the configuration of each
+// IgnoreServerAddedKeys map in the state private data, so that their later
+// removal from the configuration can be planned.
+func (r *Resource) recordConfiguredMapKeys(state *terraform.InstanceState, config cty.Value) *terraform.InstanceState {
+	if state == nil || config == cty.NilVal {
+		return state
+	}
+
+	recorded := make(map[string]interface{})
+	schemaMap(r.SchemaMap()).configuredMapKeys("", config, recorded)
+
+	if len(recorded) == 0 {
+		return state
+	}
+
+	if state.Meta == nil {
+		state.Meta = make(map[string]interface{})
+	}
+
+	state.Meta[configuredMapKeysMetaKey] = recorded
+
+	return state
 }
 
 // Diff returns a diff of this resource.
@@ -1025,6 +1245,79 @@ func (r *Resource) Validate(c *terraform.ResourceConfig) diag.Diagnostics {
 	return diags
 }
 
+// CollectValidationDiagnostics validates the configuration value, which must
+// conform to the CoreConfigSchema of the resource, reporting every failure.
+// Unlike Validate, which stops at the first failed constraint of each
+// attribute, all attribute validators and ConflictsWith, ExactlyOneOf,
+// AtLeastOneOf, AtMostOneOf, RequiredWith and Required constraints are
+// checked, including within nested blocks. Each diagnostic is reported once,
+// with the AttributePath of the attribute it applies to.
+//
+// This is intended for external tooling, such as editors, which want all
+// validation results at once. It does not configure or call the provider.
+func (r *Resource) CollectValidationDiagnostics(ctx context.Context, cfg cty.Value) diag.Diagnostics {
+	schemaBlock := r.CoreConfigSchema()
+
+	if errs := cfg.Type().TestConformance(schemaBlock.ImpliedType()); len(errs) > 0 {
+		var diags diag.Diagnostics
+
+		for _, err := range errs {
+			d := diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid configuration value",
+				Detail:   err.Error(),
+			}
+
+			var pathErr cty.PathError
+			if errors.As(err, &pathErr) {
+				d.AttributePath = pathErr.Path
+			}
+
+			diags = append(diags, d)
+		}
+
+		return diags
+	}
+
+	// Unknown configurations cannot be validated yet.
+	if !cfg.IsKnown() {
+		return nil
+	}
+
+	c := terraform.NewResourceConfigShimmed(cfg, schemaBlock)
+	sm := schemaMap(r.SchemaMap())
+
+	logging.HelperSchemaTrace(ctx, "Collecting validation diagnostics")
+
+	diags := r.Validate(c)
+	diags = append(diags, sm.collectValidationDiagnostics("", sm, c, cty.Path{})...)
+
+	var result diag.Diagnostics
+
+	for _, d := range diags {
+		if !containsDiagnostic(result, d) {
+			result = append(result, d)
+		}
+	}
+
+	return result
+}
+
+// containsDiagnostic returns whether the diagnostics contain an equivalent
+// diagnostic, with the same severity, summary, detail and attribute path.
+func containsDiagnostic(diags diag.Diagnostics, d diag.Diagnostic) bool {
+	for _, existing := range diags {
+		if existing.Severity == d.Severity &&
+			existing.Summary == d.Summary &&
+			existing.Detail == d.Detail &&
+			existing.AttributePath.Equals(d.AttributePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ReadDataApply loads the data for a data source, given a diff that
 // describes the configuration arguments and desired computed attributes.
 func (r *Resource) ReadDataApply(
@@ -1038,6 +1331,7 @@ func (r *Resource) ReadDataApply(
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	data.timeoutFunc = r.TimeoutFunc
 
 	logging.HelperSchemaTrace(ctx, "Calling downstream")
 	diags := r.read(ctx, data, meta)
@@ -1063,6 +1357,16 @@ func (r *Resource) RefreshWithoutUpgrade(
 	ctx context.Context,
 	s *terraform.InstanceState,
 	meta interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	return r.refresh(ctx, s, meta, nil)
+}
+
+// refresh implements RefreshWithoutUpgrade. If batcher is not nil and the
+// Resource implements BatchReadFunc, the read is performed as part of a batch.
+func (r *Resource) refresh(
+	ctx context.Context,
+	s *terraform.InstanceState,
+	meta interface{},
+	batcher *readBatcher) (*terraform.InstanceState, diag.Diagnostics) {
 	// If the ID is already somehow blank, it doesn't exist
 	if s.ID == "" {
 		return nil, nil
@@ -1085,6 +1389,7 @@ func (r *Resource) RefreshWithoutUpgrade(
 			return s, diag.FromErr(err)
 		}
 		data.timeouts = &rt
+		data.timeoutFunc = r.TimeoutFunc
 
 		if s != nil {
 			data.providerMeta = s.ProviderMeta
@@ -1108,13 +1413,27 @@ func (r *Resource) RefreshWithoutUpgrade(
 		return s, diag.FromErr(err)
 	}
 	data.timeouts = &rt
+	data.timeoutFunc = r.TimeoutFunc
 
 	if s != nil {
 		data.providerMeta = s.ProviderMeta
 	}
 
+	var diags diag.Diagnostics
+
 	logging.HelperSchemaTrace(ctx, "Calling downstream")
-	diags := r.read(ctx, data, meta)
+	if batcher != nil && r.BatchReadFunc != nil {
+		var read bool
+
+		diags, read = batcher.read(ctx, r, data, meta)
+
+		// The batch may still be reading the data, so it cannot be used.
+		if !read {
+			return s, diags
+		}
+	} else {
+		diags = r.read(ctx, data, meta)
+	}
 	logging.HelperSchemaTrace(ctx, "Called downstream")
 
 	state := data.State()
@@ -1162,6 +1481,14 @@ func (r *Resource) InternalValidate(topSchemaMap schemaMap, writable bool) error
 			return fmt.Errorf("must not implement Create, Update or Delete")
 		}
 
+		if r.BatchReadFunc != nil {
+			return fmt.Errorf("must not implement BatchReadFunc")
+		}
+
+		if len(r.ImportRedact) > 0 {
+			return fmt.Errorf("must not implement ImportRedact")
+		}
+
 		// CustomizeDiff cannot be defined for read-only resources
 		if r.CustomizeDiff != nil {
 			return fmt.Errorf("cannot implement CustomizeDiff")
@@ -1215,6 +1542,20 @@ func (r *Resource) InternalValidate(topSchemaMap schemaMap, writable bool) error
 			}
 		}
 
+		if len(r.ImportRedact) > 0 && r.Importer == nil {
+			return fmt.Errorf("ImportRedact can only be set with Importer")
+		}
+
+		for _, k := range r.ImportRedact {
+			if k == "id" {
+				return fmt.Errorf("ImportRedact cannot contain id")
+			}
+
+			if _, ok := schema[k]; !ok {
+				return fmt.Errorf("ImportRedact references unknown attribute (%s)", k)
+			}
+		}
+
 		if f, ok := tsm["id"]; ok {
 			// if there is an explicit ID, validate it...
 			err := validateResourceID(f)
@@ -1371,6 +1712,7 @@ func (r *Resource) Data(s *terraform.InstanceState) *ResourceData {
 	if result.timeouts == nil {
 		result.timeouts = &ResourceTimeout{}
 	}
+	result.timeoutFunc = r.TimeoutFunc
 
 	// Set the schema version to latest by default
 	result.meta = map[string]interface{}{
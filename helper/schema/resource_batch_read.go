@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/logging"
+)
+
+// defaultReadBatchWindow is how long the first read of a batch waits for
+// other reads of the same resource type to join before calling BatchReadFunc.
+const defaultReadBatchWindow = 50 * time.Millisecond
+
+// readBatcher collects concurrent reads of a single managed resource type so
+// they can be passed to the Resource BatchReadFunc together.
+type readBatcher struct {
+	window time.Duration
+
+	mu       sync.Mutex
+	pending  *readBatch
+	inFlight int
+}
+
+// readBatch is a set of reads which will be performed by a single call to
+// BatchReadFunc.
+type readBatch struct {
+	data    []*ResourceData
+	waiting int
+	diags   diag.Diagnostics
+	done    chan struct{}
+
+	// ctx and cancel are set when the batch is started.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// read adds the given ResourceData to the pending batch, or starts a new
+// batch if there is none, and blocks until the batch has been read or the
+// context is done. It returns false if the ResourceData was not read, in
+// which case it must not be used.
+//
+// If no other batch of the resource type is being read, the read is
+// performed immediately on its own. Otherwise the first read of a batch
+// waits for the batch window to elapse so that other reads can join. The
+// batch is read with the meta and read timeout of its first read and is only
+// cancelled once every read in it has been cancelled.
+func (b *readBatcher) read(ctx context.Context, r *Resource, d *ResourceData, meta interface{}) (diag.Diagnostics, bool) {
+	b.mu.Lock()
+
+	batch := b.pending
+	leader := batch == nil
+
+	if leader {
+		batch = &readBatch{
+			done: make(chan struct{}),
+		}
+	}
+
+	batch.data = append(batch.data, d)
+	batch.waiting++
+
+	if leader {
+		if b.inFlight == 0 {
+			b.start(ctx, batch)
+			go b.flush(r, batch, meta)
+		} else {
+			b.pending = batch
+			go b.flushAfterWindow(ctx, r, batch, meta)
+		}
+	}
+
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return batch.diags, true
+	case <-ctx.Done():
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch.waiting--
+
+	if batch.cancel == nil {
+		// The batch has not started, so the read can be withdrawn.
+		for i, data := range batch.data {
+			if data == d {
+				batch.data = append(batch.data[:i], batch.data[i+1:]...)
+				break
+			}
+		}
+	} else if batch.waiting == 0 {
+		batch.cancel()
+	}
+
+	return diag.FromErr(ctx.Err()), false
+}
+
+// start marks the batch as in flight and derives its context from the given
+// context, without its cancellation, and the read timeout of the first read
+// in the batch. The caller must hold the lock.
+func (b *readBatcher) start(ctx context.Context, batch *readBatch) {
+	if b.pending == batch {
+		b.pending = nil
+	}
+
+	b.inFlight++
+
+	batch.ctx, batch.cancel = context.WithTimeout(context.WithoutCancel(ctx), batch.data[0].Timeout(TimeoutRead))
+}
+
+// flushAfterWindow starts the batch once the batch window has elapsed, unless
+// every read has been withdrawn from it.
+func (b *readBatcher) flushAfterWindow(ctx context.Context, r *Resource, batch *readBatch, meta interface{}) {
+	time.Sleep(b.window)
+
+	b.mu.Lock()
+
+	if len(batch.data) == 0 {
+		if b.pending == batch {
+			b.pending = nil
+		}
+
+		b.mu.Unlock()
+		return
+	}
+
+	b.start(ctx, batch)
+	b.mu.Unlock()
+
+	b.flush(r, batch, meta)
+}
+
+// flush calls BatchReadFunc for a started batch.
+func (b *readBatcher) flush(r *Resource, batch *readBatch, meta interface{}) {
+	defer close(batch.done)
+	defer batch.cancel()
+
+	logging.HelperSchemaDebug(batch.ctx, "Calling BatchReadFunc", map[string]interface{}{logging.KeyBatchSize: len(batch.data)})
+
+	batch.diags = r.BatchReadFunc(batch.ctx, batch.data, meta)
+
+	b.mu.Lock()
+	b.inFlight--
+	b.mu.Unlock()
+}
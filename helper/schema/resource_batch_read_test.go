@@ -0,0 +1,372 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceRefresh_batchRead(t *testing.T) {
+	var mu sync.Mutex
+	var batchSizes []int
+	var reads int
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": {
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+		ReadContext: func(_ context.Context, d *ResourceData, _ interface{}) diag.Diagnostics {
+			mu.Lock()
+			reads++
+			mu.Unlock()
+
+			return nil
+		},
+		BatchReadFunc: func(_ context.Context, ds []*ResourceData, m interface{}) diag.Diagnostics {
+			if m != 42 {
+				return diag.Errorf("meta not passed")
+			}
+
+			mu.Lock()
+			batchSizes = append(batchSizes, len(ds))
+			mu.Unlock()
+
+			// The first read is performed on its own and holds the batch
+			// in flight until the others are pending.
+			if ds[0].Id() == "first" {
+				close(started)
+				<-release
+			}
+
+			for _, d := range ds {
+				if d.Id() == "gone" {
+					d.SetId("")
+					continue
+				}
+
+				if err := d.Set("foo", d.Get("foo").(int)+1); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	batcher := &readBatcher{
+		window: 500 * time.Millisecond,
+	}
+
+	ids := []string{"first", "a", "b", "gone"}
+	results := make([]*terraform.InstanceState, len(ids))
+
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		wg.Add(1)
+
+		if i == 1 {
+			<-started
+		}
+
+		go func(i int, id string) {
+			defer wg.Done()
+
+			s := &terraform.InstanceState{
+				ID: id,
+				Attributes: map[string]string{
+					"foo": "12",
+				},
+			}
+
+			state, diags := r.refresh(context.Background(), s, 42, batcher)
+			if diags.HasError() {
+				t.Errorf("unexpected error for %s: %v", id, diags)
+			}
+
+			results[i] = state
+		}(i, id)
+	}
+
+	waitForPendingReads(t, batcher, len(ids)-1)
+	close(release)
+	wg.Wait()
+
+	if len(batchSizes) != 2 || batchSizes[0] != 1 || batchSizes[1] != len(ids)-1 {
+		t.Fatalf("expected a batch of 1 then a batch of %d, got: %v", len(ids)-1, batchSizes)
+	}
+
+	if reads != 0 {
+		t.Fatalf("expected no per-instance reads, got %d", reads)
+	}
+
+	for i, id := range ids {
+		if id == "gone" {
+			if results[i] != nil {
+				t.Errorf("expected %s to be removed, got: %#v", id, results[i])
+			}
+
+			continue
+		}
+
+		if got := results[i].Attributes["foo"]; got != "13" {
+			t.Errorf("expected %s foo to be 13, got: %s", id, got)
+		}
+	}
+
+	// Outside of a batcher, such as in RefreshWithoutUpgrade, the
+	// per-instance read is used.
+	_, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: "a"}, 42)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if reads != 1 || len(batchSizes) != 2 {
+		t.Fatalf("expected one per-instance read and no further batches, got %d and %v", reads, batchSizes)
+	}
+}
+
+func TestResourceRefresh_batchReadSingle(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": {
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+		ReadContext: func(context.Context, *ResourceData, interface{}) diag.Diagnostics {
+			return diag.Errorf("unexpected per-instance read")
+		},
+		BatchReadFunc: func(_ context.Context, ds []*ResourceData, _ interface{}) diag.Diagnostics {
+			if len(ds) != 1 {
+				return diag.Errorf("expected a batch of 1, got: %d", len(ds))
+			}
+
+			return nil
+		},
+	}
+
+	// A read while no batch is in flight does not wait for the window.
+	batcher := &readBatcher{
+		window: time.Hour,
+	}
+
+	done := make(chan diag.Diagnostics)
+
+	go func() {
+		_, diags := r.refresh(context.Background(), &terraform.InstanceState{ID: "a"}, nil, batcher)
+		done <- diags
+	}()
+
+	select {
+	case diags := <-done:
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected read to be performed without waiting for the batch window")
+	}
+}
+
+func TestResourceRefresh_batchReadCancel(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	var batchErr error
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	batchStarted := make(chan struct{})
+	batchRelease := make(chan struct{})
+
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": {
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+		ReadContext: func(context.Context, *ResourceData, interface{}) diag.Diagnostics {
+			return diag.Errorf("unexpected per-instance read")
+		},
+		BatchReadFunc: func(ctx context.Context, ds []*ResourceData, _ interface{}) diag.Diagnostics {
+			var ids []string
+			for _, d := range ds {
+				ids = append(ids, d.Id())
+			}
+
+			mu.Lock()
+			batches = append(batches, ids)
+			mu.Unlock()
+
+			if ids[0] == "first" {
+				close(started)
+				<-release
+				return nil
+			}
+
+			close(batchStarted)
+			<-batchRelease
+
+			mu.Lock()
+			batchErr = ctx.Err()
+			mu.Unlock()
+
+			for _, d := range ds {
+				if err := d.Set("foo", 13); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	batcher := &readBatcher{
+		window: 500 * time.Millisecond,
+	}
+
+	type result struct {
+		state *terraform.InstanceState
+		diags diag.Diagnostics
+	}
+
+	refresh := func(ctx context.Context, id string) <-chan result {
+		ch := make(chan result, 1)
+
+		go func() {
+			s := &terraform.InstanceState{
+				ID: id,
+				Attributes: map[string]string{
+					"foo": "12",
+				},
+			}
+
+			state, diags := r.refresh(ctx, s, nil, batcher)
+			ch <- result{state, diags}
+		}()
+
+		return ch
+	}
+
+	// Hold a batch in flight so that the following reads are pending.
+	first := refresh(context.Background(), "first")
+	<-started
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	defer cancelA()
+	a := refresh(ctxA, "a")
+	waitForPendingReads(t, batcher, 1)
+
+	b := refresh(context.Background(), "b")
+	waitForPendingReads(t, batcher, 2)
+
+	ctxC, cancelC := context.WithCancel(context.Background())
+	c := refresh(ctxC, "c")
+	waitForPendingReads(t, batcher, 3)
+
+	// A pending read is withdrawn from the batch when cancelled.
+	cancelC()
+
+	if res := <-c; !res.diags.HasError() || res.state.Attributes["foo"] != "12" {
+		t.Errorf("expected cancelled read of c to return an error and the prior state, got: %v, %#v", res.diags, res.state)
+	}
+
+	waitForPendingReads(t, batcher, 2)
+	close(release)
+
+	if res := <-first; res.diags.HasError() {
+		t.Fatalf("unexpected error for first: %v", res.diags)
+	}
+
+	// Cancelling the first read of a started batch does not cancel the
+	// batch for the other reads in it.
+	<-batchStarted
+	cancelA()
+
+	if res := <-a; !res.diags.HasError() {
+		t.Errorf("expected cancelled read of a to return an error")
+	}
+
+	close(batchRelease)
+
+	res := <-b
+	if res.diags.HasError() {
+		t.Fatalf("unexpected error for b: %v", res.diags)
+	}
+
+	if got := res.state.Attributes["foo"]; got != "13" {
+		t.Errorf("expected b foo to be 13, got: %s", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if batchErr != nil {
+		t.Errorf("expected batch context not to be cancelled, got: %s", batchErr)
+	}
+
+	if len(batches) != 2 || len(batches[1]) != 2 || batches[1][0] != "a" || batches[1][1] != "b" {
+		t.Errorf("expected batches [first] and [a b], got: %v", batches)
+	}
+}
+
+// waitForPendingReads waits until the pending batch of the readBatcher holds
+// the given number of reads.
+func waitForPendingReads(t *testing.T, b *readBatcher, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+
+	for {
+		b.mu.Lock()
+		var pending int
+		if b.pending != nil {
+			pending = len(b.pending.data)
+		}
+		b.mu.Unlock()
+
+		if pending == n {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d pending reads, got: %d", n, pending)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestResourceInternalValidate_batchReadDataSource(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": {
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+		ReadContext: func(context.Context, *ResourceData, interface{}) diag.Diagnostics {
+			return nil
+		},
+		BatchReadFunc: func(context.Context, []*ResourceData, interface{}) diag.Diagnostics {
+			return nil
+		},
+	}
+
+	if err := r.InternalValidate(nil, false); err == nil {
+		t.Fatal("expected error for data source with BatchReadFunc")
+	}
+}
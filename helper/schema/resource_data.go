@@ -4,8 +4,11 @@
 package schema
 
 import (
+	"fmt"
 	"log"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +16,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-cty/cty/gocty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -31,6 +35,7 @@ type ResourceData struct {
 	diff         *terraform.InstanceDiff
 	meta         map[string]interface{}
 	timeouts     *ResourceTimeout
+	timeoutFunc  func(string, *ResourceData) time.Duration
 	providerMeta cty.Value
 
 	// Don't set
@@ -113,6 +118,32 @@ func (d *ResourceData) GetOkExists(key string) (interface{}, bool) {
 	return r.Value, exists
 }
 
+// GetOkRaw returns the data for the given key and whether or not the key is
+// set in the configuration, as reported by GetRawConfig. Unlike GetOk, zero
+// values such as a TypeBool set to false are reported as set, so it can
+// distinguish an Optional attribute set to its zero value from one which is
+// omitted. Values which are unknown in the configuration are also reported
+// as set.
+//
+// Values from Default, DefaultFunc, or set by the provider are not part of
+// the configuration, so they are reported as not set, although they are
+// still returned as the first result. Terraform does not send the
+// configuration when refreshing, so during a Read called by Terraform, rather
+// than after Create or Update, the second result is always false. Keys within
+// TypeSet attributes cannot be looked up in the configuration and are also
+// reported as not set.
+func (d *ResourceData) GetOkRaw(key string) (interface{}, bool) {
+	value := d.Get(key)
+
+	v, err := flatmapKeyPath(key).Apply(d.GetRawConfig())
+
+	if err != nil {
+		return value, false
+	}
+
+	return value, !v.IsNull()
+}
+
 func (d *ResourceData) getRaw(key string, level getSource) getResult {
 	var parts []string
 	if key != "" {
@@ -346,7 +377,12 @@ func (d *ResourceData) State() *terraform.InstanceState {
 	// attribute set as a map[string]interface{}, write it to a MapFieldWriter,
 	// and then use that map.
 	rawMap := make(map[string]interface{})
-	for k := range d.schema {
+	for k, schema := range d.schema {
+		// Write-only values are never persisted.
+		if schema.WriteOnly {
+			continue
+		}
+
 		source := getSourceSet
 		if d.partial {
 			source = getSourceState
@@ -410,35 +446,94 @@ func (d *ResourceData) State() *terraform.InstanceState {
 }
 
 // Timeout returns the data for the given timeout key
-// Returns a duration of 20 minutes for any key not found, or not found and no default.
+// Returns the Resource TimeoutFunc duration if it is non-zero and the
+// timeout was not configured by the practitioner, otherwise a duration of 20
+// minutes for any key not found, or not found and no default.
 func (d *ResourceData) Timeout(key string) time.Duration {
 	key = strings.ToLower(key)
 
+	timeout := resourceTimeout(d.timeouts, key)
+
+	// A timeout configured by the practitioner always takes precedence.
+	if d.timeoutFunc == nil || d.timeoutConfigured(key) {
+		return timeout
+	}
+
+	if funcTimeout := d.timeoutFunc(key, d); funcTimeout > 0 {
+		return funcTimeout
+	}
+
+	return timeout
+}
+
+// timeoutConfigured returns whether the timeout for the given key, or the
+// default timeout, is set in the timeouts configuration block. The block is
+// read from the raw configuration, or the raw plan or state when there is no
+// configuration, such as during delete, as the SDK copies it into both.
+func (d *ResourceData) timeoutConfigured(key string) bool {
+	if _, ok := d.schema[TimeoutsConfigKey]; ok {
+		return false
+	}
+
+	for _, raw := range []cty.Value{d.GetRawConfig(), d.GetRawPlan(), d.GetRawState()} {
+		if raw.IsNull() || !raw.IsKnown() {
+			continue
+		}
+
+		if !raw.Type().IsObjectType() || !raw.Type().HasAttribute(TimeoutsConfigKey) {
+			return false
+		}
+
+		timeouts := raw.GetAttr(TimeoutsConfigKey)
+
+		if timeouts.IsNull() || !timeouts.IsKnown() || !timeouts.Type().IsObjectType() {
+			return false
+		}
+
+		for _, k := range []string{key, TimeoutDefault} {
+			if !timeouts.Type().HasAttribute(k) {
+				continue
+			}
+
+			if v := timeouts.GetAttr(k); v.IsKnown() && !v.IsNull() {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return false
+}
+
+// resourceTimeout returns the duration for the given timeout key, falling
+// back to the Default duration, then the system default of 20 minutes.
+func resourceTimeout(t *ResourceTimeout, key string) time.Duration {
 	// System default of 20 minutes
 	defaultTimeout := 20 * time.Minute
 
-	if d.timeouts == nil {
+	if t == nil {
 		return defaultTimeout
 	}
 
 	var timeout *time.Duration
 	switch key {
 	case TimeoutCreate:
-		timeout = d.timeouts.Create
+		timeout = t.Create
 	case TimeoutRead:
-		timeout = d.timeouts.Read
+		timeout = t.Read
 	case TimeoutUpdate:
-		timeout = d.timeouts.Update
+		timeout = t.Update
 	case TimeoutDelete:
-		timeout = d.timeouts.Delete
+		timeout = t.Delete
 	}
 
 	if timeout != nil {
 		return *timeout
 	}
 
-	if d.timeouts.Default != nil {
-		return *d.timeouts.Default
+	if t.Default != nil {
+		return *t.Default
 	}
 
 	return defaultTimeout
@@ -637,3 +732,87 @@ func (d *ResourceData) GetRawPlan() cty.Value {
 	}
 	return cty.NullVal(schemaMap(d.schema).CoreConfigSchema().ImpliedType())
 }
+
+// GetTypedMap returns the TypeMap attribute at the given key with each value
+// converted by the parse function, such as strconv.Atoi or
+// strconv.ParseBool. TypeMap values are returned by Get as strings unless the
+// Elem is a typed schema, so this avoids parsing each value by hand when a
+// map of strings holds numbers or booleans. Values which are not strings are
+// formatted with fmt before being parsed.
+//
+// An error diagnostic, with the attribute path of the map key, is returned
+// for each value which cannot be parsed, along with the values which could.
+//
+//	ports, diags := schema.GetTypedMap(d, "ports", strconv.Atoi)
+//	if diags.HasError() {
+//	  return diags
+//	}
+func GetTypedMap[T any](d *ResourceData, key string, parse func(string) (T, error)) (map[string]T, diag.Diagnostics) {
+	raw, ok := d.Get(key).(map[string]interface{})
+
+	if !ok {
+		return nil, diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid map attribute",
+				Detail:        fmt.Sprintf("Expected %s to be a map, got: %T", key, d.Get(key)),
+				AttributePath: flatmapKeyPath(key),
+			},
+		}
+	}
+
+	keys := make([]string, 0, len(raw))
+
+	for k := range raw {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	result := make(map[string]T, len(raw))
+
+	var diags diag.Diagnostics
+
+	for _, k := range keys {
+		s, ok := raw[k].(string)
+
+		if !ok {
+			s = fmt.Sprintf("%v", raw[k])
+		}
+
+		v, err := parse(s)
+
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid map value",
+				Detail:        fmt.Sprintf("The value of %s key %q cannot be parsed: %s", key, k, err),
+				AttributePath: flatmapKeyPath(key).IndexString(k),
+			})
+
+			continue
+		}
+
+		result[k] = v
+	}
+
+	return result, diags
+}
+
+// flatmapKeyPath returns the attribute path of the given ResourceData key,
+// such as "block.0.attr". Numeric parts are treated as list indexes.
+func flatmapKeyPath(key string) cty.Path {
+	var path cty.Path
+
+	for _, part := range strings.Split(key, ".") {
+		if i, err := strconv.Atoi(part); err == nil {
+			path = path.IndexInt(i)
+
+			continue
+		}
+
+		path = path.GetAttr(part)
+	}
+
+	return path
+}
@@ -0,0 +1,383 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// marshalTag is the struct field tag naming the attribute a field maps to.
+const marshalTag = "tf"
+
+// Unmarshal populates the struct pointed to by v from the resource data,
+// using the same values as Get. Each exported struct field with a `tf` tag,
+// such as `tf:"name"`, is populated from the attribute of that name. Fields
+// without a tag, or tagged `tf:"-"`, are ignored.
+//
+// Primitive attributes map to fields of a compatible Go kind, for example
+// TypeInt to any integer kind. TypeList and TypeSet attributes map to slices
+// and TypeMap attributes map to maps with string keys. Configuration blocks
+// map to slices of tagged structs, and blocks with MaxItems of 1 can also map
+// to a tagged struct or pointer to a struct, whose tags must name attributes
+// of the block. An error is returned if a field cannot hold the attribute
+// value, including a TypeFloat value in an integer field or an integer which
+// overflows its field.
+//
+//	type thing struct {
+//	  Name    string            `tf:"name"`
+//	  Size    int64             `tf:"size"`
+//	  Tags    map[string]string `tf:"tags"`
+//	  Network *thingNetwork     `tf:"network"`
+//	}
+//
+//	var t thing
+//	if err := d.Unmarshal(&t); err != nil {
+//	  return diag.FromErr(err)
+//	}
+func (d *ResourceData) Unmarshal(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Unmarshal: expected non-nil pointer to struct, got %T", v)
+	}
+
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		key, ok := marshalFieldKey(rt.Field(i))
+		if !ok {
+			continue
+		}
+
+		if _, ok := d.schema[key]; !ok {
+			return fmt.Errorf("Unmarshal: field %s references unknown attribute %q", rt.Field(i).Name, key)
+		}
+
+		if err := unmarshalValue(key, d.Get(key), d.schema[key], rv.Field(i)); err != nil {
+			return fmt.Errorf("Unmarshal: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Marshal sets the resource data from the struct, or pointer to struct, v,
+// using the same values as Set. It is the reverse of Unmarshal and uses the
+// same `tf` struct field tags. Nested structs are set as a single
+// configuration block and nil pointers are set as null, except in slices of
+// blocks where they are omitted.
+//
+//	if err := d.Marshal(t); err != nil {
+//	  return diag.FromErr(err)
+//	}
+func (d *ResourceData) Marshal(v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("Marshal: expected struct or pointer to struct, got %T", v)
+	}
+
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		key, ok := marshalFieldKey(rt.Field(i))
+		if !ok {
+			continue
+		}
+
+		if _, ok := d.schema[key]; !ok {
+			return fmt.Errorf("Marshal: field %s references unknown attribute %q", rt.Field(i).Name, key)
+		}
+
+		if err := d.Set(key, marshalValue(rv.Field(i))); err != nil {
+			return fmt.Errorf("Marshal: %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// marshalFieldKey returns the attribute name of a struct field and whether
+// the field should be marshalled.
+func marshalFieldKey(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" {
+		return "", false
+	}
+
+	key, _, _ := strings.Cut(f.Tag.Get(marshalTag), ",")
+	if key == "" || key == "-" {
+		return "", false
+	}
+
+	return key, true
+}
+
+// unmarshalValue assigns the value, as returned by ResourceData.Get, of the
+// attribute with schema s to dst.
+func unmarshalValue(k string, src interface{}, s *Schema, dst reflect.Value) error {
+	if src == nil {
+		return nil
+	}
+
+	if set, ok := src.(*Set); ok {
+		src = set.List()
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if l, ok := src.([]interface{}); ok && len(l) == 0 && dst.Type().Elem().Kind() == reflect.Struct {
+			return nil
+		}
+
+		v := reflect.New(dst.Type().Elem())
+		if err := unmarshalValue(k, src, s, v.Elem()); err != nil {
+			return err
+		}
+
+		dst.Set(v)
+
+		return nil
+	case reflect.Struct:
+		if l, ok := src.([]interface{}); ok {
+			switch len(l) {
+			case 0:
+				return nil
+			case 1:
+				src = l[0]
+			default:
+				return fmt.Errorf("%s: cannot assign %d blocks to field of type %s, use a slice instead", k, len(l), dst.Type())
+			}
+		}
+
+		if src == nil {
+			return nil
+		}
+
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: cannot assign %T to field of type %s", k, src, dst.Type())
+		}
+
+		var r *Resource
+		if s != nil {
+			r, _ = s.Elem.(*Resource)
+		}
+
+		if r == nil {
+			return fmt.Errorf("%s: cannot assign %T to field of type %s, attribute is not a block", k, src, dst.Type())
+		}
+
+		nested := r.SchemaMap()
+		dt := dst.Type()
+
+		for i := 0; i < dt.NumField(); i++ {
+			key, ok := marshalFieldKey(dt.Field(i))
+			if !ok {
+				continue
+			}
+
+			if _, ok := nested[key]; !ok {
+				return fmt.Errorf("%s: field %s references unknown attribute %q", k, dt.Field(i).Name, key)
+			}
+
+			if err := unmarshalValue(k+"."+key, m[key], nested[key], dst.Field(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Slice:
+		l, ok := src.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: cannot assign %T to field of type %s", k, src, dst.Type())
+		}
+
+		v := reflect.MakeSlice(dst.Type(), len(l), len(l))
+		for i, e := range l {
+			if err := unmarshalValue(fmt.Sprintf("%s.%d", k, i), e, marshalElemSchema(s), v.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		dst.Set(v)
+
+		return nil
+	case reflect.Map:
+		m, ok := src.(map[string]interface{})
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("%s: cannot assign %T to field of type %s", k, src, dst.Type())
+		}
+
+		v := reflect.MakeMapWithSize(dst.Type(), len(m))
+		for mk, mv := range m {
+			e := reflect.New(dst.Type().Elem()).Elem()
+			if err := unmarshalValue(k+"."+mk, mv, marshalElemSchema(s), e); err != nil {
+				return err
+			}
+
+			v.SetMapIndex(reflect.ValueOf(mk).Convert(dst.Type().Key()), e)
+		}
+
+		dst.Set(v)
+
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+
+	switch {
+	case sv.Type().AssignableTo(dst.Type()):
+		dst.Set(sv)
+	case isMarshalNumber(sv.Kind()) && isMarshalNumber(dst.Kind()):
+		if err := checkMarshalNumber(sv, dst.Type()); err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+
+		dst.Set(sv.Convert(dst.Type()))
+	case sv.Kind() == dst.Kind():
+		dst.Set(sv.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("%s: cannot assign %T to field of type %s", k, src, dst.Type())
+	}
+
+	return nil
+}
+
+// marshalElemSchema returns the schema of the elements of an attribute with
+// schema s. The elements of a block are the blocks themselves, so s is
+// returned for them.
+func marshalElemSchema(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+
+	switch elem := s.Elem.(type) {
+	case *Schema:
+		return elem
+	case *Resource:
+		return s
+	}
+
+	return nil
+}
+
+// checkMarshalNumber returns an error if the number v cannot be converted to
+// the numeric type t without losing its value, such as a float to an integer
+// or an integer which overflows t.
+func checkMarshalNumber(v reflect.Value, t reflect.Type) error {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return fmt.Errorf("cannot assign %s to field of type %s without truncation", v.Type(), t)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v.Uint() > 1<<(t.Bits()-1)-1 {
+				return fmt.Errorf("value %d overflows field of type %s", v.Uint(), t)
+			}
+		default:
+			if reflect.Zero(t).OverflowInt(v.Int()) {
+				return fmt.Errorf("value %d overflows field of type %s", v.Int(), t)
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return fmt.Errorf("cannot assign %s to field of type %s without truncation", v.Type(), t)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if reflect.Zero(t).OverflowUint(v.Uint()) {
+				return fmt.Errorf("value %d overflows field of type %s", v.Uint(), t)
+			}
+		default:
+			if v.Int() < 0 || reflect.Zero(t).OverflowUint(uint64(v.Int())) {
+				return fmt.Errorf("value %d overflows field of type %s", v.Int(), t)
+			}
+		}
+	}
+
+	return nil
+}
+
+// marshalValue returns the value of v in the form accepted by
+// ResourceData.Set.
+func marshalValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return marshalValue(v.Elem())
+	case reflect.Struct:
+		return []interface{}{marshalStruct(v)}
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+
+		l := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			e := v.Index(i)
+
+			// Blocks are never null, so nil blocks are omitted.
+			if e.Kind() == reflect.Ptr && e.Type().Elem().Kind() == reflect.Struct {
+				if e.IsNil() {
+					continue
+				}
+
+				e = e.Elem()
+			}
+
+			if e.Kind() == reflect.Struct {
+				l = append(l, marshalStruct(e))
+				continue
+			}
+
+			l = append(l, marshalValue(e))
+		}
+
+		return l
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = marshalValue(iter.Value())
+		}
+
+		return m
+	}
+
+	return v.Interface()
+}
+
+func marshalStruct(v reflect.Value) map[string]interface{} {
+	m := make(map[string]interface{})
+	vt := v.Type()
+
+	for i := 0; i < vt.NumField(); i++ {
+		key, ok := marshalFieldKey(vt.Field(i))
+		if !ok {
+			continue
+		}
+
+		m[key] = marshalValue(v.Field(i))
+	}
+
+	return m
+}
+
+func isMarshalNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
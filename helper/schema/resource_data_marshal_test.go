@@ -0,0 +1,365 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testMarshalNetwork struct {
+	Subnet string `tf:"subnet"`
+	Public bool   `tf:"public"`
+}
+
+type testMarshalRule struct {
+	Port     int    `tf:"port"`
+	Protocol string `tf:"protocol"`
+}
+
+type testMarshalThing struct {
+	Name     string              `tf:"name"`
+	Size     int64               `tf:"size"`
+	Ratio    float32             `tf:"ratio"`
+	Enabled  bool                `tf:"enabled"`
+	Zones    []string            `tf:"zones"`
+	Aliases  []string            `tf:"aliases"`
+	Tags     map[string]string   `tf:"tags"`
+	Network  *testMarshalNetwork `tf:"network"`
+	Rules    []testMarshalRule   `tf:"rule"`
+	Ignored  string              `tf:"-"`
+	Untagged string
+}
+
+func testMarshalSchema() map[string]*Schema {
+	return map[string]*Schema{
+		"name": {
+			Type:     TypeString,
+			Optional: true,
+		},
+		"size": {
+			Type:     TypeInt,
+			Optional: true,
+		},
+		"ratio": {
+			Type:     TypeFloat,
+			Optional: true,
+		},
+		"enabled": {
+			Type:     TypeBool,
+			Optional: true,
+		},
+		"zones": {
+			Type:     TypeList,
+			Optional: true,
+			Elem:     &Schema{Type: TypeString},
+		},
+		"aliases": {
+			Type:     TypeSet,
+			Optional: true,
+			Elem:     &Schema{Type: TypeString},
+		},
+		"tags": {
+			Type:     TypeMap,
+			Optional: true,
+			Elem:     &Schema{Type: TypeString},
+		},
+		"network": {
+			Type:     TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &Resource{
+				Schema: map[string]*Schema{
+					"subnet": {
+						Type:     TypeString,
+						Optional: true,
+					},
+					"public": {
+						Type:     TypeBool,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"rule": {
+			Type:     TypeList,
+			Optional: true,
+			Elem: &Resource{
+				Schema: map[string]*Schema{
+					"port": {
+						Type:     TypeInt,
+						Optional: true,
+					},
+					"protocol": {
+						Type:     TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestResourceDataUnmarshal(t *testing.T) {
+	d := TestResourceDataRaw(t, testMarshalSchema(), map[string]interface{}{
+		"name":    "test",
+		"size":    3,
+		"ratio":   0.5,
+		"enabled": true,
+		"zones":   []interface{}{"a", "b"},
+		"aliases": []interface{}{"x"},
+		"tags":    map[string]interface{}{"env": "dev"},
+		"network": []interface{}{
+			map[string]interface{}{
+				"subnet": "10.0.0.0/24",
+				"public": true,
+			},
+		},
+		"rule": []interface{}{
+			map[string]interface{}{"port": 80, "protocol": "tcp"},
+			map[string]interface{}{"port": 53, "protocol": "udp"},
+		},
+	})
+
+	var actual testMarshalThing
+	if err := d.Unmarshal(&actual); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := testMarshalThing{
+		Name:    "test",
+		Size:    3,
+		Ratio:   0.5,
+		Enabled: true,
+		Zones:   []string{"a", "b"},
+		Aliases: []string{"x"},
+		Tags:    map[string]string{"env": "dev"},
+		Network: &testMarshalNetwork{
+			Subnet: "10.0.0.0/24",
+			Public: true,
+		},
+		Rules: []testMarshalRule{
+			{Port: 80, Protocol: "tcp"},
+			{Port: 53, Protocol: "udp"},
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected:\n%#v\n\ngot:\n%#v", expected, actual)
+	}
+}
+
+func TestResourceDataUnmarshal_emptyBlock(t *testing.T) {
+	d := TestResourceDataRaw(t, testMarshalSchema(), map[string]interface{}{
+		"name": "test",
+	})
+
+	type thing struct {
+		Name    string              `tf:"name"`
+		Network *testMarshalNetwork `tf:"network"`
+		Value   testMarshalNetwork  `tf:"network"`
+	}
+
+	var actual thing
+	if err := d.Unmarshal(&actual); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if actual.Name != "test" || actual.Network != nil || actual.Value != (testMarshalNetwork{}) {
+		t.Fatalf("unexpected result: %#v", actual)
+	}
+}
+
+func TestResourceDataUnmarshal_errors(t *testing.T) {
+	d := TestResourceDataRaw(t, testMarshalSchema(), map[string]interface{}{
+		"name":  "test",
+		"size":  300,
+		"ratio": 1.5,
+		"rule": []interface{}{
+			map[string]interface{}{"port": 80},
+			map[string]interface{}{"port": 53},
+		},
+	})
+
+	cases := map[string]struct {
+		Value interface{}
+		Err   string
+	}{
+		"not-pointer": {
+			Value: testMarshalThing{},
+			Err:   "expected non-nil pointer to struct",
+		},
+		"type-mismatch": {
+			Value: &struct {
+				Name int `tf:"name"`
+			}{},
+			Err: "name: cannot assign string to field of type int",
+		},
+		"unknown-attribute": {
+			Value: &struct {
+				Missing string `tf:"missing"`
+			}{},
+			Err: `references unknown attribute "missing"`,
+		},
+		"multiple-blocks-to-struct": {
+			Value: &struct {
+				Rule *testMarshalRule `tf:"rule"`
+			}{},
+			Err: "rule: cannot assign 2 blocks to field of type",
+		},
+		"nested-type-mismatch": {
+			Value: &struct {
+				Rules []struct {
+					Port string `tf:"port"`
+				} `tf:"rule"`
+			}{},
+			Err: "rule.0.port: cannot assign int to field of type string",
+		},
+		"nested-unknown-attribute": {
+			Value: &struct {
+				Rules []struct {
+					Missing string `tf:"missing"`
+				} `tf:"rule"`
+			}{},
+			Err: `rule.0: field Missing references unknown attribute "missing"`,
+		},
+		"float-to-int": {
+			Value: &struct {
+				Ratio int `tf:"ratio"`
+			}{},
+			Err: "ratio: cannot assign float64 to field of type int without truncation",
+		},
+		"int-overflow": {
+			Value: &struct {
+				Size int8 `tf:"size"`
+			}{},
+			Err: "size: value 300 overflows field of type int8",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := d.Unmarshal(tc.Value)
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.Err)
+			}
+
+			if !strings.Contains(err.Error(), tc.Err) {
+				t.Fatalf("expected error containing %q, got: %s", tc.Err, err)
+			}
+		})
+	}
+}
+
+func TestResourceDataMarshal(t *testing.T) {
+	d := TestResourceDataRaw(t, testMarshalSchema(), map[string]interface{}{})
+
+	v := testMarshalThing{
+		Name:    "test",
+		Size:    3,
+		Ratio:   0.5,
+		Enabled: true,
+		Zones:   []string{"a", "b"},
+		Aliases: []string{"x"},
+		Tags:    map[string]string{"env": "dev"},
+		Network: &testMarshalNetwork{
+			Subnet: "10.0.0.0/24",
+			Public: true,
+		},
+		Rules: []testMarshalRule{
+			{Port: 80, Protocol: "tcp"},
+		},
+		Ignored: "ignored",
+	}
+
+	if err := d.Marshal(&v); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var actual testMarshalThing
+	if err := d.Unmarshal(&actual); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v.Ignored = ""
+
+	if !reflect.DeepEqual(actual, v) {
+		t.Fatalf("expected:\n%#v\n\ngot:\n%#v", v, actual)
+	}
+
+	if got, want := d.Get("network.0.subnet"), "10.0.0.0/24"; got != want {
+		t.Fatalf("expected network.0.subnet %q, got %q", want, got)
+	}
+}
+
+func TestResourceDataMarshal_nilBlocks(t *testing.T) {
+	d := TestResourceDataRaw(t, testMarshalSchema(), map[string]interface{}{})
+
+	v := struct {
+		Rules []*testMarshalRule `tf:"rule"`
+	}{
+		Rules: []*testMarshalRule{
+			nil,
+			{Port: 80, Protocol: "tcp"},
+			nil,
+		},
+	}
+
+	if err := d.Marshal(v); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"port":     80,
+			"protocol": "tcp",
+		},
+	}
+
+	if got := d.Get("rule"); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected:\n%#v\n\ngot:\n%#v", expected, got)
+	}
+}
+
+func TestResourceDataMarshal_errors(t *testing.T) {
+	d := TestResourceDataRaw(t, testMarshalSchema(), map[string]interface{}{})
+
+	cases := map[string]struct {
+		Value interface{}
+		Err   string
+	}{
+		"not-struct": {
+			Value: "test",
+			Err:   "expected struct or pointer to struct",
+		},
+		"unknown-attribute": {
+			Value: struct {
+				Missing string `tf:"missing"`
+			}{},
+			Err: `references unknown attribute "missing"`,
+		},
+		"type-mismatch": {
+			Value: struct {
+				Size []string `tf:"size"`
+			}{
+				Size: []string{"a"},
+			},
+			Err: "size:",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := d.Marshal(tc.Value)
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.Err)
+			}
+
+			if !strings.Contains(err.Error(), tc.Err) {
+				t.Fatalf("expected error containing %q, got: %s", tc.Err, err)
+			}
+		})
+	}
+}
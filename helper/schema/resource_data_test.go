@@ -7,9 +7,13 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -1337,6 +1341,145 @@ func TestResourceDataGetOkExists(t *testing.T) {
 	}
 }
 
+func TestResourceDataGetOkRaw(t *testing.T) {
+	t.Parallel()
+
+	testSchema := map[string]*Schema{
+		"enabled": {
+			Type:     TypeBool,
+			Optional: true,
+		},
+		"name": {
+			Type:     TypeString,
+			Optional: true,
+			Default:  "default",
+		},
+		"block": {
+			Type:     TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &Resource{
+				Schema: map[string]*Schema{
+					"flag": {
+						Type:     TypeBool,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	blockType := cty.List(cty.Object(map[string]cty.Type{"flag": cty.Bool}))
+
+	testCases := map[string]struct {
+		rawConfig cty.Value
+		attrs     map[string]string
+		key       string
+		value     interface{}
+		ok        bool
+	}{
+		"false": {
+			rawConfig: cty.ObjectVal(map[string]cty.Value{
+				"enabled": cty.False,
+				"name":    cty.NullVal(cty.String),
+				"block":   cty.NullVal(blockType),
+			}),
+			attrs: map[string]string{"enabled": "false"},
+			key:   "enabled",
+			value: false,
+			ok:    true,
+		},
+		"omitted": {
+			rawConfig: cty.ObjectVal(map[string]cty.Value{
+				"enabled": cty.NullVal(cty.Bool),
+				"name":    cty.NullVal(cty.String),
+				"block":   cty.NullVal(blockType),
+			}),
+			key:   "enabled",
+			value: false,
+			ok:    false,
+		},
+		"unknown": {
+			rawConfig: cty.ObjectVal(map[string]cty.Value{
+				"enabled": cty.UnknownVal(cty.Bool),
+				"name":    cty.NullVal(cty.String),
+				"block":   cty.NullVal(blockType),
+			}),
+			key:   "enabled",
+			value: false,
+			ok:    true,
+		},
+		"default": {
+			rawConfig: cty.ObjectVal(map[string]cty.Value{
+				"enabled": cty.NullVal(cty.Bool),
+				"name":    cty.NullVal(cty.String),
+				"block":   cty.NullVal(blockType),
+			}),
+			attrs: map[string]string{"name": "default"},
+			key:   "name",
+			value: "default",
+			ok:    false,
+		},
+		"nested-false": {
+			rawConfig: cty.ObjectVal(map[string]cty.Value{
+				"enabled": cty.NullVal(cty.Bool),
+				"name":    cty.NullVal(cty.String),
+				"block": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"flag": cty.False}),
+				}),
+			}),
+			attrs: map[string]string{"block.#": "1", "block.0.flag": "false"},
+			key:   "block.0.flag",
+			value: false,
+			ok:    true,
+		},
+		"nested-missing-block": {
+			rawConfig: cty.ObjectVal(map[string]cty.Value{
+				"enabled": cty.NullVal(cty.Bool),
+				"name":    cty.NullVal(cty.String),
+				"block":   cty.NullVal(blockType),
+			}),
+			key:   "block.0.flag",
+			value: false,
+			ok:    false,
+		},
+		"no-config": {
+			rawConfig: cty.NullVal(cty.EmptyObject),
+			attrs:     map[string]string{"enabled": "false"},
+			key:       "enabled",
+			value:     false,
+			ok:        false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := schemaMap(testSchema).Data(&terraform.InstanceState{
+				ID:         "test",
+				Attributes: testCase.attrs,
+				RawConfig:  testCase.rawConfig,
+			}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			v, ok := d.GetOkRaw(testCase.key)
+
+			if !reflect.DeepEqual(v, testCase.value) {
+				t.Errorf("expected value %#v, got: %#v", testCase.value, v)
+			}
+
+			if ok != testCase.ok {
+				t.Errorf("expected ok: %t, got: %t", testCase.ok, ok)
+			}
+		})
+	}
+}
+
 func TestResourceDataTimeout(t *testing.T) {
 	cases := []struct {
 		Name     string
@@ -3775,6 +3918,42 @@ func TestResourceDataState_schema(t *testing.T) {
 				},
 			},
 		},
+
+		// #21 WriteOnly is not persisted
+		{
+			Schema: map[string]*Schema{
+				"password": {
+					Type:      TypeString,
+					Optional:  true,
+					WriteOnly: true,
+				},
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: nil,
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"password": {
+						Old: "",
+						New: "secret",
+					},
+					"name": {
+						Old: "",
+						New: "foo",
+					},
+				},
+			},
+
+			Result: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"name": "foo",
+				},
+			},
+		},
 	}
 
 	for i, tc := range cases {
@@ -4014,3 +4193,109 @@ func TestResourceDataSetType(t *testing.T) {
 func testPtrTo(raw interface{}) interface{} {
 	return &raw
 }
+
+func TestGetTypedMap(t *testing.T) {
+	s := map[string]*Schema{
+		"ports": {
+			Type:     TypeMap,
+			Optional: true,
+			Elem:     &Schema{Type: TypeString},
+		},
+		"weights": {
+			Type:     TypeMap,
+			Optional: true,
+			Elem:     &Schema{Type: TypeInt},
+		},
+		"name": {
+			Type:     TypeString,
+			Optional: true,
+		},
+	}
+
+	d := TestResourceDataRaw(t, s, map[string]interface{}{
+		"ports": map[string]interface{}{
+			"http":  "80",
+			"https": "443",
+			"bad":   "eighty",
+		},
+		"weights": map[string]interface{}{
+			"a": 1,
+		},
+		"name": "test",
+	})
+
+	got, diags := GetTypedMap(d, "ports", strconv.Atoi)
+
+	expected := map[string]int{"http": 80, "https": 443}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %#v, got: %#v", expected, got)
+	}
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got: %#v", diags)
+	}
+
+	if diags[0].Severity != diag.Error {
+		t.Fatalf("expected error diagnostic, got: %#v", diags[0])
+	}
+
+	if path := cty.GetAttrPath("ports").IndexString("bad"); !diags[0].AttributePath.Equals(path) {
+		t.Fatalf("expected attribute path %#v, got: %#v", path, diags[0].AttributePath)
+	}
+
+	// Values of typed map elements are formatted before parsing.
+	weights, diags := GetTypedMap(d, "weights", strconv.Atoi)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %#v", diags)
+	}
+
+	if !reflect.DeepEqual(weights, map[string]int{"a": 1}) {
+		t.Fatalf("expected weights, got: %#v", weights)
+	}
+
+	if _, diags := GetTypedMap(d, "name", strconv.ParseBool); !diags.HasError() {
+		t.Fatal("expected error for non-map attribute")
+	}
+}
+
+func TestGetTypedMap_nested(t *testing.T) {
+	s := map[string]*Schema{
+		"block": {
+			Type:     TypeList,
+			Optional: true,
+			Elem: &Resource{
+				Schema: map[string]*Schema{
+					"flags": {
+						Type:     TypeMap,
+						Optional: true,
+						Elem:     &Schema{Type: TypeString},
+					},
+				},
+			},
+		},
+	}
+
+	d := TestResourceDataRaw(t, s, map[string]interface{}{
+		"block": []interface{}{
+			map[string]interface{}{
+				"flags": map[string]interface{}{
+					"enabled": "yes",
+				},
+			},
+		},
+	})
+
+	_, diags := GetTypedMap(d, "block.0.flags", strconv.ParseBool)
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got: %#v", diags)
+	}
+
+	path := cty.GetAttrPath("block").IndexInt(0).GetAttr("flags").IndexString("enabled")
+
+	if !diags[0].AttributePath.Equals(path) {
+		t.Fatalf("expected attribute path %#v, got: %#v", path, diags[0].AttributePath)
+	}
+}
@@ -61,6 +61,15 @@ func (w *newValueWriter) WriteField(address []string, value interface{}, compute
 	return nil
 }
 
+// setComputed flags the address as computed without writing a value.
+func (w *newValueWriter) setComputed(address []string) {
+	w.once.Do(w.init)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.computedKeys[strings.Join(address, ".")] = true
+}
+
 // ComputedKeysMap returns the underlying computed keys map.
 func (w *newValueWriter) ComputedKeysMap() map[string]bool {
 	w.once.Do(w.init)
@@ -301,8 +310,16 @@ func (d *ResourceDiff) SetNew(key string, value interface{}) error {
 // SetNewComputed functions like SetNew, except that it blanks out a new value
 // and marks it as computed.
 //
+// Unlike SetNew, the key may also address an attribute nested within a list
+// block, including the block index, for example "block.0.attr". Attributes
+// nested within sets or maps cannot be addressed.
+//
 // This function is only allowed on computed attributes.
 func (d *ResourceDiff) SetNewComputed(key string) error {
+	if strings.Contains(key, ".") {
+		return d.setNestedComputed(key)
+	}
+
 	if err := d.checkKey(key, "SetNewComputed", false); err != nil {
 		return err
 	}
@@ -310,6 +327,32 @@ func (d *ResourceDiff) SetNewComputed(key string) error {
 	return d.setDiff(key, nil, true)
 }
 
+// setNestedComputed marks an attribute nested within a list block as
+// computed. The underlying writer only accepts whole lists, so the key is
+// flagged directly instead of written.
+func (d *ResourceDiff) setNestedComputed(key string) error {
+	if err := d.checkKey(key, "SetNewComputed", true); err != nil {
+		return err
+	}
+
+	keyParts := strings.Split(key, ".")
+	schemaL := addrToSchema(keyParts, d.schema)
+	for _, schema := range schemaL[:len(schemaL)-1] {
+		if schema.Type == TypeSet || schema.Type == TypeMap {
+			return fmt.Errorf("SetNewComputed: %s: cannot address attributes nested within sets or maps", key)
+		}
+	}
+
+	if err := d.clear(key); err != nil {
+		return err
+	}
+
+	d.newWriter.setComputed(keyParts)
+	d.updatedKeys[key] = true
+
+	return nil
+}
+
 // setDiff performs common diff setting behaviour.
 func (d *ResourceDiff) setDiff(key string, newValue interface{}, computed bool) error {
 	if err := d.clear(key); err != nil {
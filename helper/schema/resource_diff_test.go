@@ -691,6 +691,108 @@ func TestSetNewComputed(t *testing.T) {
 	}
 }
 
+func TestSetNewComputedNested(t *testing.T) {
+	blockSchema := func(blockType ValueType, computed bool) map[string]*Schema {
+		return map[string]*Schema{
+			"block": {
+				Type:     blockType,
+				Optional: true,
+				Elem: &Resource{
+					Schema: map[string]*Schema{
+						"a": {
+							Type:     TypeString,
+							Optional: true,
+						},
+						"b": {
+							Type:     TypeString,
+							Optional: true,
+							Computed: computed,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []resourceDiffTestCase{
+		{
+			Name:   "list block attribute",
+			Schema: blockSchema(TypeList, true),
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"block.#":   "2",
+					"block.0.a": "foo",
+					"block.0.b": "old",
+					"block.1.a": "",
+					"block.1.b": "bar",
+				},
+			},
+			Config: testConfig(t, map[string]interface{}{
+				"block": []interface{}{
+					map[string]interface{}{
+						"a": "foo",
+					},
+					map[string]interface{}{
+						"b": "bar",
+					},
+				},
+			}),
+			Diff: &terraform.InstanceDiff{Attributes: map[string]*terraform.ResourceAttrDiff{}},
+			Key:  "block.0.b",
+			Expected: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"block.0.b": {
+						Old:         "old",
+						NewComputed: true,
+					},
+				},
+			},
+		},
+		{
+			Name:          "non-computed list block attribute, should error",
+			Schema:        blockSchema(TypeList, false),
+			State:         &terraform.InstanceState{},
+			Config:        testConfig(t, map[string]interface{}{}),
+			Diff:          &terraform.InstanceDiff{Attributes: map[string]*terraform.ResourceAttrDiff{}},
+			Key:           "block.0.b",
+			ExpectedError: true,
+		},
+		{
+			Name:          "set block attribute, should error",
+			Schema:        blockSchema(TypeSet, true),
+			State:         &terraform.InstanceState{},
+			Config:        testConfig(t, map[string]interface{}{}),
+			Diff:          &terraform.InstanceDiff{Attributes: map[string]*terraform.ResourceAttrDiff{}},
+			Key:           "block.1234.b",
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			m := schemaMap(tc.Schema)
+			d := newResourceDiff(tc.Schema, tc.Config, tc.State, tc.Diff)
+			err := d.SetNewComputed(tc.Key)
+			switch {
+			case err != nil && !tc.ExpectedError:
+				t.Fatalf("bad: %s", err)
+			case err == nil && tc.ExpectedError:
+				t.Fatalf("Expected error, got none")
+			case err != nil && tc.ExpectedError:
+				return
+			}
+			for _, k := range d.UpdatedKeys() {
+				if err := m.diff(context.Background(), k, m.updatedKeySchema(k), tc.Diff, d, false); err != nil {
+					t.Fatalf("bad: %s", err)
+				}
+			}
+			if diff := cmp.Diff(tc.Expected, tc.Diff); diff != "" {
+				t.Fatalf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
 func TestForceNew(t *testing.T) {
 	cases := []resourceDiffTestCase{
 		{
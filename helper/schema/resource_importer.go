@@ -6,6 +6,9 @@ package schema
 import (
 	"context"
 	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // ResourceImporter defines how a resource is imported in Terraform. This
@@ -49,7 +52,10 @@ type StateFunc func(*ResourceData, interface{}) ([]*ResourceData, error)
 // multiple.
 //
 // To create the ResourceData structures for other resource types (if
-// you have to), instantiate your resource and call the Data function.
+// you have to), instantiate your resource and call the Data function, then
+// call the SetType method with the resource type name. ResourceData without
+// a type are of the imported resource type, and an error is returned for
+// types which are not in the provider ResourcesMap.
 type StateContextFunc func(context.Context, *ResourceData, interface{}) ([]*ResourceData, error)
 
 // InternalValidate should be called to validate the structure of this
@@ -80,3 +86,19 @@ func ImportStatePassthrough(d *ResourceData, m interface{}) ([]*ResourceData, er
 func ImportStatePassthroughContext(ctx context.Context, d *ResourceData, m interface{}) ([]*ResourceData, error) {
 	return []*ResourceData{d}, nil
 }
+
+// redactImportState removes the attributes listed in ImportRedact, including
+// any flatmap keys nested within them, from the given imported state.
+func (r *Resource) redactImportState(s *terraform.InstanceState) {
+	if len(r.ImportRedact) == 0 || s.Attributes == nil {
+		return
+	}
+
+	for _, redact := range r.ImportRedact {
+		for k := range s.Attributes {
+			if k == redact || strings.HasPrefix(k, redact+".") {
+				delete(s.Attributes, k)
+			}
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -73,6 +74,198 @@ func TestResourceApply_create(t *testing.T) {
 	}
 }
 
+func TestResourceApply_createWaitForReadable(t *testing.T) {
+	reads := 0
+
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": {
+				Type:     TypeInt,
+				Optional: true,
+			},
+			"status": {
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+		WaitForReadableAfterCreate: true,
+		CreateContext: func(_ context.Context, d *ResourceData, _ interface{}) diag.Diagnostics {
+			d.SetId("foo")
+			return nil
+		},
+		ReadContext: func(_ context.Context, d *ResourceData, _ interface{}) diag.Diagnostics {
+			reads++
+
+			switch reads {
+			case 1:
+				// not yet visible
+				d.SetId("")
+				return nil
+			case 2:
+				return diag.Errorf("eventual consistency")
+			}
+
+			if err := d.Set("status", "ready"); err != nil {
+				return diag.FromErr(err)
+			}
+
+			return diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  "read warning",
+				},
+			}
+		},
+	}
+
+	d := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"foo": {
+				New: "42",
+			},
+		},
+	}
+
+	actual, diags := r.Apply(context.Background(), nil, d, nil)
+	if diags.HasError() {
+		t.Fatalf("err: %s", diagutils.ErrorDiags(diags))
+	}
+
+	if reads != 3 {
+		t.Fatalf("expected 3 reads, got %d", reads)
+	}
+
+	if len(diags) != 1 || diags[0].Summary != "read warning" {
+		t.Fatalf("expected only the successful read diagnostics, got: %#v", diags)
+	}
+
+	expected := map[string]string{
+		"id":     "foo",
+		"foo":    "42",
+		"status": "ready",
+	}
+
+	if actual.ID != "foo" || !reflect.DeepEqual(actual.Attributes, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceApply_createWaitForReadableTimeout(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": {
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+		WaitForReadableAfterCreate: true,
+		CreateContext: func(_ context.Context, d *ResourceData, _ interface{}) diag.Diagnostics {
+			d.SetId("foo")
+			return nil
+		},
+		ReadContext: func(_ context.Context, d *ResourceData, _ interface{}) diag.Diagnostics {
+			return diag.Errorf("eventual consistency")
+		},
+	}
+
+	d := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"foo": {
+				New: "42",
+			},
+		},
+	}
+
+	diffTimeout := &ResourceTimeout{
+		Create: DefaultTimeout(time.Second),
+	}
+
+	if err := diffTimeout.DiffEncode(d); err != nil {
+		t.Fatalf("Error encoding timeout to diff: %s", err)
+	}
+
+	actual, diags := r.Apply(context.Background(), nil, d, nil)
+	if !diags.HasError() {
+		t.Fatal("expected error")
+	}
+
+	if diags[len(diags)-1].Summary != "Resource not readable after create" {
+		t.Fatalf("unexpected diagnostics: %#v", diags)
+	}
+
+	// The created instance is still returned so it can be tainted.
+	if actual == nil || actual.ID != "foo" {
+		t.Fatalf("expected created instance state, got: %#v", actual)
+	}
+}
+
+func TestResourceApply_ignoreServerAddedKeys(t *testing.T) {
+	tags := &Schema{
+		Type:                  TypeMap,
+		Optional:              true,
+		Computed:              true,
+		IgnoreServerAddedKeys: true,
+		Elem:                  &Schema{Type: TypeString},
+	}
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"tags": tags,
+			"block": {
+				Type:     TypeList,
+				Optional: true,
+				Elem: &Resource{
+					Schema: map[string]*Schema{
+						"labels": tags,
+					},
+				},
+			},
+		},
+		Create: func(d *ResourceData, m interface{}) error {
+			d.SetId("foo")
+			return nil
+		},
+	}
+
+	d := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"tags.%": {
+				New: "1",
+			},
+			"tags.user": {
+				New: "a",
+			},
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"tags": cty.MapVal(map[string]cty.Value{
+				"user":  cty.StringVal("a"),
+				"other": cty.StringVal("b"),
+			}),
+			"block": cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"labels": cty.NullVal(cty.Map(cty.String)),
+				}),
+				cty.ObjectVal(map[string]cty.Value{
+					"labels": cty.MapValEmpty(cty.String),
+				}),
+			}),
+		}),
+	}
+
+	state, diags := r.Apply(context.Background(), nil, d, nil)
+	if diags.HasError() {
+		t.Fatalf("err: %s", diagutils.ErrorDiags(diags))
+	}
+
+	expected := map[string]interface{}{
+		"tags":           []string{"other", "user"},
+		"block.1.labels": []string{},
+	}
+
+	if got := state.Meta[configuredMapKeysMetaKey]; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected recorded keys:\n%#v\n\ngot:\n%#v", expected, got)
+	}
+}
+
 func TestResourceApply_Timeout_state(t *testing.T) {
 	r := &Resource{
 		SchemaVersion: 2,
@@ -142,6 +335,137 @@ func TestResourceApply_Timeout_state(t *testing.T) {
 	}
 }
 
+func TestResourceApply_TimeoutFunc(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"size": {
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+		Timeouts: &ResourceTimeout{
+			Create: DefaultTimeout(40 * time.Minute),
+			Delete: DefaultTimeout(40 * time.Minute),
+		},
+		TimeoutFunc: func(op string, d *ResourceData) time.Duration {
+			if op != TimeoutCreate {
+				return 0
+			}
+
+			return time.Duration(d.Get("size").(int)) * time.Minute
+		},
+	}
+
+	var createTimeout, deleteTimeout time.Duration
+	r.Create = func(d *ResourceData, m interface{}) error {
+		createTimeout = d.Timeout(TimeoutCreate)
+		deleteTimeout = d.Timeout(TimeoutDelete)
+		d.SetId("foo")
+		return nil
+	}
+
+	testCases := map[string]struct {
+		size           string
+		timeouts       map[string]interface{}
+		expectedCreate time.Duration
+		expectedDelete time.Duration
+	}{
+		"scaled": {
+			size:           "90",
+			expectedCreate: 90 * time.Minute,
+			expectedDelete: 40 * time.Minute,
+		},
+		"zero falls back to static timeout": {
+			size:           "0",
+			expectedCreate: 40 * time.Minute,
+			expectedDelete: 40 * time.Minute,
+		},
+		"configured timeout takes precedence": {
+			size: "90",
+			timeouts: map[string]interface{}{
+				TimeoutCreate: "5m",
+				TimeoutDelete: "10m",
+			},
+			expectedCreate: 5 * time.Minute,
+			expectedDelete: 10 * time.Minute,
+		},
+		"configured timeout equal to default takes precedence": {
+			size: "90",
+			timeouts: map[string]interface{}{
+				TimeoutCreate: "40m",
+			},
+			expectedCreate: 40 * time.Minute,
+			expectedDelete: 40 * time.Minute,
+		},
+		"configured timeout for other operation": {
+			size: "90",
+			timeouts: map[string]interface{}{
+				TimeoutDelete: "10m",
+			},
+			expectedCreate: 90 * time.Minute,
+			expectedDelete: 10 * time.Minute,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			timeoutsVal := cty.NullVal(cty.Object(map[string]cty.Type{
+				TimeoutCreate: cty.String,
+				TimeoutDelete: cty.String,
+			}))
+			config := map[string]interface{}{}
+
+			if tc.timeouts != nil {
+				vals := map[string]cty.Value{
+					TimeoutCreate: cty.NullVal(cty.String),
+					TimeoutDelete: cty.NullVal(cty.String),
+				}
+
+				for k, v := range tc.timeouts {
+					vals[k] = cty.StringVal(v.(string))
+				}
+
+				timeoutsVal = cty.ObjectVal(vals)
+				config[TimeoutsConfigKey] = []interface{}{tc.timeouts}
+			}
+
+			var timeouts ResourceTimeout
+			if err := timeouts.ConfigDecode(r, terraform.NewResourceConfigRaw(config)); err != nil {
+				t.Fatalf("Error decoding timeout config: %s", err)
+			}
+
+			d := &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"size": {
+						New: tc.size,
+					},
+				},
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"size":            cty.StringVal(tc.size),
+					TimeoutsConfigKey: timeoutsVal,
+				}),
+			}
+
+			if err := timeouts.DiffEncode(d); err != nil {
+				t.Fatalf("Error encoding timeout to diff: %s", err)
+			}
+
+			_, diags := r.Apply(context.Background(), nil, d, nil)
+			if diags.HasError() {
+				t.Fatalf("err: %s", diagutils.ErrorDiags(diags))
+			}
+
+			if createTimeout != tc.expectedCreate {
+				t.Errorf("expected create timeout %s, got: %s", tc.expectedCreate, createTimeout)
+			}
+
+			if deleteTimeout != tc.expectedDelete {
+				t.Errorf("expected delete timeout %s, got: %s", tc.expectedDelete, deleteTimeout)
+			}
+		})
+	}
+}
+
 // Regression test to ensure that the meta data is read from state, if a
 // resource is destroyed and the timeout meta is no longer available from the
 // config
@@ -1125,6 +1449,111 @@ func TestResourceInternalValidate(t *testing.T) {
 			Writable: true,
 			Err:      true,
 		},
+		29: { // ImportRedact existing attribute
+			In: &Resource{
+				Schema: map[string]*Schema{
+					"test": {
+						Type:     TypeString,
+						Required: true,
+					},
+					"secret": {
+						Type:      TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+				Importer:     &ResourceImporter{},
+				ImportRedact: []string{"secret"},
+				Create:       Noop,
+				Read:         Noop,
+				Update:       Noop,
+				Delete:       Noop,
+			},
+			Writable: true,
+			Err:      false,
+		},
+		30: { // ImportRedact without Importer
+			In: &Resource{
+				Schema: map[string]*Schema{
+					"test": {
+						Type:     TypeString,
+						Required: true,
+					},
+					"secret": {
+						Type:      TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+				ImportRedact: []string{"secret"},
+				Create:       Noop,
+				Read:         Noop,
+				Update:       Noop,
+				Delete:       Noop,
+			},
+			Writable: true,
+			Err:      true,
+		},
+		31: { // ImportRedact unknown attribute
+			In: &Resource{
+				Schema: map[string]*Schema{
+					"test": {
+						Type:     TypeString,
+						Required: true,
+					},
+					"secret": {
+						Type:      TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+				Importer:     &ResourceImporter{},
+				ImportRedact: []string{"missing"},
+				Create:       Noop,
+				Read:         Noop,
+				Update:       Noop,
+				Delete:       Noop,
+			},
+			Writable: true,
+			Err:      true,
+		},
+		32: { // ImportRedact id
+			In: &Resource{
+				Schema: map[string]*Schema{
+					"test": {
+						Type:     TypeString,
+						Required: true,
+					},
+					"secret": {
+						Type:      TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+				Importer:     &ResourceImporter{},
+				ImportRedact: []string{"id"},
+				Create:       Noop,
+				Read:         Noop,
+				Update:       Noop,
+				Delete:       Noop,
+			},
+			Writable: true,
+			Err:      true,
+		},
+		33: { // Data source ImportRedact
+			In: &Resource{
+				Schema: map[string]*Schema{
+					"secret": {
+						Type:     TypeString,
+						Computed: true,
+					},
+				},
+				ImportRedact: []string{"secret"},
+				Read:         Noop,
+			},
+			Writable: false,
+			Err:      true,
+		},
 	}
 
 	for i, tc := range cases {
@@ -1144,6 +1573,142 @@ func TestResourceInternalValidate(t *testing.T) {
 	}
 }
 
+func TestResourceCollectValidationDiagnostics(t *testing.T) {
+	lowercase := func(v interface{}, path cty.Path) diag.Diagnostics {
+		if s := v.(string); s != strings.ToLower(s) {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Invalid value",
+					Detail:        "must be lowercase",
+					AttributePath: path,
+				},
+			}
+		}
+
+		return nil
+	}
+
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"a": {
+				Type:             TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"b"},
+				ValidateDiagFunc: lowercase,
+			},
+			"b": {
+				Type:         TypeString,
+				Optional:     true,
+				RequiredWith: []string{"c"},
+			},
+			"c": {
+				Type:     TypeString,
+				Optional: true,
+			},
+			"block": {
+				Type:     TypeList,
+				Optional: true,
+				Elem: &Resource{
+					Schema: map[string]*Schema{
+						"x": {
+							Type:     TypeString,
+							Required: true,
+						},
+						"y": {
+							Type:             TypeString,
+							Optional:         true,
+							ValidateDiagFunc: lowercase,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.NullVal(cty.String),
+		"a":  cty.StringVal("UPPER"),
+		"b":  cty.StringVal("set"),
+		"c":  cty.NullVal(cty.String),
+		"block": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"x": cty.NullVal(cty.String),
+				"y": cty.StringVal("UPPER"),
+			}),
+		}),
+	})
+
+	expected := diag.Diagnostics{
+		{
+			Severity:      diag.Error,
+			Summary:       "Conflicting configuration arguments",
+			Detail:        `"a": conflicts with b`,
+			AttributePath: cty.GetAttrPath("a"),
+		},
+		{
+			Severity:      diag.Error,
+			Summary:       "Invalid value",
+			Detail:        "must be lowercase",
+			AttributePath: cty.GetAttrPath("a"),
+		},
+		{
+			Severity:      diag.Error,
+			Summary:       "Missing required argument",
+			Detail:        `"b": all of ` + "`b,c`" + ` must be specified`,
+			AttributePath: cty.GetAttrPath("b"),
+		},
+		{
+			Severity:      diag.Error,
+			Summary:       "Missing required argument",
+			Detail:        `The argument "block.0.x" is required, but no definition was found.`,
+			AttributePath: cty.GetAttrPath("block").IndexInt(0).GetAttr("x"),
+		},
+		{
+			Severity:      diag.Error,
+			Summary:       "Invalid value",
+			Detail:        "must be lowercase",
+			AttributePath: cty.GetAttrPath("block").IndexInt(0).GetAttr("y"),
+		},
+	}
+
+	diags := r.CollectValidationDiagnostics(context.Background(), cfg)
+
+	if len(diags) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %d: %#v", len(expected), len(diags), diags)
+	}
+
+	for _, d := range expected {
+		if !containsDiagnostic(diags, d) {
+			t.Errorf("expected diagnostic not found: %#v\n\ngot: %#v", d, diags)
+		}
+	}
+
+	// Validate stops at the first failed constraint of each attribute.
+	if validateDiags := r.Validate(terraform.NewResourceConfigShimmed(cfg, r.CoreConfigSchema())); len(validateDiags) >= len(diags) {
+		t.Fatalf("expected Validate to report fewer diagnostics, got: %#v", validateDiags)
+	}
+}
+
+func TestResourceCollectValidationDiagnostics_invalidValue(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"a": {
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+	}
+
+	diags := r.CollectValidationDiagnostics(context.Background(), cty.ObjectVal(map[string]cty.Value{
+		"a": cty.StringVal("value"),
+	}))
+
+	if !diags.HasError() {
+		t.Fatal("expected error for configuration not conforming to the schema")
+	}
+}
+
 func TestResourceRefresh(t *testing.T) {
 	r := &Resource{
 		SchemaVersion: 2,
@@ -1666,3 +2231,67 @@ func TestResource_ContextTimeout(t *testing.T) {
 		t.Fatal("context does not have timeout")
 	}
 }
+
+func TestResourceAttributePaths(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"name": {
+				Type:     TypeString,
+				Required: true,
+			},
+			"tags": {
+				Type:     TypeMap,
+				Optional: true,
+				Elem:     &Schema{Type: TypeString},
+			},
+			"rule": {
+				Type:     TypeList,
+				Optional: true,
+				Elem: &Resource{
+					Schema: map[string]*Schema{
+						"port": {
+							Type:     TypeInt,
+							Optional: true,
+						},
+						"target": {
+							Type:     TypeSet,
+							Optional: true,
+							Elem: &Resource{
+								Schema: map[string]*Schema{
+									"address": {
+										Type:     TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rule := cty.GetAttrPath("rule").Index(cty.UnknownVal(cty.Number))
+	target := rule.GetAttr("target").Index(cty.DynamicVal)
+
+	expected := []cty.Path{
+		cty.GetAttrPath("name"),
+		cty.GetAttrPath("rule"),
+		rule.GetAttr("port"),
+		rule.GetAttr("target"),
+		target.GetAttr("address"),
+		cty.GetAttrPath("tags"),
+	}
+
+	got := r.AttributePaths()
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d paths, got: %#v", len(expected), got)
+	}
+
+	for i := range expected {
+		if !got[i].Equals(expected[i]) {
+			t.Errorf("path %d: expected %#v, got: %#v", i, expected[i], got[i])
+		}
+	}
+}
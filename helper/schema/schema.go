@@ -100,6 +100,17 @@ type Schema struct {
 	// its value.
 	Computed bool
 
+	// ComputedDependsOn is a list of top level Computed attributes this
+	// Computed attribute is derived from. When any of them is planned as
+	// unknown, such as by the ResourceDiff type SetNewComputed method, this
+	// attribute is also planned as unknown (known after apply) rather than
+	// keeping its prior state value. Dependencies are followed transitively,
+	// so chains of derived attributes are all planned as unknown.
+	//
+	// An Optional attribute is only planned as unknown when it is not set in
+	// the configuration. This field is only valid for top level attributes.
+	ComputedDependsOn []string
+
 	// ForceNew indicates whether a change in this value requires the
 	// replacement (destroy and create) of the managed resource instance,
 	// rather than an in-place update. This field is only valid when the
@@ -107,8 +118,57 @@ type Schema struct {
 	//
 	// If conditional replacement logic is needed, use the Resource type
 	// CustomizeDiff field to call the ResourceDiff type ForceNew method.
+	//
+	// ForceNew on an attribute nested within a TypeSet block effectively
+	// applies to the whole set. Set elements are identified by their hash,
+	// so changing any attribute of an element, or adding or removing an
+	// element, is planned as removing the old element and adding a new one.
+	// The ForceNew attribute of that element then changes from or to empty,
+	// which requires replacement even if only a sibling attribute without
+	// ForceNew was changed. InternalValidate logs a warning for this case.
 	ForceNew bool
 
+	// Immutable indicates that this value can only be set when the managed
+	// resource instance is created. Any planned change to the value of an
+	// existing instance, including changes to values nested within it,
+	// returns an error asking the practitioner to replace the instance
+	// manually (e.g. terraform apply -replace) instead. This is intended for
+	// remote APIs which reject updates to a value, but where silently
+	// replacing the instance, as ForceNew does, is undesirable.
+	//
+	// Changes are permitted when the instance is already planned for
+	// replacement, for example due to another ForceNew attribute or tainting.
+	// Immutable cannot be combined with ForceNew and is only valid for
+	// configurable attributes of managed resources.
+	Immutable bool
+
+	// UpdateCost is an optional relative hint of how expensive an in-place
+	// update of this value is for the remote system, such as an update which
+	// restarts a server. Higher values are more expensive and zero means no
+	// hint is given. The SDK does not act on this value. It is included in
+	// the CoreConfigSchema of the resource for tooling, such as wrappers
+	// which order or batch updates, but is not sent to Terraform as the
+	// protocol has no equivalent field.
+	//
+	// UpdateCost cannot be negative and is only valid for configurable
+	// attributes.
+	UpdateCost int
+
+	// WriteOnly indicates that this value is accepted from the configuration
+	// and passed to the create and update functionality, but is never stored
+	// in state, such as a credential which the remote system does not return.
+	// Sensitive should usually also be set.
+	//
+	// As there is no prior state value to compare against, a configured value
+	// is never considered a change by itself, so changing only this value
+	// does not plan an update. It is available with the ResourceData type Get
+	// method when the create or update is planned due to other changes.
+	//
+	// WriteOnly cannot be combined with Computed, ForceNew, or Immutable and
+	// is only valid for top level attributes of primitive types in managed
+	// resources.
+	WriteOnly bool
+
 	// If this is non-nil, the provided function will be used during diff
 	// of this field. If this is nil, a default diff for the type of the
 	// schema will be used.
@@ -125,6 +185,12 @@ type Schema struct {
 	// a configuration value is significantly different than the prior state
 	// value during planning. Set DiffSuppressOnRefresh to opt in to checking
 	// this also during the refresh step.
+	//
+	// Computed-only attributes have no configuration value, so their
+	// DiffSuppressFunc is instead called only during the refresh step, with
+	// the prior state value as old and the value set by Read as new, as if
+	// DiffSuppressOnRefresh were set. This prevents perpetual changes when the
+	// remote API returns semantically equal values, such as normalized JSON.
 	DiffSuppressFunc SchemaDiffSuppressFunc
 
 	// DiffSuppressOnRefresh enables using the DiffSuppressFunc to ignore
@@ -156,6 +222,40 @@ type Schema struct {
 	// for existing providers if activated everywhere all at once.
 	DiffSuppressOnRefresh bool
 
+	// PlanModifiers are called in order during planning to adjust the
+	// planned value of this attribute, or to require replacement of the
+	// resource, based on the prior state, configuration, and planned values.
+	// Each modifier receives the response of the previous one, so they can be
+	// composed. For example, UseStateForUnknown keeps the prior state value
+	// rather than planning an unknown value.
+	//
+	// Modifiers are called for each planned change to the attribute, after
+	// DiffSuppressFunc and CustomizeDiff. When the resource is planned for
+	// replacement, the plan is recalculated without prior state and only
+	// RequiresReplace is retained from the modifiers.
+	//
+	// This field is only valid for attributes of primitive types. Modifiers
+	// are not called for attributes nested within a TypeSet, since changing
+	// the planned value would change the identity of the set element.
+	PlanModifiers []AttributePlanModifier
+
+	// IgnoreServerAddedKeys, if true, retains map keys which are present in
+	// the prior state but were never in the configuration, rather than
+	// planning their removal. This is intended for Optional and Computed
+	// TypeMap attributes where the remote system adds its own keys, such as
+	// system tags, which would otherwise cause a perpetual difference. Keys
+	// in the configuration are added, updated, and removed as normal.
+	//
+	// The configured keys are recorded in the instance private state each
+	// time it is applied, so a key which was configured when the instance
+	// was last applied and has since been removed from the configuration is
+	// planned for removal. Keys configured before the first apply with this
+	// field set are not recorded, so are retained like keys added by the
+	// remote system.
+	//
+	// This field is only valid for Optional and Computed TypeMap attributes.
+	IgnoreServerAddedKeys bool
+
 	// Default indicates a value to set if this attribute is not set in the
 	// configuration. Default cannot be used with DefaultFunc or Required.
 	// Default is only supported if the Type is TypeBool, TypeFloat, TypeInt,
@@ -186,6 +286,28 @@ type Schema struct {
 	// default.
 	DefaultFunc SchemaDefaultFunc
 
+	// DefaultFrom is the key of a sibling attribute, at the same level of
+	// nesting, whose configured value is used when this attribute is not set
+	// in the configuration, such as a description which defaults to the
+	// name. If the sibling value is unknown, so is this value. If the sibling
+	// is also not set, this attribute is unset.
+	//
+	// DefaultFrom cannot be used with Default, DefaultFunc, Required, or
+	// Computed and is only valid for primitive types. The sibling must have
+	// the same Type and cannot itself have DefaultFrom.
+	DefaultFrom string
+
+	// DefaultBlock is the block to use when a TypeList or TypeSet block,
+	// with MaxItems of 1, is omitted from the configuration. It is a map of
+	// the block's attribute values, matching the Elem schema, and can be
+	// empty to only ensure the block exists with the Default values of its
+	// attributes. This is useful for APIs which require the structure of the
+	// block even when practitioners do not configure it.
+	//
+	// DefaultBlock cannot be used with Required or Computed. The default is
+	// validated against the Elem schema by InternalValidate.
+	DefaultBlock map[string]interface{}
+
 	// Description is used as the description for docs, the language server and
 	// other user facing usage. It can be plain-text or markdown depending on the
 	// global DescriptionKind setting.
@@ -202,6 +324,20 @@ type Schema struct {
 	// to simply store the hash of it.
 	StateFunc SchemaStateFunc
 
+	// ReadTransformFunc is a function called to transform the value of this
+	// attribute in the prior state, as returned by Read, before it is
+	// compared with the configuration when calculating the diff. If the
+	// transformed value equals the configuration value, no difference is
+	// planned. Unlike StateFunc, the value stored in the state is left in the
+	// form returned by Read.
+	//
+	// This is useful when the form needed for comparison differs from both
+	// the configured form and the form returned by the remote system.
+	//
+	// This is only valid for primitive types and cannot be used with
+	// computed-only attributes.
+	ReadTransformFunc func(cty.Value) cty.Value
+
 	// Elem represents the element type for a TypeList, TypeSet, or TypeMap
 	// attribute or block. The only valid types are *Schema and *Resource.
 	// Only TypeList and TypeSet support *Resource.
@@ -296,6 +432,21 @@ type Schema struct {
 	// "parent_block_name.0.child_attribute_name".
 	AtLeastOneOf []string
 
+	// AtMostOneOf is a set of attribute paths, including this attribute,
+	// where at most one attribute out of all specified can be configured.
+	// Unlike ExactlyOneOf, it is valid for none of them to be configured.
+	// This implements the validation logic declaratively within the schema
+	// and can trigger earlier in Terraform operations, rather than using
+	// create or update logic which only triggers during apply.
+	//
+	// Only absolute attribute paths, ones starting with top level attribute
+	// names, are supported. Attribute paths cannot be accurately declared
+	// for TypeList (if MaxItems is greater than 1), TypeMap, or TypeSet
+	// attributes. To reference an attribute under a single configuration block
+	// (TypeList with Elem of *Resource and MaxItems of 1), the syntax is
+	// "parent_block_name.0.child_attribute_name".
+	AtMostOneOf []string
+
 	// RequiredWith is a set of attribute paths, including this attribute,
 	// that must be set simultaneously. This implements the validation logic
 	// declaratively within the schema and can trigger earlier in Terraform
@@ -339,6 +490,17 @@ type Schema struct {
 	//  - https://github.com/hashicorp/terraform/issues/7569
 	Deprecated string
 
+	// SupersededBy is the path of the attribute which replaces this deprecated
+	// attribute or block, such as "settings.0.name" when it has moved into a
+	// nested block. The path uses the same syntax as ConflictsWith, starting
+	// from the top of the resource schema, and requires Deprecated.
+	//
+	// The path is added to the deprecation warning diagnostic detail as a
+	// final "Superseded by: <path>" line, which editors can use to offer a
+	// quick fix that moves the configuration to the new location. It is also
+	// included in the CoreConfigSchema of the resource.
+	SupersededBy string
+
 	// ValidateFunc allows individual fields to define arbitrary validation
 	// logic. It is yielded the provided config value as an interface{} that is
 	// guaranteed to be of the proper Schema type, and it can yield warnings or
@@ -432,6 +594,51 @@ func EnvDefaultFunc(k string, dv interface{}) SchemaDefaultFunc {
 	}
 }
 
+// EnvDefaultFuncTyped is a helper function like EnvDefaultFunc, except that
+// the value of the environment variable is converted to the given attribute
+// type: bool for TypeBool, int for TypeInt, float64 for TypeFloat and string
+// for TypeString. The default value, which may be nil, is returned unchanged
+// if the environment variable is unset. A malformed environment variable
+// value, or any other type, returns an error instead of passing the raw
+// string along.
+func EnvDefaultFuncTyped(k string, t ValueType, dv interface{}) SchemaDefaultFunc {
+	return func() (interface{}, error) {
+		v := os.Getenv(k)
+
+		if v == "" {
+			return dv, nil
+		}
+
+		switch t {
+		case TypeBool:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("environment variable %s must be a bool, got: %q", k, v)
+			}
+
+			return b, nil
+		case TypeInt:
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("environment variable %s must be an integer, got: %q", k, v)
+			}
+
+			return i, nil
+		case TypeFloat:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("environment variable %s must be a float, got: %q", k, v)
+			}
+
+			return f, nil
+		case TypeString:
+			return v, nil
+		default:
+			return nil, fmt.Errorf("environment variable %s: unsupported attribute type %s", k, t)
+		}
+	}
+}
+
 // MultiEnvDefaultFunc is a helper function that returns the value of the first
 // environment variable in the given list that returns a non-empty value. If
 // none of the environment variables return a value, the default value is
@@ -604,6 +811,7 @@ func (s *Schema) validateFunc(decoded interface{}, k string, path cty.Path) diag
 				Severity:      diag.Error,
 				Summary:       e.Error(),
 				AttributePath: path,
+				Code:          diag.ErrorCode(e),
 			})
 		}
 	}
@@ -667,6 +875,9 @@ func (m schemaMap) Diff(
 		result.RawPlan = s.RawPlan
 	}
 
+	c = m.withDefaultBlocks(c)
+	c = m.withDefaultFrom(c)
+
 	d := &ResourceData{
 		schema:       m,
 		state:        s,
@@ -702,13 +913,23 @@ func (m schemaMap) Diff(
 			return nil, err
 		}
 		for _, k := range rd.UpdatedKeys() {
-			err := m.diff(ctx, k, mc[k], result, rd, false)
+			err := m.diff(ctx, k, mc.updatedKeySchema(k), result, rd, false)
 			if err != nil {
 				return nil, err
 			}
 		}
 	}
 
+	if !result.DestroyTainted {
+		m.applyPlanModifiers(ctx, d, result)
+	}
+
+	if s != nil && s.ID != "" && !result.DestroyTainted && !result.RequiresNew() {
+		if err := m.validateImmutable(result); err != nil {
+			return nil, err
+		}
+	}
+
 	if handleRequiresNew {
 		// If the diff requires a new resource, then we recompute the diff
 		// so we have the complete new resource diff, and preserve the
@@ -746,7 +967,7 @@ func (m schemaMap) Diff(
 					return nil, err
 				}
 				for _, k := range rd.UpdatedKeys() {
-					err := m.diff(ctx, k, mc[k], result2, rd, false)
+					err := m.diff(ctx, k, mc.updatedKeySchema(k), result2, rd, false)
 					if err != nil {
 						return nil, err
 					}
@@ -793,6 +1014,16 @@ func (m schemaMap) Diff(
 
 	}
 
+	if !result.DestroyTainted {
+		m.applyComputedDependsOn(s, c, result)
+	}
+
+	// Write-only values are never in state, so differences in them alone
+	// are not a change to an existing resource.
+	if s != nil && s.ID != "" && m.onlyWriteOnlyDiff(result) {
+		return nil, nil
+	}
+
 	// Go through and detect all of the ComputedWhens now that we've
 	// finished the diff.
 	// TODO
@@ -805,6 +1036,325 @@ func (m schemaMap) Diff(
 	return result, nil
 }
 
+// updatedKeySchema returns the schema for a key updated by a ResourceDiff,
+// which may address an attribute nested within a list block.
+func (m schemaMap) updatedKeySchema(k string) *Schema {
+	if schema, ok := m[k]; ok {
+		return schema
+	}
+
+	schemaL := addrToSchema(strings.Split(k, "."), m)
+	if len(schemaL) == 0 {
+		return nil
+	}
+
+	return schemaL[len(schemaL)-1]
+}
+
+// onlyWriteOnlyDiff returns whether every attribute difference in the diff
+// is for a WriteOnly attribute.
+func (m schemaMap) onlyWriteOnlyDiff(diff *terraform.InstanceDiff) bool {
+	if diff.Destroy || diff.DestroyTainted {
+		return false
+	}
+
+	found := false
+
+	for k, attr := range diff.Attributes {
+		if attr == nil {
+			continue
+		}
+
+		schema, ok := m[strings.SplitN(k, ".", 2)[0]]
+
+		if !ok || !schema.WriteOnly {
+			return false
+		}
+
+		found = true
+	}
+
+	return found
+}
+
+// applyComputedDependsOn plans an unknown value for each attribute with
+// ComputedDependsOn which is not configured and depends on an attribute
+// planned as unknown. It repeats until no more attributes are changed, so
+// chains of dependent attributes are all planned as unknown.
+func (m schemaMap) applyComputedDependsOn(s *terraform.InstanceState, c *terraform.ResourceConfig, diff *terraform.InstanceDiff) {
+	var keys []string
+
+	for k, schema := range m {
+		if len(schema.ComputedDependsOn) > 0 {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, k := range keys {
+			if m.diffComputed(k, diff) {
+				continue
+			}
+
+			if c != nil {
+				if _, ok := c.Get(k); ok {
+					continue
+				}
+			}
+
+			for _, dep := range m[k].ComputedDependsOn {
+				if m.diffComputed(dep, diff) {
+					m.setDiffComputed(k, s, diff)
+					changed = true
+
+					break
+				}
+			}
+		}
+	}
+}
+
+// computedDiffKey returns the flatmap key which is planned as unknown when
+// the value of the top level attribute is unknown.
+func (m schemaMap) computedDiffKey(k string) string {
+	switch m[k].Type {
+	case TypeList, TypeSet:
+		return k + ".#"
+	case TypeMap:
+		return k + ".%"
+	default:
+		return k
+	}
+}
+
+// diffComputed returns whether the diff plans an unknown value for the top
+// level attribute.
+func (m schemaMap) diffComputed(k string, diff *terraform.InstanceDiff) bool {
+	if _, ok := m[k]; !ok {
+		return false
+	}
+
+	attr := diff.Attributes[m.computedDiffKey(k)]
+
+	return attr != nil && attr.NewComputed
+}
+
+// setDiffComputed plans an unknown value for the top level attribute,
+// replacing any planned changes to the values nested within it.
+func (m schemaMap) setDiffComputed(k string, s *terraform.InstanceState, diff *terraform.InstanceDiff) {
+	key := m.computedDiffKey(k)
+
+	if key != k {
+		for attrK := range diff.Attributes {
+			if strings.HasPrefix(attrK, k+".") {
+				delete(diff.Attributes, attrK)
+			}
+		}
+	}
+
+	attr := &terraform.ResourceAttrDiff{
+		NewComputed: true,
+	}
+
+	if s != nil {
+		attr.Old = s.Attributes[key]
+	}
+
+	if existing := diff.Attributes[key]; existing != nil {
+		attr.RequiresNew = existing.RequiresNew
+	}
+
+	diff.Attributes[key] = attr
+}
+
+// validateImmutable returns an error if the given diff of an existing
+// resource instance changes the value of any attribute marked Immutable, or
+// of any value nested within one.
+func (m schemaMap) validateImmutable(diff *terraform.InstanceDiff) error {
+	keys := make([]string, 0, len(diff.Attributes))
+
+	for k := range diff.Attributes {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		attr := diff.Attributes[k]
+
+		if attr == nil || (attr.Old == attr.New && !attr.NewComputed && !attr.NewRemoved) {
+			continue
+		}
+
+		for _, schema := range addrToSchema(strings.Split(k, "."), m) {
+			if !schema.Immutable {
+				continue
+			}
+
+			return fmt.Errorf(
+				"%s: cannot be changed after the resource has been created. "+
+					"To apply this change, taint or replace the resource "+
+					"(e.g. terraform apply -replace) so that it is recreated.", k)
+		}
+	}
+
+	return nil
+}
+
+// withDefaultBlocks returns a copy of the configuration with the DefaultBlock
+// of each block omitted from it added, or the configuration itself if no
+// schema in the mapping has a DefaultBlock.
+func (m schemaMap) withDefaultBlocks(c *terraform.ResourceConfig) *terraform.ResourceConfig {
+	if c == nil || !m.hasDefaultBlocks() {
+		return c
+	}
+
+	c = c.DeepCopy()
+
+	if c.Config == nil {
+		c.Config = make(map[string]interface{})
+	}
+
+	if c.Raw == nil {
+		c.Raw = make(map[string]interface{})
+	}
+
+	m.addDefaultBlocks(c.Config)
+	m.addDefaultBlocks(c.Raw)
+
+	return c
+}
+
+// hasDefaultBlocks returns whether any schema in the mapping, including
+// nested blocks, has a DefaultBlock.
+func (m schemaMap) hasDefaultBlocks() bool {
+	for _, s := range m {
+		r, ok := s.Elem.(*Resource)
+
+		if !ok {
+			continue
+		}
+
+		if s.DefaultBlock != nil || schemaMap(r.SchemaMap()).hasDefaultBlocks() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addDefaultBlocks sets the DefaultBlock of each block omitted from the
+// configuration map, then does the same for any nested blocks.
+func (m schemaMap) addDefaultBlocks(raw map[string]interface{}) {
+	for k, s := range m {
+		r, ok := s.Elem.(*Resource)
+
+		if !ok || (s.Type != TypeList && s.Type != TypeSet) {
+			continue
+		}
+
+		v, ok := raw[k]
+
+		if blocks, isList := v.([]interface{}); s.DefaultBlock != nil && (!ok || v == nil || (isList && len(blocks) == 0)) {
+			block := make(map[string]interface{}, len(s.DefaultBlock))
+
+			for bk, bv := range s.DefaultBlock {
+				block[bk] = bv
+			}
+
+			v = []interface{}{block}
+			raw[k] = v
+		}
+
+		blocks, ok := v.([]interface{})
+
+		if !ok {
+			continue
+		}
+
+		for _, block := range blocks {
+			if block, ok := block.(map[string]interface{}); ok {
+				schemaMap(r.SchemaMap()).addDefaultBlocks(block)
+			}
+		}
+	}
+}
+
+// withDefaultFrom returns a copy of the configuration with the sibling value
+// of each attribute with DefaultFrom omitted from it added, or the
+// configuration itself if no schema in the mapping has a DefaultFrom.
+func (m schemaMap) withDefaultFrom(c *terraform.ResourceConfig) *terraform.ResourceConfig {
+	if c == nil || !m.hasDefaultFrom() {
+		return c
+	}
+
+	c = c.DeepCopy()
+
+	m.addDefaultFrom(c.Config)
+	m.addDefaultFrom(c.Raw)
+
+	return c
+}
+
+// hasDefaultFrom returns whether any schema in the mapping, including nested
+// blocks, has a DefaultFrom.
+func (m schemaMap) hasDefaultFrom() bool {
+	for _, s := range m {
+		if s.DefaultFrom != "" {
+			return true
+		}
+
+		if r, ok := s.Elem.(*Resource); ok && schemaMap(r.SchemaMap()).hasDefaultFrom() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addDefaultFrom sets the sibling value of each attribute with DefaultFrom
+// omitted from the configuration map, then does the same for any nested
+// blocks. Unknown sibling values are copied as is, so remain unknown.
+func (m schemaMap) addDefaultFrom(raw map[string]interface{}) {
+	if raw == nil {
+		return
+	}
+
+	for k, s := range m {
+		if s.DefaultFrom != "" {
+			if v, ok := raw[k]; !ok || v == nil {
+				if source, ok := raw[s.DefaultFrom]; ok && source != nil {
+					raw[k] = source
+				}
+			}
+
+			continue
+		}
+
+		r, ok := s.Elem.(*Resource)
+
+		if !ok || (s.Type != TypeList && s.Type != TypeSet) {
+			continue
+		}
+
+		blocks, ok := raw[k].([]interface{})
+
+		if !ok {
+			continue
+		}
+
+		for _, block := range blocks {
+			if block, ok := block.(map[string]interface{}); ok {
+				schemaMap(r.SchemaMap()).addDefaultFrom(block)
+			}
+		}
+	}
+}
+
 // Validate validates the configuration against this schema mapping.
 func (m schemaMap) Validate(c *terraform.ResourceConfig) diag.Diagnostics {
 	return m.validateObject("", m, c, cty.Path{})
@@ -874,6 +1424,36 @@ func (m schemaMap) internalValidate(topSchemaMap schemaMap, attrsOnly bool) erro
 			return fmt.Errorf("%s: Default cannot be set with Required", k)
 		}
 
+		if v.DefaultFrom != "" {
+			if v.Default != nil || v.DefaultFunc != nil || v.Required || v.Computed {
+				return fmt.Errorf("%s: DefaultFrom cannot be set with Default, DefaultFunc, Required, or Computed", k)
+			}
+
+			switch v.Type {
+			case TypeBool, TypeInt, TypeFloat, TypeString:
+			default:
+				return fmt.Errorf("%s: DefaultFrom is only valid for primitive types", k)
+			}
+
+			source, ok := m[v.DefaultFrom]
+
+			if !ok {
+				return fmt.Errorf("%s: DefaultFrom references unknown sibling attribute (%s)", k, v.DefaultFrom)
+			}
+
+			if v.DefaultFrom == k {
+				return fmt.Errorf("%s: DefaultFrom cannot reference self", k)
+			}
+
+			if source.Type != v.Type {
+				return fmt.Errorf("%s: DefaultFrom references attribute of a different type (%s)", k, v.DefaultFrom)
+			}
+
+			if source.DefaultFrom != "" {
+				return fmt.Errorf("%s: DefaultFrom references attribute which also has DefaultFrom (%s)", k, v.DefaultFrom)
+			}
+		}
+
 		if len(v.ComputedWhen) > 0 && !v.Computed {
 			return fmt.Errorf("%s: ComputedWhen can only be set with Computed", k)
 		}
@@ -886,35 +1466,136 @@ func (m schemaMap) internalValidate(topSchemaMap schemaMap, attrsOnly bool) erro
 			return fmt.Errorf("%s: ExactlyOneOf cannot be set with Required", k)
 		}
 
-		if len(v.AtLeastOneOf) > 0 && v.Required {
-			return fmt.Errorf("%s: AtLeastOneOf cannot be set with Required", k)
-		}
+		if len(v.AtLeastOneOf) > 0 && v.Required {
+			return fmt.Errorf("%s: AtLeastOneOf cannot be set with Required", k)
+		}
+
+		if len(v.AtMostOneOf) > 0 && v.Required {
+			return fmt.Errorf("%s: AtMostOneOf cannot be set with Required", k)
+		}
+
+		if len(v.ConflictsWith) > 0 {
+			err := checkKeysAgainstSchemaFlags(k, v.ConflictsWith, topSchemaMap, v, false)
+			if err != nil {
+				return fmt.Errorf("ConflictsWith: %+v", err)
+			}
+		}
+
+		if len(v.RequiredWith) > 0 {
+			err := checkKeysAgainstSchemaFlags(k, v.RequiredWith, topSchemaMap, v, true)
+			if err != nil {
+				return fmt.Errorf("RequiredWith: %+v", err)
+			}
+		}
+
+		if len(v.ExactlyOneOf) > 0 {
+			err := checkKeysAgainstSchemaFlags(k, v.ExactlyOneOf, topSchemaMap, v, true)
+			if err != nil {
+				return fmt.Errorf("ExactlyOneOf: %+v", err)
+			}
+		}
+
+		if len(v.AtLeastOneOf) > 0 {
+			err := checkKeysAgainstSchemaFlags(k, v.AtLeastOneOf, topSchemaMap, v, true)
+			if err != nil {
+				return fmt.Errorf("AtLeastOneOf: %+v", err)
+			}
+		}
+
+		if len(v.AtMostOneOf) > 0 {
+			err := checkKeysAgainstSchemaFlags(k, v.AtMostOneOf, topSchemaMap, v, true)
+			if err != nil {
+				return fmt.Errorf("AtMostOneOf: %+v", err)
+			}
+		}
+
+		if v.ReadTransformFunc != nil {
+			switch v.Type {
+			case TypeBool, TypeInt, TypeFloat, TypeString:
+			default:
+				return fmt.Errorf("%s: ReadTransformFunc is only valid for primitive types", k)
+			}
+		}
+
+		if len(v.PlanModifiers) > 0 {
+			switch v.Type {
+			case TypeBool, TypeInt, TypeFloat, TypeString:
+			default:
+				return fmt.Errorf("%s: PlanModifiers is only valid for primitive types", k)
+			}
+		}
+
+		if v.IgnoreServerAddedKeys && (v.Type != TypeMap || !v.Optional || !v.Computed) {
+			return fmt.Errorf("%s: IgnoreServerAddedKeys is only valid for Optional and Computed TypeMap", k)
+		}
+
+		if v.UpdateCost < 0 {
+			return fmt.Errorf("%s: UpdateCost cannot be negative", k)
+		}
+
+		if v.Immutable && v.ForceNew {
+			return fmt.Errorf("%s: Immutable cannot be set with ForceNew", k)
+		}
+
+		if v.WriteOnly {
+			if v.Computed {
+				return fmt.Errorf("%s: WriteOnly cannot be set with Computed", k)
+			}
+
+			if v.ForceNew || v.Immutable {
+				return fmt.Errorf("%s: WriteOnly cannot be set with ForceNew or Immutable", k)
+			}
+
+			switch v.Type {
+			case TypeBool, TypeInt, TypeFloat, TypeString:
+			default:
+				return fmt.Errorf("%s: WriteOnly is only valid for primitive types", k)
+			}
+
+			if topSchemaMap[k] != v {
+				return fmt.Errorf("%s: WriteOnly is only valid for top level attributes", k)
+			}
+		}
+
+		if v.SupersededBy != "" {
+			if v.Deprecated == "" {
+				return fmt.Errorf("%s: SupersededBy requires Deprecated", k)
+			}
 
-		if len(v.ConflictsWith) > 0 {
-			err := checkKeysAgainstSchemaFlags(k, v.ConflictsWith, topSchemaMap, v, false)
-			if err != nil {
-				return fmt.Errorf("ConflictsWith: %+v", err)
+			target := addrToSchema(strings.Split(v.SupersededBy, "."), topSchemaMap)
+
+			if len(target) == 0 {
+				return fmt.Errorf("%s: SupersededBy references unknown attribute (%s)", k, v.SupersededBy)
 			}
-		}
 
-		if len(v.RequiredWith) > 0 {
-			err := checkKeysAgainstSchemaFlags(k, v.RequiredWith, topSchemaMap, v, true)
-			if err != nil {
-				return fmt.Errorf("RequiredWith: %+v", err)
+			if target[len(target)-1] == v {
+				return fmt.Errorf("%s: SupersededBy cannot reference self (%s)", k, v.SupersededBy)
 			}
 		}
 
-		if len(v.ExactlyOneOf) > 0 {
-			err := checkKeysAgainstSchemaFlags(k, v.ExactlyOneOf, topSchemaMap, v, true)
-			if err != nil {
-				return fmt.Errorf("ExactlyOneOf: %+v", err)
+		if len(v.ComputedDependsOn) > 0 {
+			if !v.Computed {
+				return fmt.Errorf("%s: ComputedDependsOn requires Computed", k)
 			}
-		}
 
-		if len(v.AtLeastOneOf) > 0 {
-			err := checkKeysAgainstSchemaFlags(k, v.AtLeastOneOf, topSchemaMap, v, true)
-			if err != nil {
-				return fmt.Errorf("AtLeastOneOf: %+v", err)
+			if topSchemaMap[k] != v {
+				return fmt.Errorf("%s: ComputedDependsOn is only valid for top level attributes", k)
+			}
+
+			for _, dep := range v.ComputedDependsOn {
+				target, ok := topSchemaMap[dep]
+
+				if !ok {
+					return fmt.Errorf("%s: ComputedDependsOn references unknown attribute (%s)", k, dep)
+				}
+
+				if target == v {
+					return fmt.Errorf("%s: ComputedDependsOn cannot reference self (%s)", k, dep)
+				}
+
+				if !target.Computed {
+					return fmt.Errorf("%s: ComputedDependsOn references non-Computed attribute (%s)", k, dep)
+				}
 			}
 		}
 
@@ -935,8 +1616,22 @@ func (m schemaMap) internalValidate(topSchemaMap schemaMap, attrsOnly bool) erro
 				return fmt.Errorf("%s: Set can only be set for TypeSet", k)
 			}
 
+			if v.DefaultBlock != nil {
+				if err := validateDefaultBlock(k, v); err != nil {
+					return err
+				}
+			}
+
 			switch t := v.Elem.(type) {
 			case *Resource:
+				for _, w := range forceNewInSetWarnings(k, v) {
+					log.Printf("[WARN] %s", w)
+				}
+
+				for _, w := range computedInSetHashWarnings(k, v) {
+					log.Printf("[WARN] %s", w)
+				}
+
 				attrsOnly := attrsOnly || v.ConfigMode == SchemaConfigModeAttr
 
 				if err := schemaMap(t.SchemaMap()).internalValidate(topSchemaMap, attrsOnly); err != nil {
@@ -980,8 +1675,8 @@ func (m schemaMap) internalValidate(topSchemaMap schemaMap, attrsOnly bool) erro
 				return fmt.Errorf("%s: DefaultFunc is for configurable attributes,"+
 					"there's nothing to configure on computed-only field", k)
 			}
-			if v.DiffSuppressFunc != nil {
-				return fmt.Errorf("%s: DiffSuppressFunc is for suppressing differences"+
+			if v.ReadTransformFunc != nil {
+				return fmt.Errorf("%s: ReadTransformFunc is for comparing differences"+
 					" between config and state representation. "+
 					"There is no config for computed-only field, nothing to compare.", k)
 			}
@@ -989,10 +1684,22 @@ func (m schemaMap) internalValidate(topSchemaMap schemaMap, attrsOnly bool) erro
 				return fmt.Errorf("%s: ExactlyOneOf is for configurable attributes,"+
 					"there's nothing to configure on computed-only field", k)
 			}
+			if len(v.AtMostOneOf) > 0 {
+				return fmt.Errorf("%s: AtMostOneOf is for configurable attributes,"+
+					"there's nothing to configure on computed-only field", k)
+			}
 			if v.InputDefault != "" {
 				return fmt.Errorf("%s: InputDefault is for configurable attributes,"+
 					"there's nothing to configure on computed-only field", k)
 			}
+			if v.Immutable {
+				return fmt.Errorf("%s: Immutable is for configurable attributes,"+
+					"there's nothing to configure on computed-only field", k)
+			}
+			if v.UpdateCost != 0 {
+				return fmt.Errorf("%s: UpdateCost is for configurable attributes,"+
+					"there's nothing to configure on computed-only field", k)
+			}
 			if v.MaxItems > 0 {
 				return fmt.Errorf("%s: MaxItems is for configurable attributes,"+
 					"there's nothing to configure on computed-only field", k)
@@ -1094,6 +1801,128 @@ func checkKeysAgainstSchemaFlags(k string, keys []string, topSchemaMap schemaMap
 
 var validFieldNameRe = regexp.MustCompile("^[a-z0-9_]+$")
 
+// forceNewInSetWarnings returns warnings for attributes with ForceNew directly
+// within a TypeSet block which is not itself ForceNew. Any change to such a
+// set, including changes to sibling attributes without ForceNew and adding or
+// removing elements, will require replacement of the resource instance.
+func forceNewInSetWarnings(k string, v *Schema) []string {
+	if v.Type != TypeSet || v.ForceNew {
+		return nil
+	}
+
+	r, ok := v.Elem.(*Resource)
+	if !ok {
+		return nil
+	}
+
+	sm := r.SchemaMap()
+	keys := make([]string, 0, len(sm))
+
+	for nk := range sm {
+		keys = append(keys, nk)
+	}
+
+	sort.Strings(keys)
+
+	var warnings []string
+
+	for _, nk := range keys {
+		if !sm[nk].ForceNew {
+			continue
+		}
+
+		warnings = append(warnings, fmt.Sprintf(
+			"%s.%s: ForceNew within a TypeSet block requires replacement on any change to %s, "+
+				"including adding or removing elements and changing attributes without ForceNew; "+
+				"consider setting ForceNew on %s instead", k, nk, k, k))
+	}
+
+	return warnings
+}
+
+// computedInSetHashWarnings returns warnings for the computed attributes of a
+// configurable TypeSet block which participate in the default set hash.
+// SerializeResourceForHash includes Optional and Computed attributes, and
+// only includes computed-only attributes when every attribute of the block is
+// computed-only. In either case the hash changes as their values become
+// known, causing perpetual differences.
+func computedInSetHashWarnings(k string, v *Schema) []string {
+	if v.Type != TypeSet || v.Set != nil || !(v.Optional || v.Required) {
+		return nil
+	}
+
+	r, ok := v.Elem.(*Resource)
+	if !ok {
+		return nil
+	}
+
+	sm := r.SchemaMap()
+	keys := make([]string, 0, len(sm))
+	allComputed := true
+
+	for nk, ns := range sm {
+		if ns.Optional || ns.Required {
+			allComputed = false
+		}
+
+		keys = append(keys, nk)
+	}
+
+	sort.Strings(keys)
+
+	var warnings []string
+
+	for _, nk := range keys {
+		ns := sm[nk]
+
+		switch {
+		case allComputed:
+			warnings = append(warnings, fmt.Sprintf(
+				"%s.%s: Computed-only attribute participates in the default hash of TypeSet %s, "+
+					"which can change as its value becomes known and cause perpetual differences; "+
+					"consider providing a Set function for %s keyed on stable attributes", k, nk, k, k))
+		case ns.Optional && ns.Computed:
+			warnings = append(warnings, fmt.Sprintf(
+				"%s.%s: Optional and Computed attribute participates in the default hash of TypeSet %s, "+
+					"which can change as its value becomes known when not configured and cause perpetual differences; "+
+					"consider providing a Set function for %s keyed on stable attributes", k, nk, k, k))
+		}
+	}
+
+	return warnings
+}
+
+// validateDefaultBlock returns an error if the DefaultBlock of the schema is
+// unsupported, or is not valid against the Elem schema of the block.
+func validateDefaultBlock(k string, v *Schema) error {
+	r, ok := v.Elem.(*Resource)
+	if !ok {
+		return fmt.Errorf("%s: DefaultBlock is only valid for blocks", k)
+	}
+
+	if v.MaxItems != 1 {
+		return fmt.Errorf("%s: DefaultBlock requires MaxItems to be 1", k)
+	}
+
+	if v.Required || v.Computed {
+		return fmt.Errorf("%s: DefaultBlock cannot be set with Required or Computed", k)
+	}
+
+	var errs []string
+
+	for _, d := range schemaMap(r.SchemaMap()).Validate(terraform.NewResourceConfigRaw(v.DefaultBlock)) {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Summary)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: DefaultBlock is invalid: %s", k, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
 func isValidFieldName(name string) bool {
 	return validFieldNameRe.MatchString(name)
 }
@@ -1139,7 +1968,7 @@ func (m schemaMap) diff(
 	for attrK, attrV := range unsupressedDiff.Attributes {
 		switch rd := d.(type) {
 		case *ResourceData:
-			if schema.DiffSuppressFunc != nil && attrV != nil &&
+			if schema.DiffSuppressFunc != nil && attrV != nil && !(schema.Computed && !schema.Optional) &&
 				schema.DiffSuppressFunc(attrK, attrV.Old, attrV.New, rd) {
 				// If this attr diff is suppressed, we may still need it in the
 				// overall diff if it's contained within a set. Rather than
@@ -1289,6 +2118,95 @@ func (m schemaMap) diffList(
 	return nil
 }
 
+// configuredMapKeysMetaKey is the instance private state key which records,
+// for each IgnoreServerAddedKeys map by flatmap key, the map keys which were
+// configured when the instance was last applied.
+const configuredMapKeysMetaKey = "ignore_server_added_keys"
+
+// priorConfiguredMapKeys returns the keys of the map k which were configured
+// when the instance was last applied, as recorded by configuredMapKeys.
+func priorConfiguredMapKeys(d resourceDiffer, k string) map[string]bool {
+	var s *terraform.InstanceState
+
+	switch d := d.(type) {
+	case *ResourceData:
+		s = d.state
+	case *ResourceDiff:
+		s = d.state
+	}
+
+	if s == nil {
+		return nil
+	}
+
+	recorded, _ := s.Meta[configuredMapKeysMetaKey].(map[string]interface{})
+	configured := make(map[string]bool)
+
+	// The recorded keys are []interface{} once decoded from the private
+	// state JSON.
+	switch keys := recorded[k].(type) {
+	case []interface{}:
+		for _, key := range keys {
+			if key, ok := key.(string); ok {
+				configured[key] = true
+			}
+		}
+	case []string:
+		for _, key := range keys {
+			configured[key] = true
+		}
+	}
+
+	return configured
+}
+
+// configuredMapKeys adds the keys in the configuration of each
+// IgnoreServerAddedKeys map, including those within list blocks, to result
+// by the flatmap key of the map prefixed with prefix.
+func (m schemaMap) configuredMapKeys(prefix string, config cty.Value, result map[string]interface{}) {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() {
+		return
+	}
+
+	for k, schema := range m {
+		if !config.Type().HasAttribute(k) {
+			continue
+		}
+
+		v := config.GetAttr(k)
+
+		if v.IsNull() || !v.IsKnown() {
+			continue
+		}
+
+		if schema.IgnoreServerAddedKeys && v.Type().IsMapType() {
+			keys := make([]string, 0, v.LengthInt())
+
+			for it := v.ElementIterator(); it.Next(); {
+				key, _ := it.Element()
+				keys = append(keys, key.AsString())
+			}
+
+			result[prefix+k] = keys
+
+			continue
+		}
+
+		r, ok := schema.Elem.(*Resource)
+
+		if !ok || schema.Type != TypeList || !v.CanIterateElements() {
+			continue
+		}
+
+		for it := v.ElementIterator(); it.Next(); {
+			i, e := it.Element()
+			idx, _ := i.AsBigFloat().Int64()
+
+			schemaMap(r.SchemaMap()).configuredMapKeys(fmt.Sprintf("%s%s.%d.", prefix, k, idx), e, result)
+		}
+	}
+}
+
 func (m schemaMap) diffMap(
 	k string,
 	schema *Schema,
@@ -1314,6 +2232,22 @@ func (m schemaMap) diffMap(
 	delete(configMap, "%")
 	delete(stateMap, "%")
 
+	// Retain keys which are only in the state, so they are not removed,
+	// unless they were configured when the instance was last applied.
+	if schema.IgnoreServerAddedKeys && n != nil {
+		configured := priorConfiguredMapKeys(d, k)
+
+		for k, v := range stateMap {
+			if _, ok := configMap[k]; !ok && !configured[k] {
+				if configMap == nil {
+					configMap = make(map[string]string)
+				}
+
+				configMap[k] = v
+			}
+		}
+	}
+
 	// Check if the number of elements has changed.
 	oldLen, newLen := len(stateMap), len(configMap)
 	changed := oldLen != newLen
@@ -1559,6 +2493,14 @@ func (m schemaMap) diffString(
 		return fmt.Errorf("%s: %s", k, err)
 	}
 
+	if schema.ReadTransformFunc != nil && o != nil && n != nil && !all && !computed {
+		// The prior state value only differs from the configuration in the
+		// form returned by Read, so there is nothing to change.
+		if ts, ok := schema.readTransform(o); ok && ts == ns {
+			return nil
+		}
+	}
+
 	if os == ns && !all && !computed {
 		// They're the same value. If there old value is not blank or we
 		// have an ID, then return right away since we're already setup.
@@ -1597,11 +2539,30 @@ func (m schemaMap) diffString(
 	return nil
 }
 
+// readTransform returns the string form of the given prior state value after
+// applying ReadTransformFunc, or false if the transformed value is null or
+// unknown.
+func (s *Schema) readTransform(v interface{}) (string, bool) {
+	tv := s.ReadTransformFunc(hcl2shim.HCL2ValueFromConfigValue(v))
+
+	if tv.IsNull() || !tv.IsWhollyKnown() {
+		return "", false
+	}
+
+	var ts string
+
+	if err := mapstructure.WeakDecode(hcl2shim.ConfigValueFromHCL2(tv), &ts); err != nil {
+		return "", false
+	}
+
+	return ts, true
+}
+
 // handleDiffSuppressOnRefresh visits each of the attributes set in "new" and,
-// if the corresponding schema sets both DiffSuppressFunc and
-// DiffSuppressOnRefresh, checks whether the new value is materially different
-// than the old and if not it overwrites the new value with the old one,
-// in-place.
+// if the corresponding schema sets DiffSuppressFunc and either
+// DiffSuppressOnRefresh or is computed-only, checks whether the new value is
+// materially different than the old and if not it overwrites the new value
+// with the old one, in-place.
 func (m schemaMap) handleDiffSuppressOnRefresh(ctx context.Context, oldState, newState *terraform.InstanceState) {
 	if newState == nil || oldState == nil {
 		return // nothing to do, then
@@ -1627,7 +2588,12 @@ func (m schemaMap) handleDiffSuppressOnRefresh(ctx context.Context, oldState, ne
 			continue // no schema? weird, but not our responsibility to handle
 		}
 		schema := schemaList[len(schemaList)-1]
-		if !schema.DiffSuppressOnRefresh || schema.DiffSuppressFunc == nil {
+		if schema.DiffSuppressFunc == nil {
+			continue // not relevant
+		}
+		// Computed-only attributes have no configuration to compare during
+		// planning, so their DiffSuppressFunc always applies on refresh.
+		if !schema.DiffSuppressOnRefresh && !(schema.Computed && !schema.Optional) {
 			continue // not relevant
 		}
 
@@ -1656,6 +2622,18 @@ func (m schemaMap) validate(
 	schema *Schema,
 	c *terraform.ResourceConfig,
 	path cty.Path) diag.Diagnostics {
+	return m.validateAttribute(k, schema, c, path, false)
+}
+
+// validateAttribute validates the attribute within the configuration. Unless
+// all is true, it returns at the first failed constraint, such as
+// ConflictsWith, without validating the value itself.
+func (m schemaMap) validateAttribute(
+	k string,
+	schema *Schema,
+	c *terraform.ResourceConfig,
+	path cty.Path,
+	all bool) diag.Diagnostics {
 
 	var diags diag.Diagnostics
 
@@ -1679,22 +2657,44 @@ func (m schemaMap) validate(
 
 	err := validateExactlyOneAttribute(k, schema, c)
 	if err != nil {
-		return append(diags, diag.Diagnostic{
+		diags = append(diags, diag.Diagnostic{
 			Severity:      diag.Error,
 			Summary:       "Invalid combination of arguments",
 			Detail:        err.Error(),
 			AttributePath: path,
 		})
+
+		if !all {
+			return diags
+		}
 	}
 
 	err = validateAtLeastOneAttribute(k, schema, c)
 	if err != nil {
-		return append(diags, diag.Diagnostic{
+		diags = append(diags, diag.Diagnostic{
 			Severity:      diag.Error,
 			Summary:       "Missing required argument",
 			Detail:        err.Error(),
 			AttributePath: path,
 		})
+
+		if !all {
+			return diags
+		}
+	}
+
+	err = validateAtMostOneAttribute(k, schema, c)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid combination of arguments",
+			Detail:        err.Error(),
+			AttributePath: path,
+		})
+
+		if !all {
+			return diags
+		}
 	}
 
 	if !ok {
@@ -1721,12 +2721,16 @@ func (m schemaMap) validate(
 
 	err = validateRequiredWithAttribute(k, schema, c)
 	if err != nil {
-		return append(diags, diag.Diagnostic{
+		diags = append(diags, diag.Diagnostic{
 			Severity:      diag.Error,
 			Summary:       "Missing required argument",
 			Detail:        err.Error(),
 			AttributePath: path,
 		})
+
+		if !all {
+			return diags
+		}
 	}
 
 	// If the value is unknown then we can't validate it yet.
@@ -1735,20 +2739,24 @@ func (m schemaMap) validate(
 	// The SDK has to allow the unknown value through initially, so that
 	// Required fields set via an interpolated value are accepted.
 	if !isWhollyKnown(raw) {
-		return nil
+		return diags
 	}
 
 	err = validateConflictingAttributes(k, schema, c)
 	if err != nil {
-		return append(diags, diag.Diagnostic{
+		diags = append(diags, diag.Diagnostic{
 			Severity:      diag.Error,
 			Summary:       "Conflicting configuration arguments",
 			Detail:        err.Error(),
 			AttributePath: path,
 		})
+
+		if !all {
+			return diags
+		}
 	}
 
-	return m.validateType(k, raw, schema, c, path)
+	return append(diags, m.validateType(k, raw, schema, c, path)...)
 }
 
 // isWhollyKnown returns false if the argument contains an UnknownVariableValue
@@ -1891,6 +2899,37 @@ func validateAtLeastOneAttribute(
 	return fmt.Errorf("%q: one of `%s` must be specified", k, strings.Join(allKeys, ","))
 }
 
+func validateAtMostOneAttribute(
+	k string,
+	schema *Schema,
+	c *terraform.ResourceConfig) error {
+
+	if len(schema.AtMostOneOf) == 0 {
+		return nil
+	}
+
+	allKeys := removeDuplicates(append(schema.AtMostOneOf, k))
+	sort.Strings(allKeys)
+	specified := make([]string, 0)
+	for _, atMostOneOfKey := range allKeys {
+		if c.IsComputed(atMostOneOfKey) {
+			// An unknown value might become unset (null) once known, so
+			// we must defer validation until it's known.
+			continue
+		}
+
+		if _, ok := c.Get(atMostOneOfKey); ok {
+			specified = append(specified, atMostOneOfKey)
+		}
+	}
+
+	if len(specified) > 1 {
+		return fmt.Errorf("%q: only one of `%s` can be specified, but `%s` were specified.", k, strings.Join(allKeys, ","), strings.Join(specified, ","))
+	}
+
+	return nil
+}
+
 func (m schemaMap) validateList(
 	k string,
 	raw interface{},
@@ -2206,6 +3245,50 @@ func (m schemaMap) validateObject(
 	return diags
 }
 
+// collectValidationDiagnostics validates every attribute of the mapping
+// within the configuration, including those of nested blocks, reporting all
+// failed constraints of each attribute rather than only the first. The
+// result can contain duplicate diagnostics.
+func (m schemaMap) collectValidationDiagnostics(
+	k string,
+	schema map[string]*Schema,
+	c *terraform.ResourceConfig,
+	path cty.Path) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	for subK, s := range schema {
+		key := subK
+		if k != "" {
+			key = fmt.Sprintf("%s.%s", k, subK)
+		}
+		p := append(path.Copy(), cty.GetAttrStep{Name: subK})
+
+		diags = append(diags, m.validateAttribute(key, s, c, p, true)...)
+
+		r, ok := s.Elem.(*Resource)
+		if !ok {
+			continue
+		}
+
+		raw, _ := c.Get(key)
+		raws, ok := raw.([]interface{})
+		if !ok {
+			continue
+		}
+
+		for i := range raws {
+			diags = append(diags, m.collectValidationDiagnostics(
+				fmt.Sprintf("%s.%d", key, i),
+				r.SchemaMap(),
+				c,
+				append(p.Copy(), cty.IndexStep{Key: cty.NumberIntVal(int64(i))}))...)
+		}
+	}
+
+	return diags
+}
+
 func (m schemaMap) validatePrimitive(
 	k string,
 	raw interface{},
@@ -2342,10 +3425,16 @@ func (m schemaMap) validateType(
 	}
 
 	if schema.Deprecated != "" {
+		detail := schema.Deprecated
+
+		if schema.SupersededBy != "" {
+			detail += "\n\nSuperseded by: " + schema.SupersededBy
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity:      diag.Warning,
 			Summary:       "Argument is deprecated",
-			Detail:        schema.Deprecated,
+			Detail:        detail,
 			AttributePath: path,
 		})
 	}
@@ -6,6 +6,7 @@ package schema
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -52,6 +53,98 @@ func TestEnvDefaultFunc(t *testing.T) {
 	}
 }
 
+func TestEnvDefaultFuncTyped(t *testing.T) {
+	key := "TF_TEST_ENV_DEFAULT_FUNC_TYPED"
+
+	cases := map[string]struct {
+		Env      string
+		Type     ValueType
+		Default  interface{}
+		Expected interface{}
+		Err      bool
+	}{
+		"bool": {
+			Type:     TypeBool,
+			Env:      "true",
+			Default:  false,
+			Expected: true,
+		},
+		"bool malformed": {
+			Type:    TypeBool,
+			Env:     "maybe",
+			Default: false,
+			Err:     true,
+		},
+		"int": {
+			Type:     TypeInt,
+			Env:      "42",
+			Default:  0,
+			Expected: 42,
+		},
+		"int malformed": {
+			Type:    TypeInt,
+			Env:     "4.2",
+			Default: 0,
+			Err:     true,
+		},
+		"float": {
+			Type:     TypeFloat,
+			Env:      "4.2",
+			Default:  0.0,
+			Expected: 4.2,
+		},
+		"float malformed": {
+			Type:    TypeFloat,
+			Env:     "four",
+			Default: 0.0,
+			Err:     true,
+		},
+		"string": {
+			Type:     TypeString,
+			Env:      "foo",
+			Default:  "bar",
+			Expected: "foo",
+		},
+		"unset": {
+			Type:     TypeBool,
+			Default:  true,
+			Expected: true,
+		},
+		"nil default": {
+			Env:      "42",
+			Type:     TypeInt,
+			Expected: 42,
+		},
+		"nil default unset": {
+			Type:     TypeInt,
+			Expected: nil,
+		},
+		"unsupported type": {
+			Env:  "foo",
+			Type: TypeList,
+			Err:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(key, tc.Env)
+
+			actual, err := EnvDefaultFuncTyped(key, tc.Type, tc.Default)()
+			if err != nil != tc.Err {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("bad: %#v", actual)
+			}
+		})
+	}
+}
+
 func TestMultiEnvDefaultFunc(t *testing.T) {
 	keys := []string{
 		"TF_TEST_MULTI_ENV_DEFAULT_FUNC1",
@@ -3102,243 +3195,1722 @@ func TestSchemaMap_Diff(t *testing.T) {
 				},
 			},
 		},
-	}
 
-	for i, tc := range cases {
-		t.Run(fmt.Sprintf("%d-%s", i, tc.Name), func(t *testing.T) {
-			c := terraform.NewResourceConfigRaw(tc.Config)
+		{
+			Name: "IgnoreServerAddedKeys, unchanged user key",
+			Schema: map[string]*Schema{
+				"tags": {
+					Type:                  TypeMap,
+					Optional:              true,
+					Computed:              true,
+					IgnoreServerAddedKeys: true,
+					Elem:                  &Schema{Type: TypeString},
+				},
+			},
 
-			d, err := schemaMap(tc.Schema).Diff(context.Background(), tc.State, c, tc.CustomizeDiff, nil, true)
-			if err != nil != tc.Err {
-				t.Fatalf("err: %s", err)
-			}
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"tags.%":      "2",
+					"tags.user":   "a",
+					"tags.system": "x",
+				},
+			},
 
-			if !reflect.DeepEqual(tc.Diff, d) {
-				t.Fatalf("expected:\n%#v\n\ngot:\n%#v", tc.Diff, d)
-			}
-		})
-	}
-}
+			Config: map[string]interface{}{
+				"tags": map[string]interface{}{
+					"user": "a",
+				},
+			},
 
-func TestSchemaMap_InternalValidate(t *testing.T) {
-	cases := map[string]struct {
-		In  map[string]*Schema
-		Err bool
-	}{
-		"nothing": {
-			nil,
-			false,
+			Diff: nil,
 		},
 
-		"Both optional and required": {
-			map[string]*Schema{
-				"foo": {
-					Type:     TypeInt,
-					Optional: true,
-					Required: true,
+		{
+			Name: "IgnoreServerAddedKeys, update user key",
+			Schema: map[string]*Schema{
+				"tags": {
+					Type:                  TypeMap,
+					Optional:              true,
+					Computed:              true,
+					IgnoreServerAddedKeys: true,
+					Elem:                  &Schema{Type: TypeString},
 				},
 			},
-			true,
-		},
 
-		"No optional and no required": {
-			map[string]*Schema{
-				"foo": {
-					Type: TypeInt,
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"tags.%":      "2",
+					"tags.user":   "a",
+					"tags.system": "x",
 				},
 			},
-			true,
-		},
 
-		"Missing Type": {
-			map[string]*Schema{
-				"foo": {
-					Required: true,
+			Config: map[string]interface{}{
+				"tags": map[string]interface{}{
+					"user": "b",
 				},
 			},
-			true,
-		},
 
-		"Required but computed": {
-			map[string]*Schema{
-				"foo": {
-					Type:     TypeInt,
-					Required: true,
-					Computed: true,
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"tags.user": {
+						Old: "a",
+						New: "b",
+					},
 				},
 			},
-			true,
 		},
 
-		"Looks good": {
-			map[string]*Schema{
-				"foo": {
-					Type:     TypeString,
-					Required: true,
+		{
+			Name: "IgnoreServerAddedKeys, add user key",
+			Schema: map[string]*Schema{
+				"tags": {
+					Type:                  TypeMap,
+					Optional:              true,
+					Computed:              true,
+					IgnoreServerAddedKeys: true,
+					Elem:                  &Schema{Type: TypeString},
 				},
 			},
-			false,
-		},
 
-		"Computed but has default": {
-			map[string]*Schema{
-				"foo": {
-					Type:     TypeInt,
-					Optional: true,
-					Computed: true,
-					Default:  "foo",
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"tags.%":      "2",
+					"tags.user":   "a",
+					"tags.system": "x",
 				},
 			},
-			true,
-		},
 
-		"Required but has default": {
-			map[string]*Schema{
-				"foo": {
-					Type:     TypeInt,
-					Optional: true,
-					Required: true,
-					Default:  "foo",
+			Config: map[string]interface{}{
+				"tags": map[string]interface{}{
+					"user":  "a",
+					"other": "c",
 				},
 			},
-			true,
-		},
 
-		"List element not set": {
-			map[string]*Schema{
-				"foo": {
-					Type: TypeList,
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"tags.%": {
+						Old: "2",
+						New: "3",
+					},
+					"tags.other": {
+						Old: "",
+						New: "c",
+					},
 				},
 			},
-			true,
 		},
 
-		"List default": {
-			map[string]*Schema{
-				"foo": {
-					Type:    TypeList,
-					Elem:    &Schema{Type: TypeInt},
-					Default: "foo",
+		{
+			Name: "IgnoreServerAddedKeys, remove unrecorded user key is retained",
+			Schema: map[string]*Schema{
+				"tags": {
+					Type:                  TypeMap,
+					Optional:              true,
+					Computed:              true,
+					IgnoreServerAddedKeys: true,
+					Elem:                  &Schema{Type: TypeString},
 				},
 			},
-			true,
-		},
 
-		"List element computed": {
-			map[string]*Schema{
-				"foo": {
-					Type:     TypeList,
-					Optional: true,
-					Elem: &Schema{
-						Type:     TypeInt,
-						Computed: true,
-					},
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"tags.%":      "2",
+					"tags.user":   "a",
+					"tags.system": "x",
 				},
 			},
-			true,
+
+			Config: map[string]interface{}{
+				"tags": map[string]interface{}{},
+			},
+
+			Diff: nil,
 		},
 
-		"List element with Set set": {
-			map[string]*Schema{
-				"foo": {
-					Type:     TypeList,
-					Elem:     &Schema{Type: TypeInt},
-					Set:      func(interface{}) int { return 0 },
-					Optional: true,
+		{
+			Name: "IgnoreServerAddedKeys, remove configured user key",
+			Schema: map[string]*Schema{
+				"tags": {
+					Type:                  TypeMap,
+					Optional:              true,
+					Computed:              true,
+					IgnoreServerAddedKeys: true,
+					Elem:                  &Schema{Type: TypeString},
 				},
 			},
-			true,
-		},
 
-		"Set element with no Set set": {
-			map[string]*Schema{
-				"foo": {
-					Type:     TypeSet,
-					Elem:     &Schema{Type: TypeInt},
-					Optional: true,
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"tags.%":      "2",
+					"tags.user":   "a",
+					"tags.system": "x",
+				},
+				Meta: map[string]interface{}{
+					configuredMapKeysMetaKey: map[string]interface{}{
+						"tags": []interface{}{"user"},
+					},
 				},
 			},
-			false,
-		},
 
-		"Required but computedWhen": {
-			map[string]*Schema{
-				"foo": {
-					Type:         TypeInt,
-					Required:     true,
-					ComputedWhen: []string{"foo"},
+			Config: map[string]interface{}{
+				"tags": map[string]interface{}{},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"tags.%": {
+						Old: "2",
+						New: "1",
+					},
+					"tags.user": {
+						Old:        "a",
+						New:        "",
+						NewRemoved: true,
+					},
 				},
 			},
-			true,
 		},
 
-		"Conflicting attributes cannot be required": {
-			map[string]*Schema{
-				"blacklist": {
-					Type:     TypeBool,
-					Required: true,
-				},
-				"whitelist": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"blacklist"},
+		{
+			Name: "IgnoreServerAddedKeys, update server key",
+			Schema: map[string]*Schema{
+				"tags": {
+					Type:                  TypeMap,
+					Optional:              true,
+					Computed:              true,
+					IgnoreServerAddedKeys: true,
+					Elem:                  &Schema{Type: TypeString},
 				},
 			},
-			true,
-		},
 
-		"Attribute with conflicts cannot be required": {
-			map[string]*Schema{
-				"whitelist": {
-					Type:          TypeBool,
-					Required:      true,
-					ConflictsWith: []string{"blacklist"},
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"tags.%":      "2",
+					"tags.user":   "a",
+					"tags.system": "x",
 				},
 			},
-			true,
-		},
 
-		"ConflictsWith cannot be used w/ ComputedWhen": {
-			map[string]*Schema{
-				"blacklist": {
-					Type:         TypeBool,
-					ComputedWhen: []string{"foor"},
+			Config: map[string]interface{}{
+				"tags": map[string]interface{}{
+					"user":   "a",
+					"system": "y",
 				},
-				"whitelist": {
-					Type:          TypeBool,
-					Required:      true,
-					ConflictsWith: []string{"blacklist"},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"tags.system": {
+						Old: "x",
+						New: "y",
+					},
 				},
 			},
-			true,
 		},
 
-		"AtLeastOneOf list index syntax with self reference": {
-			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeList,
-					Optional: true,
-					MaxItems: 1,
+		{
+			Name: "IgnoreServerAddedKeys, server key removed remotely",
+			Schema: map[string]*Schema{
+				"tags": {
+					Type:                  TypeMap,
+					Optional:              true,
+					Computed:              true,
+					IgnoreServerAddedKeys: true,
+					Elem:                  &Schema{Type: TypeString},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":        "id",
+					"tags.%":    "1",
+					"tags.user": "a",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"tags": map[string]interface{}{
+					"user": "a",
+				},
+			},
+
+			Diff: nil,
+		},
+
+		{
+			Name: "Immutable attribute on create",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:      TypeString,
+					Required:  true,
+					Immutable: true,
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"name": "a",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old: "",
+						New: "a",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "Immutable attribute unchanged, other attribute updated",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:      TypeString,
+					Required:  true,
+					Immutable: true,
+				},
+				"zone": {
+					Type:     TypeString,
+					Optional: true,
+					ForceNew: true,
+				},
+				"description": {
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"name":        "a",
+					"zone":        "z1",
+					"description": "x",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name":        "a",
+				"zone":        "z1",
+				"description": "y",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"description": {
+						Old: "x",
+						New: "y",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "Immutable attribute changed after create",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:      TypeString,
+					Required:  true,
+					Immutable: true,
+				},
+				"zone": {
+					Type:     TypeString,
+					Optional: true,
+					ForceNew: true,
+				},
+				"description": {
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"name":        "a",
+					"zone":        "z1",
+					"description": "x",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name":        "b",
+				"zone":        "z1",
+				"description": "x",
+			},
+
+			Err: true,
+		},
+
+		{
+			Name: "Immutable attribute changed with replacement",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:      TypeString,
+					Required:  true,
+					Immutable: true,
+				},
+				"zone": {
+					Type:     TypeString,
+					Optional: true,
+					ForceNew: true,
+				},
+				"description": {
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"name":        "a",
+					"zone":        "z1",
+					"description": "x",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name":        "b",
+				"zone":        "z2",
+				"description": "x",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old: "a",
+						New: "b",
+					},
+					"zone": {
+						Old:         "z1",
+						New:         "z2",
+						RequiresNew: true,
+					},
+					"description": {
+						Old: "x",
+						New: "x",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "Immutable block nested attribute changed after create",
+			Schema: map[string]*Schema{
+				"settings": {
+					Type:      TypeList,
+					Optional:  true,
+					MaxItems:  1,
+					Immutable: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"size": {
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":              "id",
+					"settings.#":      "1",
+					"settings.0.size": "1",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"settings": []interface{}{
+					map[string]interface{}{
+						"size": 2,
+					},
+				},
+			},
+
+			Err: true,
+		},
+
+		{
+			Name: "Set with nested ForceNew attribute, changing sibling attribute requires new",
+			Schema: map[string]*Schema{
+				"rule": {
+					Type:     TypeSet,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": {
+								Type:     TypeString,
+								Required: true,
+								ForceNew: true,
+							},
+							"description": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":                          "id",
+					"rule.#":                      "1",
+					"rule.4022840897.name":        "a",
+					"rule.4022840897.description": "x",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"rule": []interface{}{
+					map[string]interface{}{
+						"name":        "a",
+						"description": "y",
+					},
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"rule.#": {
+						Old: "1",
+						New: "1",
+					},
+					"rule.4022840897.name": {
+						Old:         "a",
+						New:         "",
+						NewRemoved:  true,
+						RequiresNew: true,
+					},
+					"rule.4022840897.description": {
+						Old:        "x",
+						New:        "",
+						NewRemoved: true,
+					},
+					"rule.4173117954.name": {
+						Old:         "",
+						New:         "a",
+						RequiresNew: true,
+					},
+					"rule.4173117954.description": {
+						Old: "",
+						New: "y",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "Set with nested ForceNew attribute, removing element requires new",
+			Schema: map[string]*Schema{
+				"rule": {
+					Type:     TypeSet,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": {
+								Type:     TypeString,
+								Required: true,
+								ForceNew: true,
+							},
+							"description": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":                          "id",
+					"rule.#":                      "1",
+					"rule.4022840897.name":        "a",
+					"rule.4022840897.description": "x",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"rule": []interface{}{},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"rule.#": {
+						Old: "1",
+						New: "0",
+					},
+					"rule.4022840897.name": {
+						Old:         "a",
+						New:         "",
+						NewRemoved:  true,
+						RequiresNew: true,
+					},
+					"rule.4022840897.description": {
+						Old:        "x",
+						New:        "",
+						NewRemoved: true,
+					},
+				},
+			},
+		},
+
+		{
+			Name: "ReadTransformFunc equal after transform",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:              TypeString,
+					Optional:          true,
+					ReadTransformFunc: testReadTransformLower,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"name": "EXAMPLE",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "example",
+			},
+
+			Diff: nil,
+		},
+
+		{
+			Name: "ReadTransformFunc different after transform",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:              TypeString,
+					Optional:          true,
+					ReadTransformFunc: testReadTransformLower,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"name": "EXAMPLE",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "other",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old: "EXAMPLE",
+						New: "other",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "ReadTransformFunc int",
+			Schema: map[string]*Schema{
+				"port": {
+					Type:     TypeInt,
+					Optional: true,
+					ReadTransformFunc: func(v cty.Value) cty.Value {
+						// Treat the remote default port 0 as 80.
+						if v.Equals(cty.NumberIntVal(0)).True() {
+							return cty.NumberIntVal(80)
+						}
+						return v
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"port": "0",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"port": 80,
+			},
+
+			Diff: nil,
+		},
+
+		{
+			Name: "DefaultBlock omitted",
+			Schema: map[string]*Schema{
+				"settings": {
+					Type:         TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					DefaultBlock: map[string]interface{}{"mode": "auto"},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"enabled": {
+								Type:     TypeBool,
+								Optional: true,
+								Default:  true,
+							},
+							"mode": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"settings.#": {
+						Old: "0",
+						New: "1",
+					},
+					"settings.0.enabled": {
+						Old: "",
+						New: "true",
+					},
+					"settings.0.mode": {
+						Old: "",
+						New: "auto",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "DefaultBlock provided",
+			Schema: map[string]*Schema{
+				"settings": {
+					Type:         TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					DefaultBlock: map[string]interface{}{"mode": "auto"},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"enabled": {
+								Type:     TypeBool,
+								Optional: true,
+								Default:  true,
+							},
+							"mode": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"settings": []interface{}{
+					map[string]interface{}{
+						"enabled": false,
+					},
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"settings.#": {
+						Old: "0",
+						New: "1",
+					},
+					"settings.0.enabled": {
+						Old: "",
+						New: "false",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "DefaultBlock omitted with default in state",
+			Schema: map[string]*Schema{
+				"settings": {
+					Type:         TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					DefaultBlock: map[string]interface{}{"mode": "auto"},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"enabled": {
+								Type:     TypeBool,
+								Optional: true,
+								Default:  true,
+							},
+							"mode": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"settings.#":         "1",
+					"settings.0.enabled": "true",
+					"settings.0.mode":    "auto",
+				},
+			},
+
+			Config: map[string]interface{}{},
+
+			Diff: nil,
+		},
+
+		{
+			Name: "DefaultBlock nested in omitted DefaultBlock",
+			Schema: map[string]*Schema{
+				"outer": {
+					Type:         TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					DefaultBlock: map[string]interface{}{},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"inner": {
+								Type:         TypeSet,
+								Optional:     true,
+								MaxItems:     1,
+								DefaultBlock: map[string]interface{}{"mode": "auto"},
+								Elem: &Resource{
+									Schema: map[string]*Schema{
+										"mode": {
+											Type:     TypeString,
+											Optional: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"outer.#": {
+						Old: "0",
+						New: "1",
+					},
+					"outer.0.inner.#": {
+						Old: "0",
+						New: "1",
+					},
+					"outer.0.inner.2493649048.mode": {
+						Old: "",
+						New: "auto",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "DefaultFrom set source",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "name",
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"name": "foo",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old: "",
+						New: "foo",
+					},
+					"description": {
+						Old: "",
+						New: "foo",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "DefaultFrom unset source",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "name",
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id": "id",
+				},
+			},
+
+			Config: map[string]interface{}{},
+
+			Diff: nil,
+		},
+
+		{
+			Name: "DefaultFrom unknown source",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "name",
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"name": hcl2shim.UnknownVariableValue,
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old:         "",
+						New:         hcl2shim.UnknownVariableValue,
+						NewComputed: true,
+					},
+					"description": {
+						Old:         "",
+						New:         hcl2shim.UnknownVariableValue,
+						NewComputed: true,
+					},
+				},
+			},
+		},
+
+		{
+			Name: "DefaultFrom configured",
+			Schema: map[string]*Schema{
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "name",
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":          "id",
+					"name":        "foo",
+					"description": "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name":        "foo",
+				"description": "bar",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"description": {
+						Old: "foo",
+						New: "bar",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "DefaultFrom nested",
+			Schema: map[string]*Schema{
+				"rule": {
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": {
+								Type:     TypeString,
+								Optional: true,
+							},
+							"description": {
+								Type:        TypeString,
+								Optional:    true,
+								DefaultFrom: "name",
+							},
+						},
+					},
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"rule": []interface{}{
+					map[string]interface{}{
+						"name": "foo",
+					},
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"rule.#": {
+						Old: "0",
+						New: "1",
+					},
+					"rule.0.name": {
+						Old: "",
+						New: "foo",
+					},
+					"rule.0.description": {
+						Old: "",
+						New: "foo",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "WriteOnly without other changes",
+			Schema: map[string]*Schema{
+				"password": {
+					Type:      TypeString,
+					Optional:  true,
+					WriteOnly: true,
+				},
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":   "id",
+					"name": "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"password": "secret",
+				"name":     "foo",
+			},
+
+			Diff: nil,
+		},
+
+		{
+			Name: "WriteOnly with other changes",
+			Schema: map[string]*Schema{
+				"password": {
+					Type:      TypeString,
+					Optional:  true,
+					WriteOnly: true,
+				},
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":   "id",
+					"name": "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"password": "secret",
+				"name":     "bar",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"password": {
+						Old: "",
+						New: "secret",
+					},
+					"name": {
+						Old: "foo",
+						New: "bar",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "WriteOnly create",
+			Schema: map[string]*Schema{
+				"password": {
+					Type:      TypeString,
+					Optional:  true,
+					WriteOnly: true,
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"password": "secret",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"password": {
+						Old: "",
+						New: "secret",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "ComputedDependsOn chained",
+			Schema: map[string]*Schema{
+				"version": {
+					Type:     TypeString,
+					Computed: true,
+				},
+				"arn": {
+					Type:              TypeString,
+					Computed:          true,
+					ComputedDependsOn: []string{"version"},
+				},
+				"url": {
+					Type:              TypeString,
+					Computed:          true,
+					ComputedDependsOn: []string{"arn"},
+				},
+				"aliases": {
+					Type:              TypeList,
+					Computed:          true,
+					ComputedDependsOn: []string{"url"},
+					Elem:              &Schema{Type: TypeString},
+				},
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":        "id",
+					"version":   "1",
+					"arn":       "arn:1",
+					"url":       "https://example.com/1",
+					"aliases.#": "1",
+					"aliases.0": "https://alias.example.com/1",
+					"name":      "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "bar",
+			},
+
+			CustomizeDiff: func(_ context.Context, d *ResourceDiff, meta interface{}) error {
+				if d.HasChange("name") {
+					return d.SetNewComputed("version")
+				}
+				return nil
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old: "foo",
+						New: "bar",
+					},
+					"version": {
+						Old:         "1",
+						NewComputed: true,
+					},
+					"arn": {
+						Old:         "arn:1",
+						NewComputed: true,
+					},
+					"url": {
+						Old:         "https://example.com/1",
+						NewComputed: true,
+					},
+					"aliases.#": {
+						Old:         "1",
+						NewComputed: true,
+					},
+				},
+			},
+		},
+
+		{
+			Name: "ComputedDependsOn dependency known",
+			Schema: map[string]*Schema{
+				"version": {
+					Type:     TypeString,
+					Computed: true,
+				},
+				"arn": {
+					Type:              TypeString,
+					Computed:          true,
+					ComputedDependsOn: []string{"version"},
+				},
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":      "id",
+					"version": "1",
+					"arn":     "arn:1",
+					"name":    "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "bar",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": {
+						Old: "foo",
+						New: "bar",
+					},
+				},
+			},
+		},
+
+		{
+			Name: "ComputedDependsOn configured",
+			Schema: map[string]*Schema{
+				"version": {
+					Type:     TypeString,
+					Computed: true,
+				},
+				"arn": {
+					Type:              TypeString,
+					Optional:          true,
+					Computed:          true,
+					ComputedDependsOn: []string{"version"},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":      "id",
+					"version": "1",
+					"arn":     "arn:1",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"arn": "arn:1",
+			},
+
+			CustomizeDiff: func(_ context.Context, d *ResourceDiff, meta interface{}) error {
+				return d.SetNewComputed("version")
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"version": {
+						Old:         "1",
+						NewComputed: true,
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.Name), func(t *testing.T) {
+			c := terraform.NewResourceConfigRaw(tc.Config)
+
+			d, err := schemaMap(tc.Schema).Diff(context.Background(), tc.State, c, tc.CustomizeDiff, nil, true)
+			if err != nil != tc.Err {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(tc.Diff, d) {
+				t.Fatalf("expected:\n%#v\n\ngot:\n%#v", tc.Diff, d)
+			}
+		})
+	}
+}
+
+func testReadTransformLower(v cty.Value) cty.Value {
+	if v.IsNull() || !v.IsKnown() {
+		return v
+	}
+
+	return cty.StringVal(strings.ToLower(v.AsString()))
+}
+
+func TestSchemaMap_InternalValidate(t *testing.T) {
+	cases := map[string]struct {
+		In  map[string]*Schema
+		Err bool
+	}{
+		"nothing": {
+			nil,
+			false,
+		},
+
+		"Both optional and required": {
+			map[string]*Schema{
+				"foo": {
+					Type:     TypeInt,
+					Optional: true,
+					Required: true,
+				},
+			},
+			true,
+		},
+
+		"No optional and no required": {
+			map[string]*Schema{
+				"foo": {
+					Type: TypeInt,
+				},
+			},
+			true,
+		},
+
+		"Missing Type": {
+			map[string]*Schema{
+				"foo": {
+					Required: true,
+				},
+			},
+			true,
+		},
+
+		"Required but computed": {
+			map[string]*Schema{
+				"foo": {
+					Type:     TypeInt,
+					Required: true,
+					Computed: true,
+				},
+			},
+			true,
+		},
+
+		"Looks good": {
+			map[string]*Schema{
+				"foo": {
+					Type:     TypeString,
+					Required: true,
+				},
+			},
+			false,
+		},
+
+		"IgnoreServerAddedKeys not computed": {
+			map[string]*Schema{
+				"foo": {
+					Type:                  TypeMap,
+					Optional:              true,
+					IgnoreServerAddedKeys: true,
+				},
+			},
+			true,
+		},
+
+		"IgnoreServerAddedKeys not map": {
+			map[string]*Schema{
+				"foo": {
+					Type:                  TypeString,
+					Optional:              true,
+					Computed:              true,
+					IgnoreServerAddedKeys: true,
+				},
+			},
+			true,
+		},
+
+		"Immutable with ForceNew": {
+			map[string]*Schema{
+				"foo": {
+					Type:      TypeString,
+					Required:  true,
+					ForceNew:  true,
+					Immutable: true,
+				},
+			},
+			true,
+		},
+
+		"Immutable computed-only": {
+			map[string]*Schema{
+				"foo": {
+					Type:      TypeString,
+					Computed:  true,
+					Immutable: true,
+				},
+			},
+			true,
+		},
+
+		"Immutable": {
+			map[string]*Schema{
+				"foo": {
+					Type:      TypeString,
+					Optional:  true,
+					Computed:  true,
+					Immutable: true,
+				},
+			},
+			false,
+		},
+
+		"Computed but has default": {
+			map[string]*Schema{
+				"foo": {
+					Type:     TypeInt,
+					Optional: true,
+					Computed: true,
+					Default:  "foo",
+				},
+			},
+			true,
+		},
+
+		"Required but has default": {
+			map[string]*Schema{
+				"foo": {
+					Type:     TypeInt,
+					Optional: true,
+					Required: true,
+					Default:  "foo",
+				},
+			},
+			true,
+		},
+
+		"List element not set": {
+			map[string]*Schema{
+				"foo": {
+					Type: TypeList,
+				},
+			},
+			true,
+		},
+
+		"List default": {
+			map[string]*Schema{
+				"foo": {
+					Type:    TypeList,
+					Elem:    &Schema{Type: TypeInt},
+					Default: "foo",
+				},
+			},
+			true,
+		},
+
+		"List element computed": {
+			map[string]*Schema{
+				"foo": {
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Schema{
+						Type:     TypeInt,
+						Computed: true,
+					},
+				},
+			},
+			true,
+		},
+
+		"List element with Set set": {
+			map[string]*Schema{
+				"foo": {
+					Type:     TypeList,
+					Elem:     &Schema{Type: TypeInt},
+					Set:      func(interface{}) int { return 0 },
+					Optional: true,
+				},
+			},
+			true,
+		},
+
+		"Set element with no Set set": {
+			map[string]*Schema{
+				"foo": {
+					Type:     TypeSet,
+					Elem:     &Schema{Type: TypeInt},
+					Optional: true,
+				},
+			},
+			false,
+		},
+
+		"Required but computedWhen": {
+			map[string]*Schema{
+				"foo": {
+					Type:         TypeInt,
+					Required:     true,
+					ComputedWhen: []string{"foo"},
+				},
+			},
+			true,
+		},
+
+		"Conflicting attributes cannot be required": {
+			map[string]*Schema{
+				"blacklist": {
+					Type:     TypeBool,
+					Required: true,
+				},
+				"whitelist": {
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"blacklist"},
+				},
+			},
+			true,
+		},
+
+		"Attribute with conflicts cannot be required": {
+			map[string]*Schema{
+				"whitelist": {
+					Type:          TypeBool,
+					Required:      true,
+					ConflictsWith: []string{"blacklist"},
+				},
+			},
+			true,
+		},
+
+		"ConflictsWith cannot be used w/ ComputedWhen": {
+			map[string]*Schema{
+				"blacklist": {
+					Type:         TypeBool,
+					ComputedWhen: []string{"foor"},
+				},
+				"whitelist": {
+					Type:          TypeBool,
+					Required:      true,
+					ConflictsWith: []string{"blacklist"},
+				},
+			},
+			true,
+		},
+
+		"AtLeastOneOf list index syntax with self reference": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:         TypeString,
+								Optional:     true,
+								AtLeastOneOf: []string{"config_block_attr.0.nested_attr"},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		"AtLeastOneOf list index syntax with list configuration block existing attribute": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"config_block_attr.0.nested_attr"},
+				},
+			},
+			false,
+		},
+
+		"AtLeastOneOf list index syntax with list configuration block missing attribute": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"config_block_attr.0.missing_attr"},
+				},
+			},
+			true,
+		},
+
+		"AtLeastOneOf list index syntax with list configuration block missing MaxItems": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"config_block_attr.0.missing_attr"},
+				},
+			},
+			true,
+		},
+
+		"AtLeastOneOf list index syntax with set configuration block existing attribute": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeSet,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"config_block_attr.0.nested_attr"},
+				},
+			},
+			true,
+		},
+
+		"AtLeastOneOf list index syntax with set configuration block missing attribute": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeSet,
+					Optional: true,
 					Elem: &Resource{
 						Schema: map[string]*Schema{
 							"nested_attr": {
-								Type:         TypeString,
-								Optional:     true,
-								AtLeastOneOf: []string{"config_block_attr.0.nested_attr"},
+								Type:     TypeString,
+								Optional: true,
 							},
 						},
 					},
 				},
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"config_block_attr.0.missing_attr"},
+				},
 			},
-			false,
+			true,
 		},
 
-		"AtLeastOneOf list index syntax with list configuration block existing attribute": {
+		"AtLeastOneOf map key syntax with list configuration block existing attribute": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeList,
 					Optional: true,
-					MaxItems: 1,
 					Elem: &Resource{
 						Schema: map[string]*Schema{
 							"nested_attr": {
@@ -3351,17 +4923,36 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 				"test": {
 					Type:         TypeBool,
 					Optional:     true,
-					AtLeastOneOf: []string{"config_block_attr.0.nested_attr"},
+					AtLeastOneOf: []string{"config_block_attr.nested_attr"},
 				},
 			},
-			false,
+			true,
 		},
 
-		"AtLeastOneOf list index syntax with list configuration block missing attribute": {
+		"AtLeastOneOf map key syntax with list configuration block self reference": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeList,
 					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:         TypeString,
+								Optional:     true,
+								AtLeastOneOf: []string{"config_block_attr.nested_attr"},
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
+
+		"AtLeastOneOf map key syntax with set configuration block existing attribute": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeSet,
+					Optional: true,
 					Elem: &Resource{
 						Schema: map[string]*Schema{
 							"nested_attr": {
@@ -3374,13 +4965,64 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 				"test": {
 					Type:         TypeBool,
 					Optional:     true,
-					AtLeastOneOf: []string{"config_block_attr.0.missing_attr"},
+					AtLeastOneOf: []string{"config_block_attr.nested_attr"},
 				},
 			},
 			true,
 		},
 
-		"AtLeastOneOf list index syntax with list configuration block missing MaxItems": {
+		"AtLeastOneOf map key syntax with set configuration block self reference": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeSet,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:         TypeString,
+								Optional:     true,
+								AtLeastOneOf: []string{"config_block_attr.nested_attr"},
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
+
+		"AtLeastOneOf map key syntax with map attribute": {
+			map[string]*Schema{
+				"map_attr": {
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+				},
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"map_attr.some_key"},
+				},
+			},
+			true,
+		},
+
+		"AtLeastOneOf string syntax with list attribute": {
+			map[string]*Schema{
+				"list_attr": {
+					Type:     TypeList,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+				},
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"list_attr"},
+				},
+			},
+			false,
+		},
+
+		"AtLeastOneOf string syntax with list configuration block": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeList,
@@ -3397,13 +5039,45 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 				"test": {
 					Type:         TypeBool,
 					Optional:     true,
-					AtLeastOneOf: []string{"config_block_attr.0.missing_attr"},
+					AtLeastOneOf: []string{"config_block_attr"},
 				},
 			},
-			true,
+			false,
 		},
 
-		"AtLeastOneOf list index syntax with set configuration block existing attribute": {
+		"AtLeastOneOf string syntax with map attribute": {
+			map[string]*Schema{
+				"map_attr": {
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+				},
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"map_attr"},
+				},
+			},
+			false,
+		},
+
+		"AtLeastOneOf string syntax with set attribute": {
+			map[string]*Schema{
+				"set_attr": {
+					Type:     TypeSet,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+				},
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"set_attr"},
+				},
+			},
+			false,
+		},
+
+		"AtLeastOneOf string syntax with set configuration block": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeSet,
@@ -3420,13 +5094,136 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 				"test": {
 					Type:         TypeBool,
 					Optional:     true,
-					AtLeastOneOf: []string{"config_block_attr.0.nested_attr"},
+					AtLeastOneOf: []string{"config_block_attr"},
+				},
+			},
+			false,
+		},
+
+		"AtLeastOneOf string syntax with self reference": {
+			map[string]*Schema{
+				"test": {
+					Type:         TypeBool,
+					Optional:     true,
+					AtLeastOneOf: []string{"test"},
+				},
+			},
+			false,
+		},
+
+		"ConflictsWith list index syntax with self reference": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:          TypeString,
+								Optional:      true,
+								ConflictsWith: []string{"config_block_attr.0.nested_attr"},
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
+
+		"ConflictsWith list index syntax with list configuration block existing attribute": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"test": {
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"config_block_attr.0.nested_attr"},
+				},
+			},
+			false,
+		},
+
+		"ConflictsWith list index syntax with list configuration block missing attribute": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"test": {
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"config_block_attr.0.missing_attr"},
+				},
+			},
+			true,
+		},
+
+		"ConflictsWith list index syntax with list configuration block missing MaxItems": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"test": {
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"config_block_attr.0.missing_attr"},
+				},
+			},
+			true,
+		},
+
+		"ConflictsWith list index syntax with set configuration block existing attribute": {
+			map[string]*Schema{
+				"config_block_attr": {
+					Type:     TypeSet,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"nested_attr": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"test": {
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"config_block_attr.0.nested_attr"},
 				},
 			},
 			true,
 		},
 
-		"AtLeastOneOf list index syntax with set configuration block missing attribute": {
+		"ConflictsWith list index syntax with set configuration block missing attribute": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeSet,
@@ -3441,15 +5238,15 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					},
 				},
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"config_block_attr.0.missing_attr"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"config_block_attr.0.missing_attr"},
 				},
 			},
 			true,
 		},
 
-		"AtLeastOneOf map key syntax with list configuration block existing attribute": {
+		"ConflictsWith map key syntax with list configuration block existing attribute": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeList,
@@ -3464,15 +5261,15 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					},
 				},
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"config_block_attr.nested_attr"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"config_block_attr.nested_attr"},
 				},
 			},
 			true,
 		},
 
-		"AtLeastOneOf map key syntax with list configuration block self reference": {
+		"ConflictsWith map key syntax with list configuration block self reference": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeList,
@@ -3480,9 +5277,9 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					Elem: &Resource{
 						Schema: map[string]*Schema{
 							"nested_attr": {
-								Type:         TypeString,
-								Optional:     true,
-								AtLeastOneOf: []string{"config_block_attr.nested_attr"},
+								Type:          TypeString,
+								Optional:      true,
+								ConflictsWith: []string{"config_block_attr.nested_attr"},
 							},
 						},
 					},
@@ -3491,7 +5288,7 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			true,
 		},
 
-		"AtLeastOneOf map key syntax with set configuration block existing attribute": {
+		"ConflictsWith map key syntax with set configuration block existing attribute": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeSet,
@@ -3506,15 +5303,15 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					},
 				},
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"config_block_attr.nested_attr"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"config_block_attr.nested_attr"},
 				},
 			},
 			true,
 		},
 
-		"AtLeastOneOf map key syntax with set configuration block self reference": {
+		"ConflictsWith map key syntax with set configuration block self reference": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeSet,
@@ -3522,9 +5319,9 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					Elem: &Resource{
 						Schema: map[string]*Schema{
 							"nested_attr": {
-								Type:         TypeString,
-								Optional:     true,
-								AtLeastOneOf: []string{"config_block_attr.nested_attr"},
+								Type:          TypeString,
+								Optional:      true,
+								ConflictsWith: []string{"config_block_attr.nested_attr"},
 							},
 						},
 					},
@@ -3533,7 +5330,7 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			true,
 		},
 
-		"AtLeastOneOf map key syntax with map attribute": {
+		"ConflictsWith map key syntax with map attribute": {
 			map[string]*Schema{
 				"map_attr": {
 					Type:     TypeMap,
@@ -3541,15 +5338,15 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					Elem:     &Schema{Type: TypeString},
 				},
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"map_attr.some_key"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"map_attr.some_key"},
 				},
 			},
 			true,
 		},
 
-		"AtLeastOneOf string syntax with list attribute": {
+		"ConflictsWith string syntax with list attribute": {
 			map[string]*Schema{
 				"list_attr": {
 					Type:     TypeList,
@@ -3557,15 +5354,15 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					Elem:     &Schema{Type: TypeString},
 				},
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"list_attr"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"list_attr"},
 				},
 			},
 			false,
 		},
 
-		"AtLeastOneOf string syntax with list configuration block": {
+		"ConflictsWith string syntax with list configuration block": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeList,
@@ -3580,15 +5377,15 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					},
 				},
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"config_block_attr"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"config_block_attr"},
 				},
 			},
 			false,
 		},
 
-		"AtLeastOneOf string syntax with map attribute": {
+		"ConflictsWith string syntax with map attribute": {
 			map[string]*Schema{
 				"map_attr": {
 					Type:     TypeMap,
@@ -3596,15 +5393,15 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					Elem:     &Schema{Type: TypeString},
 				},
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"map_attr"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"map_attr"},
 				},
 			},
 			false,
 		},
 
-		"AtLeastOneOf string syntax with set attribute": {
+		"ConflictsWith string syntax with set attribute": {
 			map[string]*Schema{
 				"set_attr": {
 					Type:     TypeSet,
@@ -3612,15 +5409,15 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					Elem:     &Schema{Type: TypeString},
 				},
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"set_attr"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"set_attr"},
 				},
 			},
 			false,
 		},
 
-		"AtLeastOneOf string syntax with set configuration block": {
+		"ConflictsWith string syntax with set configuration block": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeSet,
@@ -3635,36 +5432,164 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					},
 				},
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"config_block_attr"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"config_block_attr"},
 				},
 			},
 			false,
 		},
 
-		"AtLeastOneOf string syntax with self reference": {
+		"ConflictsWith string syntax with self reference": {
 			map[string]*Schema{
 				"test": {
-					Type:         TypeBool,
-					Optional:     true,
-					AtLeastOneOf: []string{"test"},
+					Type:          TypeBool,
+					Optional:      true,
+					ConflictsWith: []string{"test"},
 				},
 			},
-			false,
+			true,
 		},
 
-		"ConflictsWith list index syntax with self reference": {
+		"ExactlyOneOf list index syntax with self reference": {
 			map[string]*Schema{
 				"config_block_attr": {
 					Type:     TypeList,
 					Optional: true,
+					MaxItems: 1,
 					Elem: &Resource{
 						Schema: map[string]*Schema{
 							"nested_attr": {
-								Type:          TypeString,
-								Optional:      true,
-								ConflictsWith: []string{"config_block_attr.0.nested_attr"},
+								Type:         TypeString,
+								Optional:     true,
+								ExactlyOneOf: []string{"config_block_attr.0.nested_attr"},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		"ReadTransformFunc with primitive type": {
+			map[string]*Schema{
+				"foo": {
+					Type:              TypeString,
+					Optional:          true,
+					ReadTransformFunc: testReadTransformLower,
+				},
+			},
+			false,
+		},
+
+		"ReadTransformFunc with non-primitive type": {
+			map[string]*Schema{
+				"foo": {
+					Type:              TypeList,
+					Optional:          true,
+					Elem:              &Schema{Type: TypeString},
+					ReadTransformFunc: testReadTransformLower,
+				},
+			},
+			true,
+		},
+
+		"ReadTransformFunc on computed-only field": {
+			map[string]*Schema{
+				"foo": {
+					Type:              TypeString,
+					Computed:          true,
+					ReadTransformFunc: testReadTransformLower,
+				},
+			},
+			true,
+		},
+
+		"DefaultBlock valid": {
+			map[string]*Schema{
+				"foo": {
+					Type:         TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					DefaultBlock: map[string]interface{}{"mode": "auto"},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"mode": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		"DefaultBlock without MaxItems 1": {
+			map[string]*Schema{
+				"foo": {
+					Type:         TypeList,
+					Optional:     true,
+					DefaultBlock: map[string]interface{}{},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"mode": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
+
+		"DefaultBlock with Computed": {
+			map[string]*Schema{
+				"foo": {
+					Type:         TypeList,
+					Optional:     true,
+					Computed:     true,
+					MaxItems:     1,
+					DefaultBlock: map[string]interface{}{},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"mode": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
+
+		"DefaultBlock with Schema Elem": {
+			map[string]*Schema{
+				"foo": {
+					Type:         TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					DefaultBlock: map[string]interface{}{},
+					Elem:         &Schema{Type: TypeString},
+				},
+			},
+			true,
+		},
+
+		"DefaultBlock with unknown attribute": {
+			map[string]*Schema{
+				"foo": {
+					Type:         TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					DefaultBlock: map[string]interface{}{"other": "auto"},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"mode": {
+								Type:     TypeString,
+								Optional: true,
 							},
 						},
 					},
@@ -3673,156 +5598,186 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			true,
 		},
 
-		"ConflictsWith list index syntax with list configuration block existing attribute": {
+		"UpdateCost valid": {
+			map[string]*Schema{
+				"size": {
+					Type:       TypeInt,
+					Optional:   true,
+					UpdateCost: 10,
+				},
+			},
+			false,
+		},
+
+		"UpdateCost negative": {
+			map[string]*Schema{
+				"size": {
+					Type:       TypeInt,
+					Optional:   true,
+					UpdateCost: -1,
+				},
+			},
+			true,
+		},
+
+		"UpdateCost computed-only": {
+			map[string]*Schema{
+				"size": {
+					Type:       TypeInt,
+					Computed:   true,
+					UpdateCost: 10,
+				},
+			},
+			true,
+		},
+
+		"DefaultFrom valid": {
+			map[string]*Schema{
+				"name": {
+					Type:     TypeString,
+					Optional: true,
+				},
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "name",
+				},
+			},
+			false,
+		},
+
+		"DefaultFrom unknown sibling": {
+			map[string]*Schema{
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "name",
+				},
+			},
+			true,
+		},
+
+		"DefaultFrom self": {
+			map[string]*Schema{
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "description",
+				},
+			},
+			true,
+		},
+
+		"DefaultFrom chain": {
 			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeList,
+				"name": {
+					Type:     TypeString,
 					Optional: true,
-					MaxItems: 1,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:     TypeString,
-								Optional: true,
-							},
-						},
-					},
 				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"config_block_attr.0.nested_attr"},
+				"display_name": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "name",
+				},
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "display_name",
 				},
 			},
-			false,
+			true,
 		},
 
-		"ConflictsWith list index syntax with list configuration block missing attribute": {
+		"DefaultFrom different type": {
 			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeList,
+				"count": {
+					Type:     TypeInt,
 					Optional: true,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:     TypeString,
-								Optional: true,
-							},
-						},
-					},
 				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"config_block_attr.0.missing_attr"},
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					DefaultFrom: "count",
 				},
 			},
 			true,
 		},
 
-		"ConflictsWith list index syntax with list configuration block missing MaxItems": {
+		"DefaultFrom with Default": {
 			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeList,
+				"name": {
+					Type:     TypeString,
 					Optional: true,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:     TypeString,
-								Optional: true,
-							},
-						},
-					},
 				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"config_block_attr.0.missing_attr"},
+				"description": {
+					Type:        TypeString,
+					Optional:    true,
+					Default:     "foo",
+					DefaultFrom: "name",
 				},
 			},
 			true,
 		},
 
-		"ConflictsWith list index syntax with set configuration block existing attribute": {
+		"WriteOnly valid": {
 			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeSet,
-					Optional: true,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:     TypeString,
-								Optional: true,
-							},
-						},
-					},
+				"password": {
+					Type:      TypeString,
+					Optional:  true,
+					Sensitive: true,
+					WriteOnly: true,
 				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"config_block_attr.0.nested_attr"},
+			},
+			false,
+		},
+
+		"WriteOnly computed": {
+			map[string]*Schema{
+				"password": {
+					Type:      TypeString,
+					Optional:  true,
+					Computed:  true,
+					WriteOnly: true,
 				},
 			},
 			true,
 		},
 
-		"ConflictsWith list index syntax with set configuration block missing attribute": {
+		"WriteOnly force new": {
 			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeSet,
-					Optional: true,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:     TypeString,
-								Optional: true,
-							},
-						},
-					},
-				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"config_block_attr.0.missing_attr"},
+				"password": {
+					Type:      TypeString,
+					Optional:  true,
+					ForceNew:  true,
+					WriteOnly: true,
 				},
 			},
 			true,
 		},
 
-		"ConflictsWith map key syntax with list configuration block existing attribute": {
+		"WriteOnly non-primitive": {
 			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeList,
-					Optional: true,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:     TypeString,
-								Optional: true,
-							},
-						},
-					},
-				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"config_block_attr.nested_attr"},
+				"passwords": {
+					Type:      TypeList,
+					Optional:  true,
+					WriteOnly: true,
+					Elem:      &Schema{Type: TypeString},
 				},
 			},
 			true,
 		},
 
-		"ConflictsWith map key syntax with list configuration block self reference": {
+		"WriteOnly nested": {
 			map[string]*Schema{
-				"config_block_attr": {
+				"credentials": {
 					Type:     TypeList,
 					Optional: true,
 					Elem: &Resource{
 						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:          TypeString,
-								Optional:      true,
-								ConflictsWith: []string{"config_block_attr.nested_attr"},
+							"password": {
+								Type:      TypeString,
+								Optional:  true,
+								WriteOnly: true,
 							},
 						},
 					},
@@ -3831,140 +5786,172 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			true,
 		},
 
-		"ConflictsWith map key syntax with set configuration block existing attribute": {
+		"ComputedDependsOn valid": {
 			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeSet,
-					Optional: true,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:     TypeString,
-								Optional: true,
-							},
-						},
-					},
+				"arn": {
+					Type:     TypeString,
+					Computed: true,
 				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"config_block_attr.nested_attr"},
+				"url": {
+					Type:              TypeString,
+					Optional:          true,
+					Computed:          true,
+					ComputedDependsOn: []string{"arn"},
 				},
 			},
-			true,
+			false,
 		},
 
-		"ConflictsWith map key syntax with set configuration block self reference": {
+		"ComputedDependsOn without Computed": {
 			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeSet,
-					Optional: true,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:          TypeString,
-								Optional:      true,
-								ConflictsWith: []string{"config_block_attr.nested_attr"},
-							},
-						},
-					},
+				"arn": {
+					Type:     TypeString,
+					Computed: true,
+				},
+				"url": {
+					Type:              TypeString,
+					Optional:          true,
+					ComputedDependsOn: []string{"arn"},
 				},
 			},
 			true,
 		},
 
-		"ConflictsWith map key syntax with map attribute": {
+		"ComputedDependsOn with unknown attribute": {
 			map[string]*Schema{
-				"map_attr": {
-					Type:     TypeMap,
-					Optional: true,
-					Elem:     &Schema{Type: TypeString},
+				"url": {
+					Type:              TypeString,
+					Computed:          true,
+					ComputedDependsOn: []string{"arn"},
 				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"map_attr.some_key"},
+			},
+			true,
+		},
+
+		"ComputedDependsOn with self reference": {
+			map[string]*Schema{
+				"url": {
+					Type:              TypeString,
+					Computed:          true,
+					ComputedDependsOn: []string{"url"},
 				},
 			},
 			true,
 		},
 
-		"ConflictsWith string syntax with list attribute": {
+		"ComputedDependsOn with non-Computed attribute": {
 			map[string]*Schema{
-				"list_attr": {
-					Type:     TypeList,
-					Optional: true,
-					Elem:     &Schema{Type: TypeString},
+				"name": {
+					Type:     TypeString,
+					Required: true,
 				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"list_attr"},
+				"url": {
+					Type:              TypeString,
+					Computed:          true,
+					ComputedDependsOn: []string{"name"},
 				},
 			},
-			false,
+			true,
 		},
 
-		"ConflictsWith string syntax with list configuration block": {
+		"ComputedDependsOn nested": {
 			map[string]*Schema{
-				"config_block_attr": {
+				"block": {
 					Type:     TypeList,
 					Optional: true,
 					Elem: &Resource{
 						Schema: map[string]*Schema{
-							"nested_attr": {
+							"arn": {
+								Type:     TypeString,
+								Computed: true,
+							},
+							"url": {
+								Type:              TypeString,
+								Computed:          true,
+								ComputedDependsOn: []string{"arn"},
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
+
+		"DefaultBlock with invalid attribute type": {
+			map[string]*Schema{
+				"foo": {
+					Type:         TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					DefaultBlock: map[string]interface{}{"mode": []interface{}{"auto"}},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"mode": {
 								Type:     TypeString,
 								Optional: true,
 							},
 						},
 					},
 				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"config_block_attr"},
+			},
+			true,
+		},
+
+		"PlanModifiers with primitive type": {
+			map[string]*Schema{
+				"foo": {
+					Type:          TypeString,
+					Computed:      true,
+					PlanModifiers: []AttributePlanModifier{UseStateForUnknown()},
 				},
 			},
 			false,
 		},
 
-		"ConflictsWith string syntax with map attribute": {
+		"PlanModifiers with non-primitive type": {
 			map[string]*Schema{
-				"map_attr": {
-					Type:     TypeMap,
-					Optional: true,
-					Elem:     &Schema{Type: TypeString},
-				},
-				"test": {
-					Type:          TypeBool,
+				"foo": {
+					Type:          TypeList,
 					Optional:      true,
-					ConflictsWith: []string{"map_attr"},
+					Elem:          &Schema{Type: TypeString},
+					PlanModifiers: []AttributePlanModifier{UseStateForUnknown()},
 				},
 			},
-			false,
+			true,
 		},
 
-		"ConflictsWith string syntax with set attribute": {
+		"AtMostOneOf existing attribute": {
 			map[string]*Schema{
-				"set_attr": {
-					Type:     TypeSet,
-					Optional: true,
-					Elem:     &Schema{Type: TypeString},
+				"whitelist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"blacklist"},
 				},
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"set_attr"},
+				"blacklist": {
+					Type:     TypeBool,
+					Optional: true,
 				},
 			},
 			false,
 		},
 
-		"ConflictsWith string syntax with set configuration block": {
+		"AtMostOneOf missing attribute": {
+			map[string]*Schema{
+				"whitelist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"missing_attr"},
+				},
+			},
+			true,
+		},
+
+		"AtMostOneOf list index syntax with list configuration block missing attribute": {
 			map[string]*Schema{
 				"config_block_attr": {
-					Type:     TypeSet,
+					Type:     TypeList,
 					Optional: true,
+					MaxItems: 1,
 					Elem: &Resource{
 						Schema: map[string]*Schema{
 							"nested_attr": {
@@ -3975,43 +5962,42 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					},
 				},
 				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"config_block_attr"},
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"config_block_attr.0.missing_attr"},
 				},
 			},
-			false,
+			true,
 		},
 
-		"ConflictsWith string syntax with self reference": {
+		"AtMostOneOf with Required": {
 			map[string]*Schema{
-				"test": {
-					Type:          TypeBool,
-					Optional:      true,
-					ConflictsWith: []string{"test"},
+				"whitelist": {
+					Type:        TypeBool,
+					Required:    true,
+					AtMostOneOf: []string{"blacklist"},
+				},
+				"blacklist": {
+					Type:     TypeBool,
+					Optional: true,
 				},
 			},
 			true,
 		},
 
-		"ExactlyOneOf list index syntax with self reference": {
+		"AtMostOneOf with computed-only": {
 			map[string]*Schema{
-				"config_block_attr": {
-					Type:     TypeList,
+				"whitelist": {
+					Type:        TypeBool,
+					Computed:    true,
+					AtMostOneOf: []string{"blacklist"},
+				},
+				"blacklist": {
+					Type:     TypeBool,
 					Optional: true,
-					MaxItems: 1,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"nested_attr": {
-								Type:         TypeString,
-								Optional:     true,
-								ExactlyOneOf: []string{"config_block_attr.0.nested_attr"},
-							},
-						},
-					},
 				},
 			},
-			false,
+			true,
 		},
 
 		"ExactlyOneOf list index syntax with list configuration block existing attribute": {
@@ -4794,7 +6780,7 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					DiffSuppressFunc: func(k, oldValue, newValue string, d *ResourceData) bool { return false },
 				},
 			},
-			true,
+			false,
 		},
 
 		"DiffSuppressOnRefresh without DiffSuppressFunc": {
@@ -4930,6 +6916,92 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			false,
 		},
 
+		"SupersededBy nested attribute": {
+			map[string]*Schema{
+				"name": {
+					Type:         TypeString,
+					Optional:     true,
+					Deprecated:   "Use settings.name instead",
+					SupersededBy: "settings.0.name",
+				},
+				"settings": {
+					Type:     TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": {
+								Type:     TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		"SupersededBy without Deprecated": {
+			map[string]*Schema{
+				"name": {
+					Type:         TypeString,
+					Optional:     true,
+					SupersededBy: "settings.0.name",
+				},
+				"settings": {
+					Type:     TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": {
+								Type:     TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
+
+		"SupersededBy unknown attribute": {
+			map[string]*Schema{
+				"name": {
+					Type:         TypeString,
+					Optional:     true,
+					Deprecated:   "Use settings.other instead",
+					SupersededBy: "settings.0.other",
+				},
+				"settings": {
+					Type:     TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": {
+								Type:     TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
+
+		"SupersededBy self": {
+			map[string]*Schema{
+				"name": {
+					Type:         TypeString,
+					Optional:     true,
+					Deprecated:   "Use name instead",
+					SupersededBy: "name",
+				},
+			},
+			true,
+		},
+
 		"ConfigModeBlock with Elem *Resource": {
 			map[string]*Schema{
 				"block": {
@@ -4996,75 +7068,280 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 					},
 				},
 			},
-			true, // ConfigMode of block cannot be used in child of schema with ConfigMode of attribute
+			true, // ConfigMode of block cannot be used in child of schema with ConfigMode of attribute
+		},
+
+		"ConfigModeAuto with *Resource inside ConfigModeAttr": {
+			map[string]*Schema{
+				"block": {
+					Type:       TypeList,
+					ConfigMode: SchemaConfigModeAttr,
+					Optional:   true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"sub": {
+								Type: TypeList,
+								Elem: &Resource{},
+							},
+						},
+					},
+				},
+			},
+			true, // in *schema.Resource with ConfigMode of attribute, so must also have ConfigMode of attribute
+		},
+
+		"TypeMap with Elem *Resource": {
+			map[string]*Schema{
+				"map": {
+					Type:     TypeMap,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"keynothandled": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
+
+		"ValidateFunc and ValidateDiagFunc cannot both be set": {
+			map[string]*Schema{
+				"foo": {
+					Type:     TypeInt,
+					Required: true,
+					ValidateFunc: func(interface{}, string) ([]string, []error) {
+						return nil, nil
+					},
+					ValidateDiagFunc: func(interface{}, cty.Path) diag.Diagnostics {
+						return nil
+					},
+				},
+			},
+			true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			err := schemaMap(tc.In).InternalValidate(nil)
+			if err != nil != tc.Err {
+				if tc.Err {
+					t.Fatalf("%q: Expected error did not occur:\n\n%#v", tn, tc.In)
+				}
+				t.Fatalf("%q: Unexpected error occurred: %s\n\n%#v", tn, err, tc.In)
+			}
+		})
+	}
+
+}
+
+func TestSchemaMap_InternalValidate_forceNewInSetWarnings(t *testing.T) {
+	nested := &Resource{
+		Schema: map[string]*Schema{
+			"name": {
+				Type:     TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		Schema   *Schema
+		Expected []string
+	}{
+		"set": {
+			Schema: &Schema{
+				Type:     TypeSet,
+				Optional: true,
+				Elem:     nested,
+			},
+			Expected: []string{
+				"rule.name: ForceNew within a TypeSet block requires replacement on any change to rule, " +
+					"including adding or removing elements and changing attributes without ForceNew; " +
+					"consider setting ForceNew on rule instead",
+			},
+		},
+		"set-forcenew": {
+			Schema: &Schema{
+				Type:     TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     nested,
+			},
+		},
+		"list": {
+			Schema: &Schema{
+				Type:     TypeList,
+				Optional: true,
+				Elem:     nested,
+			},
+		},
+		"set-primitive": {
+			Schema: &Schema{
+				Type:     TypeSet,
+				Optional: true,
+				Elem:     &Schema{Type: TypeString},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := forceNewInSetWarnings("rule", tc.Schema)
+
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Fatalf("expected:\n%#v\n\ngot:\n%#v", tc.Expected, got)
+			}
+
+			if err := schemaMap(map[string]*Schema{"rule": tc.Schema}).InternalValidate(nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSchemaMap_InternalValidate_computedInSetHashWarnings(t *testing.T) {
+	computed := &Resource{
+		Schema: map[string]*Schema{
+			"id": {
+				Type:     TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+	}
+	mixed := &Resource{
+		Schema: map[string]*Schema{
+			"name": {
+				Type:     TypeString,
+				Required: true,
+			},
+			"status": {
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+	}
+	optionalComputed := &Resource{
+		Schema: map[string]*Schema{
+			"name": {
+				Type:     TypeString,
+				Required: true,
+			},
+			"port": {
+				Type:     TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"status": {
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		Schema   *Schema
+		Expected []string
+	}{
+		"set": {
+			Schema: &Schema{
+				Type:     TypeSet,
+				Optional: true,
+				Elem:     computed,
+			},
+			Expected: []string{
+				"rule.id: Computed-only attribute participates in the default hash of TypeSet rule, " +
+					"which can change as its value becomes known and cause perpetual differences; " +
+					"consider providing a Set function for rule keyed on stable attributes",
+				"rule.status: Computed-only attribute participates in the default hash of TypeSet rule, " +
+					"which can change as its value becomes known and cause perpetual differences; " +
+					"consider providing a Set function for rule keyed on stable attributes",
+			},
+		},
+		"set-with-set-func": {
+			Schema: &Schema{
+				Type:     TypeSet,
+				Optional: true,
+				Elem:     computed,
+				Set:      HashResource(computed),
+			},
+		},
+		"set-computed-only": {
+			Schema: &Schema{
+				Type:     TypeSet,
+				Computed: true,
+				Elem:     computed,
+			},
+		},
+		"set-mixed": {
+			Schema: &Schema{
+				Type:     TypeSet,
+				Optional: true,
+				Elem:     mixed,
+			},
+		},
+		"set-optional-computed": {
+			Schema: &Schema{
+				Type:     TypeSet,
+				Optional: true,
+				Elem:     optionalComputed,
+			},
+			Expected: []string{
+				"rule.port: Optional and Computed attribute participates in the default hash of TypeSet rule, " +
+					"which can change as its value becomes known when not configured and cause perpetual differences; " +
+					"consider providing a Set function for rule keyed on stable attributes",
+			},
 		},
-
-		"ConfigModeAuto with *Resource inside ConfigModeAttr": {
-			map[string]*Schema{
-				"block": {
-					Type:       TypeList,
-					ConfigMode: SchemaConfigModeAttr,
-					Optional:   true,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"sub": {
-								Type: TypeList,
-								Elem: &Resource{},
-							},
-						},
-					},
-				},
+		"set-optional-computed-with-set-func": {
+			Schema: &Schema{
+				Type:     TypeSet,
+				Optional: true,
+				Elem:     optionalComputed,
+				Set:      HashResource(optionalComputed),
 			},
-			true, // in *schema.Resource with ConfigMode of attribute, so must also have ConfigMode of attribute
 		},
-
-		"TypeMap with Elem *Resource": {
-			map[string]*Schema{
-				"map": {
-					Type:     TypeMap,
-					Optional: true,
-					Elem: &Resource{
-						Schema: map[string]*Schema{
-							"keynothandled": {
-								Type:     TypeString,
-								Optional: true,
-							},
-						},
-					},
-				},
+		"list": {
+			Schema: &Schema{
+				Type:     TypeList,
+				Optional: true,
+				Elem:     computed,
 			},
-			true,
 		},
-
-		"ValidateFunc and ValidateDiagFunc cannot both be set": {
-			map[string]*Schema{
-				"foo": {
-					Type:     TypeInt,
-					Required: true,
-					ValidateFunc: func(interface{}, string) ([]string, []error) {
-						return nil, nil
-					},
-					ValidateDiagFunc: func(interface{}, cty.Path) diag.Diagnostics {
-						return nil
-					},
-				},
+		"list-optional-computed": {
+			Schema: &Schema{
+				Type:     TypeList,
+				Optional: true,
+				Elem:     optionalComputed,
 			},
-			true,
 		},
 	}
 
 	for tn, tc := range cases {
 		t.Run(tn, func(t *testing.T) {
-			err := schemaMap(tc.In).InternalValidate(nil)
-			if err != nil != tc.Err {
-				if tc.Err {
-					t.Fatalf("%q: Expected error did not occur:\n\n%#v", tn, tc.In)
-				}
-				t.Fatalf("%q: Unexpected error occurred: %s\n\n%#v", tn, err, tc.In)
+			got := computedInSetHashWarnings("rule", tc.Schema)
+
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Fatalf("expected:\n%#v\n\ngot:\n%#v", tc.Expected, got)
+			}
+
+			if err := schemaMap(map[string]*Schema{"rule": tc.Schema}).InternalValidate(nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
 			}
 		})
 	}
-
 }
 
 func TestSchemaMap_DiffSuppress(t *testing.T) {
@@ -5483,6 +7760,72 @@ func TestSchema_DiffSuppressOnRefresh(t *testing.T) {
 				"unrelated": "hi",
 			},
 		},
+		"suppress func computed-only json": {
+			Schema: schemaMap{
+				"policy": {
+					Type:     TypeString,
+					Computed: true,
+					DiffSuppressFunc: func(key, oldV, newV string, d *ResourceData) bool {
+						var o, n interface{}
+						if json.Unmarshal([]byte(oldV), &o) != nil || json.Unmarshal([]byte(newV), &n) != nil {
+							return false
+						}
+						return reflect.DeepEqual(o, n)
+					},
+				},
+			},
+			PriorState: map[string]string{
+				"policy": `{"Version":"2012-10-17","Statement":[]}`,
+			},
+			SetKey: "policy",
+			SetVal: `{"Statement": [], "Version": "2012-10-17"}`,
+			WantState: map[string]string{
+				"policy": `{"Version":"2012-10-17","Statement":[]}`, // normalized change was ignored
+			},
+		},
+		"suppress func computed-only json changed": {
+			Schema: schemaMap{
+				"policy": {
+					Type:     TypeString,
+					Computed: true,
+					DiffSuppressFunc: func(key, oldV, newV string, d *ResourceData) bool {
+						var o, n interface{}
+						if json.Unmarshal([]byte(oldV), &o) != nil || json.Unmarshal([]byte(newV), &n) != nil {
+							return false
+						}
+						return reflect.DeepEqual(o, n)
+					},
+				},
+			},
+			PriorState: map[string]string{
+				"policy": `{"Version":"2012-10-17","Statement":[]}`,
+			},
+			SetKey: "policy",
+			SetVal: `{"Version":"2008-10-17","Statement":[]}`,
+			WantState: map[string]string{
+				"policy": `{"Version":"2008-10-17","Statement":[]}`, // set was honored
+			},
+		},
+		"suppress func optional computed without DiffSuppressOnRefresh": {
+			Schema: schemaMap{
+				"v": {
+					Type:     TypeString,
+					Optional: true,
+					Computed: true,
+					DiffSuppressFunc: func(key, oldV, newV string, d *ResourceData) bool {
+						return true
+					},
+				},
+			},
+			PriorState: map[string]string{
+				"v": "hello",
+			},
+			SetKey: "v",
+			SetVal: "howdy",
+			WantState: map[string]string{
+				"v": "howdy", // set was honored
+			},
+		},
 		"suppress func nested string always no prior": {
 			Schema: schemaMap{
 				"v": {
@@ -6188,6 +8531,40 @@ func TestSchemaMap_Validate(t *testing.T) {
 			},
 		},
 
+		"Deprecated attribute with SupersededBy generates warning with path": {
+			Schema: map[string]*Schema{
+				"old_news": {
+					Type:         TypeString,
+					Optional:     true,
+					Deprecated:   "please use 'news.0.headline' instead",
+					SupersededBy: "news.0.headline",
+				},
+				"news": {
+					Type:     TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"headline": {
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"old_news": "extra extra!",
+			},
+
+			Err: false,
+
+			Warnings: []string{
+				"Warning: Argument is deprecated: please use 'news.0.headline' instead\n\nSuperseded by: news.0.headline",
+			},
+		},
+
 		"Deprecated generates no warnings if attr not used": {
 			Schema: map[string]*Schema{
 				"old_news": {
@@ -8535,6 +10912,144 @@ func TestValidateAtLeastOneOfAttributes(t *testing.T) {
 	}
 }
 
+func TestValidateAtMostOneOfAttributes(t *testing.T) {
+	cases := map[string]struct {
+		Schema map[string]*Schema
+		Config map[string]interface{}
+		Err    bool
+	}{
+		"no attributes specified": {
+			Schema: map[string]*Schema{
+				"whitelist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"blacklist"},
+				},
+				"blacklist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"whitelist"},
+				},
+			},
+
+			Config: map[string]interface{}{},
+			Err:    false,
+		},
+
+		"one attribute specified": {
+			Schema: map[string]*Schema{
+				"whitelist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"blacklist"},
+				},
+				"blacklist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"whitelist"},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"whitelist": true,
+			},
+			Err: false,
+		},
+
+		"two attributes specified": {
+			Schema: map[string]*Schema{
+				"whitelist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"blacklist"},
+				},
+				"blacklist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"whitelist"},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"whitelist": true,
+				"blacklist": true,
+			},
+			Err: true,
+		},
+
+		"two attributes of three specified": {
+			Schema: map[string]*Schema{
+				"whitelist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"blacklist", "purplelist"},
+				},
+				"blacklist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"whitelist", "purplelist"},
+				},
+				"purplelist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"whitelist", "blacklist"},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"whitelist":  true,
+				"purplelist": true,
+			},
+			Err: true,
+		},
+
+		"one attribute specified and one unknown": {
+			Schema: map[string]*Schema{
+				"whitelist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"blacklist"},
+				},
+				"blacklist": {
+					Type:        TypeBool,
+					Optional:    true,
+					AtMostOneOf: []string{"whitelist"},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"whitelist": hcl2shim.UnknownVariableValue,
+				"blacklist": true,
+			},
+			Err: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			c := terraform.NewResourceConfigRaw(tc.Config)
+			diags := schemaMap(tc.Schema).Validate(c)
+			if diags.HasError() != tc.Err {
+				if !diags.HasError() {
+					t.Fatalf("expected error")
+				}
+
+				for _, e := range diagutils.ErrorDiags(diags).Errors() {
+					t.Fatalf("didn't expect error, got error: %+v", e)
+				}
+
+				t.FailNow()
+			}
+
+			for _, d := range diags {
+				if len(d.AttributePath) == 0 {
+					t.Fatalf("expected attribute path on diagnostic: %+v", d)
+				}
+			}
+		})
+	}
+}
+
 func TestPanicOnErrorDefaultsFalse(t *testing.T) {
 	t.Setenv("TF_ACC", "")
 
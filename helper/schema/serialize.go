@@ -11,6 +11,10 @@ import (
 )
 
 func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
+	serializeValueForHash(buf, val, schema, false)
+}
+
+func serializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema, stable bool) {
 	if val == nil {
 		buf.WriteRune(';')
 		return
@@ -33,7 +37,7 @@ func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
 		buf.WriteRune('(')
 		l := val.([]interface{})
 		for _, innerVal := range l {
-			serializeCollectionMemberForHash(buf, innerVal, schema.Elem)
+			serializeCollectionMemberForHash(buf, innerVal, schema.Elem, stable)
 		}
 		buf.WriteRune(')')
 	case TypeMap:
@@ -70,8 +74,23 @@ func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
 	case TypeSet:
 		buf.WriteRune('{')
 		s := val.(*Set)
-		for _, innerVal := range s.List() {
-			serializeCollectionMemberForHash(buf, innerVal, schema.Elem)
+		if stable {
+			// Order the members by their own serialization, rather than by
+			// the hash codes of the set, which may not be stable.
+			var members []string
+			for _, innerVal := range s.List() {
+				var memberBuf bytes.Buffer
+				serializeCollectionMemberForHash(&memberBuf, innerVal, schema.Elem, stable)
+				members = append(members, memberBuf.String())
+			}
+			sort.Strings(members)
+			for _, member := range members {
+				buf.WriteString(member)
+			}
+		} else {
+			for _, innerVal := range s.List() {
+				serializeCollectionMemberForHash(buf, innerVal, schema.Elem, stable)
+			}
 		}
 		buf.WriteRune('}')
 	default:
@@ -88,6 +107,14 @@ func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
 // to hash complex substructures when used in sets, and so the serialization
 // is not reversible.
 func SerializeResourceForHash(buf *bytes.Buffer, val interface{}, resource *Resource) {
+	serializeResourceForHash(buf, val, resource, false)
+}
+
+// serializeResourceForHash appends a serialization of the given resource
+// config to the given buffer. If stable, attributes with zero values are
+// omitted, so that adding attributes to the schema does not change the
+// serialization of existing values.
+func serializeResourceForHash(buf *bytes.Buffer, val interface{}, resource *Resource, stable bool) {
 	if val == nil {
 		return
 	}
@@ -112,20 +139,49 @@ func SerializeResourceForHash(buf *bytes.Buffer, val interface{}, resource *Reso
 			continue
 		}
 
+		innerVal := m[k]
+		if stable && isZeroHashValue(innerVal) {
+			continue
+		}
+
 		buf.WriteString(k)
 		buf.WriteRune(':')
-		innerVal := m[k]
-		SerializeValueForHash(buf, innerVal, innerSchema)
+		serializeValueForHash(buf, innerVal, innerSchema, stable)
+	}
+}
+
+// isZeroHashValue returns whether the value is unset or the zero value of its
+// type, as returned by ResourceData Get.
+func isZeroHashValue(val interface{}) bool {
+	switch val := val.(type) {
+	case nil:
+		return true
+	case bool:
+		return !val
+	case int:
+		return val == 0
+	case float64:
+		return val == 0
+	case string:
+		return val == ""
+	case []interface{}:
+		return len(val) == 0
+	case map[string]interface{}:
+		return len(val) == 0
+	case *Set:
+		return val.Len() == 0
+	default:
+		return false
 	}
 }
 
-func serializeCollectionMemberForHash(buf *bytes.Buffer, val interface{}, elem interface{}) {
+func serializeCollectionMemberForHash(buf *bytes.Buffer, val interface{}, elem interface{}, stable bool) {
 	switch tElem := elem.(type) {
 	case *Schema:
-		SerializeValueForHash(buf, val, tElem)
+		serializeValueForHash(buf, val, tElem, stable)
 	case *Resource:
 		buf.WriteRune('<')
-		SerializeResourceForHash(buf, val, tElem)
+		serializeResourceForHash(buf, val, tElem, stable)
 		buf.WriteString(">;")
 	default:
 		panic(fmt.Sprintf("invalid element type: %T", tElem))
@@ -38,6 +38,20 @@ func HashResource(resource *Resource) SchemaSetFunc {
 	}
 }
 
+// HashResourceStable hashes complex structures that are described using a
+// *Resource, like HashResource, but is safe to persist, such as in set
+// element keys recorded in state. The hash is independent of map iteration
+// order and omits attributes with zero values, so adding attributes to the
+// schema does not change the hash of existing values. Nested sets are
+// ordered by the values of their elements rather than their hash codes.
+func HashResourceStable(resource *Resource) SchemaSetFunc {
+	return func(v interface{}) int {
+		var buf bytes.Buffer
+		serializeResourceForHash(&buf, v, resource, true)
+		return hashcode.String(buf.String())
+	}
+}
+
 // HashSchema hashes values that are described using a *Schema. This is the
 // default set implementation used when a set's element type is a single
 // schema.
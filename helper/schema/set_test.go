@@ -137,6 +137,91 @@ func TestHashResource_nil(t *testing.T) {
 	}
 }
 
+func TestHashResourceStable(t *testing.T) {
+	t.Parallel()
+
+	tag := &Resource{
+		Schema: map[string]*Schema{
+			"key": {
+				Type:     TypeString,
+				Required: true,
+			},
+		},
+	}
+
+	resource := &Resource{
+		Schema: map[string]*Schema{
+			"name": {
+				Type:     TypeString,
+				Optional: true,
+			},
+			"port": {
+				Type:     TypeInt,
+				Optional: true,
+			},
+			"tags": {
+				Type:     TypeSet,
+				Optional: true,
+				Elem:     tag,
+			},
+		},
+	}
+
+	// The same schema with an additional attribute.
+	extended := &Resource{
+		Schema: map[string]*Schema{
+			"name":    resource.Schema["name"],
+			"port":    resource.Schema["port"],
+			"tags":    resource.Schema["tags"],
+			"enabled": {Type: TypeBool, Optional: true},
+		},
+	}
+
+	tags := func(f SchemaSetFunc) *Set {
+		return NewSet(f, []interface{}{
+			map[string]interface{}{"key": "a"},
+			map[string]interface{}{"key": "b"},
+		})
+	}
+
+	value := map[string]interface{}{
+		"name": "foo",
+		"port": 80,
+		"tags": tags(HashResource(tag)),
+	}
+
+	expected := HashResourceStable(resource)(value)
+
+	if got := HashResourceStable(resource)(value); got != expected {
+		t.Fatalf("expected deterministic hash %d, got: %d", expected, got)
+	}
+
+	extendedValue := map[string]interface{}{
+		"name":    "foo",
+		"port":    80,
+		"tags":    tags(HashResourceStable(tag)),
+		"enabled": false,
+	}
+
+	if got := HashResourceStable(extended)(extendedValue); got != expected {
+		t.Errorf("expected hash %d to be unchanged by unset attribute and nested set hash, got: %d", expected, got)
+	}
+
+	if got := HashResource(extended)(extendedValue); got == HashResource(resource)(value) {
+		t.Errorf("expected HashResource to be unaffected, got same hash: %d", got)
+	}
+
+	extendedValue["enabled"] = true
+
+	if got := HashResourceStable(extended)(extendedValue); got == expected {
+		t.Errorf("expected hash to change with set attribute, got: %d", got)
+	}
+
+	if got := HashResourceStable(resource)(nil); got != 0 {
+		t.Errorf("expected 0 when hashing nil, got: %d", got)
+	}
+}
+
 func TestHashEqual(t *testing.T) {
 	nested := &Resource{
 		Schema: map[string]*Schema{
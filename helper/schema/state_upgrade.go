@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// SimpleStateUpgrade returns a StateUpgradeFunc for the common case of a
+// schema version which only removes or renames top level attributes, such
+// as:
+//
+//	StateUpgraders: []schema.StateUpgrader{
+//	  {
+//	    Version: 0,
+//	    Type:    resourceExampleV0().CoreConfigSchema().ImpliedType(),
+//	    Upgrade: schema.SimpleStateUpgrade(
+//	      []string{"legacy_flag"},
+//	      map[string]string{"name_prefix": "prefix"},
+//	    ),
+//	  },
+//	},
+//
+// Both drop and rename name attributes as they were in the prior version,
+// so renames may be chained or swapped, such as renaming "a" to "b" and "b"
+// to "c", and a dropped attribute is not renamed. Attributes which are
+// already absent from the state are ignored, and all other attributes are
+// kept unchanged. Attributes added in the new version need no upgrade, as
+// they are null in the upgraded state. It returns an error if an attribute
+// would be renamed to one which is kept, or if two attributes would be
+// renamed to the same one.
+func SimpleStateUpgrade(drop []string, rename map[string]string) StateUpgradeFunc {
+	// Rename in a consistent order, so any error is deterministic.
+	renameFrom := make([]string, 0, len(rename))
+
+	for from := range rename {
+		renameFrom = append(renameFrom, from)
+	}
+
+	sort.Strings(renameFrom)
+
+	dropped := make(map[string]bool, len(drop))
+
+	for _, k := range drop {
+		dropped[k] = true
+	}
+
+	return func(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+		if rawState == nil {
+			return rawState, nil
+		}
+
+		upgraded := make(map[string]interface{}, len(rawState))
+
+		for k, v := range rawState {
+			if _, ok := rename[k]; ok || dropped[k] {
+				continue
+			}
+
+			upgraded[k] = v
+		}
+
+		renamedFrom := make(map[string]string, len(rename))
+
+		for _, from := range renameFrom {
+			v, ok := rawState[from]
+
+			if !ok || dropped[from] {
+				continue
+			}
+
+			to := rename[from]
+
+			if other, ok := renamedFrom[to]; ok {
+				return nil, fmt.Errorf("cannot rename attribute %q to %q, which %q is also renamed to", from, to, other)
+			}
+
+			if _, ok := upgraded[to]; ok {
+				return nil, fmt.Errorf("cannot rename attribute %q to %q, which is already in the state", from, to)
+			}
+
+			renamedFrom[to] = from
+			upgraded[to] = v
+		}
+
+		return upgraded, nil
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSimpleStateUpgrade(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		drop          []string
+		rename        map[string]string
+		rawState      map[string]interface{}
+		expected      map[string]interface{}
+		expectedError string
+	}{
+		"nil": {
+			drop:     []string{"old"},
+			rawState: nil,
+			expected: nil,
+		},
+		"drop": {
+			drop: []string{"old"},
+			rawState: map[string]interface{}{
+				"id":  "test",
+				"old": true,
+			},
+			expected: map[string]interface{}{
+				"id": "test",
+			},
+		},
+		"drop-absent": {
+			drop: []string{"old"},
+			rawState: map[string]interface{}{
+				"id": "test",
+			},
+			expected: map[string]interface{}{
+				"id": "test",
+			},
+		},
+		"rename": {
+			rename: map[string]string{"name_prefix": "prefix"},
+			rawState: map[string]interface{}{
+				"id":          "test",
+				"name_prefix": "foo",
+				"tags":        map[string]interface{}{"a": "b"},
+			},
+			expected: map[string]interface{}{
+				"id":     "test",
+				"prefix": "foo",
+				"tags":   map[string]interface{}{"a": "b"},
+			},
+		},
+		"rename-absent": {
+			rename: map[string]string{"name_prefix": "prefix"},
+			rawState: map[string]interface{}{
+				"id": "test",
+			},
+			expected: map[string]interface{}{
+				"id": "test",
+			},
+		},
+		"drop-prior-name": {
+			drop:   []string{"b"},
+			rename: map[string]string{"a": "b"},
+			rawState: map[string]interface{}{
+				"a": "foo",
+				"b": "bar",
+			},
+			expected: map[string]interface{}{
+				"b": "foo",
+			},
+		},
+		"drop-renamed": {
+			drop:   []string{"a"},
+			rename: map[string]string{"a": "b"},
+			rawState: map[string]interface{}{
+				"a": "foo",
+			},
+			expected: map[string]interface{}{},
+		},
+		"rename-chained": {
+			rename: map[string]string{"a": "b", "b": "c"},
+			rawState: map[string]interface{}{
+				"a": "1",
+				"b": "2",
+			},
+			expected: map[string]interface{}{
+				"b": "1",
+				"c": "2",
+			},
+		},
+		"rename-swapped": {
+			rename: map[string]string{"a": "b", "b": "a"},
+			rawState: map[string]interface{}{
+				"id": "test",
+				"a":  "1",
+				"b":  "2",
+			},
+			expected: map[string]interface{}{
+				"id": "test",
+				"a":  "2",
+				"b":  "1",
+			},
+		},
+		"rename-same-target": {
+			rename: map[string]string{"a": "c", "b": "c"},
+			rawState: map[string]interface{}{
+				"a": "1",
+				"b": "2",
+			},
+			expectedError: `cannot rename attribute "b" to "c", which "a" is also renamed to`,
+		},
+		"rename-existing": {
+			rename: map[string]string{"name_prefix": "prefix"},
+			rawState: map[string]interface{}{
+				"name_prefix": "foo",
+				"prefix":      "bar",
+			},
+			expectedError: `cannot rename attribute "name_prefix" to "prefix"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := SimpleStateUpgrade(testCase.drop, testCase.rename)(context.Background(), testCase.rawState, nil)
+
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Fatalf("expected error %q, got: %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSimpleStateUpgrade_unmodified(t *testing.T) {
+	t.Parallel()
+
+	rawState := map[string]interface{}{
+		"id":  "test",
+		"old": true,
+	}
+
+	if _, err := SimpleStateUpgrade([]string{"old"}, nil)(context.Background(), rawState, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, ok := rawState["old"]; !ok {
+		t.Error("expected prior state to be unmodified")
+	}
+}
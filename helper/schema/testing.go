@@ -6,8 +6,10 @@ package schema
 import (
 	"context"
 
+	"github.com/hashicorp/go-cty/cty"
 	testing "github.com/mitchellh/go-testing-interface"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -30,3 +32,107 @@ func TestResourceDataRaw(t testing.T, schema map[string]*Schema, raw map[string]
 
 	return result
 }
+
+// TestResource creates a new instance of the managed resource from the given
+// configuration, by validating, diffing, and applying it against an
+// in-memory state. This allows unit testing of resource logic, typically with
+// a mock API client, without the acceptance testing framework or Terraform
+// CLI. The meta passed to the resource functions is nil; use
+// TestResourceApply to pass a mock client or a prior state.
+//
+// The configuration must be an object conforming to the resource schema.
+// Omitted attributes are treated as null.
+//
+//	state, diags := schema.TestResource(t, resourceExample(), cty.ObjectVal(map[string]cty.Value{
+//	  "name": cty.StringVal("example"),
+//	}))
+//	schema.TestCheckInstanceStateAttr(t, state, "name", "example")
+func TestResource(t testing.T, r *Resource, config cty.Value) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+
+	return TestResourceApply(t, r, nil, config, nil)
+}
+
+// TestResourceApply plans and applies the given configuration for the managed
+// resource against the prior state, which is nil for a new instance, and
+// returns the new state. A null configuration destroys the instance. The meta
+// is passed to the resource functions as if returned by the Provider
+// ConfigureContextFunc.
+//
+// If the plan has no changes, the prior state is returned without calling any
+// resource functions. Diagnostics from validating the configuration against
+// the schema, planning, and applying are returned.
+func TestResourceApply(t testing.T, r *Resource, prior *terraform.InstanceState, config cty.Value, meta interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if config.IsNull() {
+		if prior == nil || prior.ID == "" {
+			return nil, nil
+		}
+
+		return r.Apply(ctx, prior, &terraform.InstanceDiff{Destroy: true}, meta)
+	}
+
+	schemaBlock := r.CoreConfigSchema()
+
+	config, err := schemaBlock.CoerceValue(config)
+	if err != nil {
+		return prior, diag.FromErr(err)
+	}
+
+	c := terraform.NewResourceConfigShimmed(config, schemaBlock)
+
+	if diags := r.Validate(c); diags.HasError() {
+		return prior, diags
+	}
+
+	diff, err := r.Diff(ctx, prior, c, meta)
+	if err != nil {
+		return prior, diag.FromErr(err)
+	}
+
+	if diff.Empty() {
+		return prior, nil
+	}
+
+	diff.RawConfig = config
+
+	return r.Apply(ctx, prior, diff, meta)
+}
+
+// TestCheckInstanceStateAttr marks the test as failed if the given flatmap
+// attribute key of the instance state does not have the given value.
+func TestCheckInstanceStateAttr(t testing.T, is *terraform.InstanceState, key, value string) {
+	t.Helper()
+
+	if is == nil {
+		t.Errorf("%s: expected %q, got no instance state", key, value)
+		return
+	}
+
+	v, ok := is.Attributes[key]
+	if !ok {
+		t.Errorf("%s: expected %q, attribute not found", key, value)
+		return
+	}
+
+	if v != value {
+		t.Errorf("%s: expected %q, got %q", key, value, v)
+	}
+}
+
+// TestCheckNoInstanceStateAttr marks the test as failed if the given flatmap
+// attribute key is set in the instance state.
+func TestCheckNoInstanceStateAttr(t testing.T, is *terraform.InstanceState, key string) {
+	t.Helper()
+
+	if is == nil {
+		return
+	}
+
+	if v, ok := is.Attributes[key]; ok {
+		t.Errorf("%s: expected not to be set, got %q", key, v)
+	}
+}
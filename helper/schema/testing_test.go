@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestTestResource(t *testing.T) {
+	type client struct {
+		names map[string]string
+	}
+
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"name": {
+				Type:     TypeString,
+				Required: true,
+			},
+			"zone": {
+				Type:     TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"arn": {
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+		CreateContext: func(_ context.Context, d *ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId("id-" + d.Get("name").(string))
+
+			if c, ok := meta.(*client); ok {
+				c.names[d.Id()] = d.Get("name").(string)
+			}
+
+			return diag.FromErr(d.Set("arn", "arn:"+d.Id()))
+		},
+		ReadContext: func(context.Context, *ResourceData, interface{}) diag.Diagnostics {
+			return nil
+		},
+		UpdateContext: func(_ context.Context, d *ResourceData, meta interface{}) diag.Diagnostics {
+			meta.(*client).names[d.Id()] = d.Get("name").(string)
+
+			return nil
+		},
+		DeleteContext: func(_ context.Context, d *ResourceData, meta interface{}) diag.Diagnostics {
+			delete(meta.(*client).names, d.Id())
+
+			return nil
+		},
+	}
+
+	state, diags := TestResource(t, r, cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("foo"),
+	}))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	TestCheckInstanceStateAttr(t, state, "id", "id-foo")
+	TestCheckInstanceStateAttr(t, state, "name", "foo")
+	TestCheckInstanceStateAttr(t, state, "arn", "arn:id-foo")
+	TestCheckNoInstanceStateAttr(t, state, "zone")
+
+	c := &client{names: map[string]string{"id-foo": "foo"}}
+
+	// update in-place
+	state, diags = TestResourceApply(t, r, state, cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("bar"),
+	}), c)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	TestCheckInstanceStateAttr(t, state, "id", "id-foo")
+	TestCheckInstanceStateAttr(t, state, "name", "bar")
+
+	if got := c.names["id-foo"]; got != "bar" {
+		t.Fatalf("expected client to be updated, got %q", got)
+	}
+
+	// replace
+	state, diags = TestResourceApply(t, r, state, cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("bar"),
+		"zone": cty.StringVal("z1"),
+	}), c)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	TestCheckInstanceStateAttr(t, state, "id", "id-bar")
+	TestCheckInstanceStateAttr(t, state, "zone", "z1")
+
+	if _, ok := c.names["id-foo"]; ok {
+		t.Fatal("expected replaced instance to be deleted")
+	}
+
+	// destroy
+	state, diags = TestResourceApply(t, r, state, cty.NullVal(r.CoreConfigSchema().ImpliedType()), c)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if state != nil {
+		t.Fatalf("expected no state after destroy, got: %#v", state)
+	}
+
+	if len(c.names) != 0 {
+		t.Fatalf("expected client to be empty, got: %v", c.names)
+	}
+
+	// validation
+	_, diags = TestResource(t, r, cty.ObjectVal(map[string]cty.Value{}))
+	if !diags.HasError() {
+		t.Fatal("expected validation error for missing required attribute")
+	}
+}
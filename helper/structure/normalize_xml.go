@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package structure
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Takes a value containing XML string and passes it through
+// the XML parser to normalize it, returns either a parsing
+// error or normalized XML string.
+//
+// The normalized form sorts the attributes of each element, removes
+// whitespace surrounding text, and removes comments, processing
+// instructions, and directives such as the XML declaration. Self-closing
+// elements are written with an explicit end tag. Namespace prefixes are
+// preserved as written.
+func NormalizeXmlString(xmlString interface{}) (string, error) {
+	if xmlString == nil || xmlString.(string) == "" {
+		return "", nil
+	}
+
+	s := xmlString.(string)
+
+	var b strings.Builder
+	var stack []xml.Name
+	roots := 0
+
+	d := xml.NewDecoder(strings.NewReader(s))
+
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return s, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 {
+				roots++
+				if roots > 1 {
+					return s, errors.New("multiple root elements")
+				}
+			}
+
+			stack = append(stack, t.Name)
+
+			attrs := make([]xml.Attr, len(t.Attr))
+			copy(attrs, t.Attr)
+			sort.Slice(attrs, func(i, j int) bool {
+				return xmlName(attrs[i].Name) < xmlName(attrs[j].Name)
+			})
+
+			b.WriteString("<" + xmlName(t.Name))
+			for _, a := range attrs {
+				b.WriteString(" " + xmlName(a.Name) + `="`)
+				_ = xml.EscapeText(&b, []byte(a.Value))
+				b.WriteString(`"`)
+			}
+			b.WriteString(">")
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1] != t.Name {
+				return s, fmt.Errorf("unexpected end element </%s>", xmlName(t.Name))
+			}
+
+			stack = stack[:len(stack)-1]
+
+			b.WriteString("</" + xmlName(t.Name) + ">")
+		case xml.CharData:
+			text := strings.TrimSpace(string(t))
+			if text == "" {
+				continue
+			}
+
+			if len(stack) == 0 {
+				return s, errors.New("text outside of root element")
+			}
+
+			_ = xml.EscapeText(&b, []byte(text))
+		}
+	}
+
+	if len(stack) > 0 {
+		return s, fmt.Errorf("unclosed element <%s>", xmlName(stack[len(stack)-1]))
+	}
+
+	if roots == 0 {
+		return s, errors.New("missing root element")
+	}
+
+	return b.String(), nil
+}
+
+func xmlName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+
+	return n.Space + ":" + n.Local
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package structure
+
+import (
+	"testing"
+)
+
+func TestNormalizeXmlString_valid(t *testing.T) {
+	validXml := `<?xml version="1.0" encoding="UTF-8"?>
+<!-- policy -->
+<Policy version="1" id="p">
+  <Statement effect="Allow">
+    <Action>read &amp; write</Action>
+    <Resource/>
+  </Statement>
+</Policy>`
+	expected := `<Policy id="p" version="1"><Statement effect="Allow"><Action>read &amp; write</Action><Resource></Resource></Statement></Policy>`
+
+	actual, err := NormalizeXmlString(validXml)
+	if err != nil {
+		t.Fatalf("Expected not to throw an error while parsing XML, but got: %s", err)
+	}
+
+	if actual != expected {
+		t.Fatalf("Got:\n\n%s\n\nExpected:\n\n%s\n", actual, expected)
+	}
+
+	actual, err = NormalizeXmlString("")
+	if err != nil {
+		t.Fatalf("Expected not to throw an error while parsing empty string, but got: %s", err)
+	}
+
+	if actual != "" {
+		t.Fatalf("Got:\n\n%s\n\nExpected empty string", actual)
+	}
+}
+
+func TestNormalizeXmlString_invalid(t *testing.T) {
+	invalidCases := map[string]string{
+		"unclosed":          `<a><b></b>`,
+		"mismatched":        `<a><b></a></b>`,
+		"multiple-roots":    `<a></a><b></b>`,
+		"text-outside-root": `text<a></a>`,
+		"not-xml":           `{"abc":1}`,
+		"bad-entity":        `<a>&bogus;</a>`,
+	}
+
+	for name, invalidXml := range invalidCases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := NormalizeXmlString(invalidXml)
+			if err == nil {
+				t.Fatalf("Expected to throw an error while parsing XML, but got: %s", actual)
+			}
+
+			if actual != invalidXml {
+				t.Fatalf("Got:\n\n%s\n\nExpected:\n\n%s\n", actual, invalidXml)
+			}
+		})
+	}
+}
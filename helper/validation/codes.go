@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Stable codes attached to the errors and diagnostics of the validators in
+// this package. The codes are copied into the Code of the resulting
+// diag.Diagnostic, so programmatic consumers can identify the problem without
+// matching the message text, which may change between releases.
+const (
+	// CodeInvalidType is attached when the value is not of the type the
+	// validator expects, such as a number given to a string validator.
+	CodeInvalidType = "invalid_type"
+
+	// CodeInvalidStringLength is attached when the length of a string, or of
+	// a map key or value, is outside the allowed range.
+	CodeInvalidStringLength = "invalid_string_length"
+
+	// CodeEmptyValue is attached when the value is empty or zero but must
+	// not be.
+	CodeEmptyValue = "empty_value"
+
+	// CodeNonEmptyValue is attached when the value must be empty but is not.
+	CodeNonEmptyValue = "non_empty_value"
+
+	// CodeValueOutOfRange is attached when a number is outside the allowed
+	// range.
+	CodeValueOutOfRange = "value_out_of_range"
+
+	// CodeValueNotAllowed is attached when the value is not one of the
+	// allowed values, or is one of the disallowed values.
+	CodeValueNotAllowed = "value_not_allowed"
+
+	// CodeInvalidFormat is attached when the value does not match the
+	// expected format, such as a regular expression, JSON or an IP address.
+	CodeInvalidFormat = "invalid_format"
+
+	// CodeInvalidValue is attached when the value is otherwise invalid, such
+	// as a number which is not divisible by the required divisor.
+	CodeInvalidValue = "invalid_value"
+
+	// CodeDuplicateValue is attached when a list contains duplicate elements.
+	CodeDuplicateValue = "duplicate_value"
+)
+
+// codedErrorf returns an error formatted with fmt.Errorf and given the code.
+func codedErrorf(code string, format string, a ...interface{}) error {
+	return diag.ErrorWithCode(code, fmt.Errorf(format, a...))
+}
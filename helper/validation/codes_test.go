@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestValidationCodes(t *testing.T) {
+	cases := map[string]struct {
+		f        schema.SchemaValidateDiagFunc
+		value    interface{}
+		expected string
+	}{
+		"StringLenBetween": {
+			f:        ToDiagFunc(StringLenBetween(1, 3)),
+			value:    "abcd",
+			expected: CodeInvalidStringLength,
+		},
+		"StringLenBetween type": {
+			f:        ToDiagFunc(StringLenBetween(1, 3)),
+			value:    1,
+			expected: CodeInvalidType,
+		},
+		"IntBetween": {
+			f:        ToDiagFunc(IntBetween(1, 3)),
+			value:    4,
+			expected: CodeValueOutOfRange,
+		},
+		"StringInSlice": {
+			f:        ToDiagFunc(StringInSlice([]string{"a"}, false)),
+			value:    "b",
+			expected: CodeValueNotAllowed,
+		},
+		"IsCIDR": {
+			f:        ToDiagFunc(IsCIDR),
+			value:    "10.0.0.0",
+			expected: CodeInvalidFormat,
+		},
+		"ListOfUniqueStrings": {
+			f:        ToDiagFunc(ListOfUniqueStrings),
+			value:    []interface{}{"a", "a"},
+			expected: CodeDuplicateValue,
+		},
+		"MapKeyLenBetween": {
+			f:        MapKeyLenBetween(1, 3),
+			value:    map[string]interface{}{"abcd": "a"},
+			expected: CodeInvalidStringLength,
+		},
+		"StringIsBase64Diag": {
+			f:        StringIsBase64Diag(false),
+			value:    "!",
+			expected: CodeInvalidFormat,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := tc.f(tc.value, cty.GetAttrPath("test_property"))
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, got: %#v", diags)
+			}
+
+			if diags[0].Code != tc.expected {
+				t.Fatalf("expected code %q, got: %q", tc.expected, diags[0].Code)
+			}
+		})
+	}
+}
+
+func TestValidationCodes_FromErr(t *testing.T) {
+	_, es := StringLenBetween(1, 3)("abcd", "test_property")
+
+	if len(es) != 1 {
+		t.Fatalf("expected 1 error, got: %#v", es)
+	}
+
+	diags := diag.FromErr(es[0])
+
+	if diags[0].Code != CodeInvalidStringLength {
+		t.Fatalf("expected code %q, got: %q", CodeInvalidStringLength, diags[0].Code)
+	}
+
+	if diags[0].Summary != es[0].Error() {
+		t.Fatalf("expected summary %q, got: %q", es[0].Error(), diags[0].Summary)
+	}
+}
@@ -3,11 +3,7 @@
 
 package validation
 
-import (
-	"fmt"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-)
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 // FloatBetween returns a SchemaValidateFunc which tests if the provided value
 // is of type float64 and is between minVal and maxVal (inclusive).
@@ -15,12 +11,12 @@ func FloatBetween(minVal, maxVal float64) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (s []string, es []error) {
 		v, ok := i.(float64)
 		if !ok {
-			es = append(es, fmt.Errorf("expected type of %s to be float64", k))
+			es = append(es, codedErrorf(CodeInvalidType, "expected type of %s to be float64", k))
 			return
 		}
 
 		if v < minVal || v > maxVal {
-			es = append(es, fmt.Errorf("expected %s to be in the range (%f - %f), got %f", k, minVal, maxVal, v))
+			es = append(es, codedErrorf(CodeValueOutOfRange, "expected %s to be in the range (%f - %f), got %f", k, minVal, maxVal, v))
 			return
 		}
 
@@ -34,12 +30,12 @@ func FloatAtLeast(minVal float64) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (s []string, es []error) {
 		v, ok := i.(float64)
 		if !ok {
-			es = append(es, fmt.Errorf("expected type of %s to be float", k))
+			es = append(es, codedErrorf(CodeInvalidType, "expected type of %s to be float", k))
 			return
 		}
 
 		if v < minVal {
-			es = append(es, fmt.Errorf("expected %s to be at least (%f), got %f", k, minVal, v))
+			es = append(es, codedErrorf(CodeValueOutOfRange, "expected %s to be at least (%f), got %f", k, minVal, v))
 			return
 		}
 
@@ -53,12 +49,12 @@ func FloatAtMost(maxVal float64) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (s []string, es []error) {
 		v, ok := i.(float64)
 		if !ok {
-			es = append(es, fmt.Errorf("expected type of %s to be float", k))
+			es = append(es, codedErrorf(CodeInvalidType, "expected type of %s to be float", k))
 			return
 		}
 
 		if v > maxVal {
-			es = append(es, fmt.Errorf("expected %s to be at most (%f), got %f", k, maxVal, v))
+			es = append(es, codedErrorf(CodeValueOutOfRange, "expected %s to be at most (%f), got %f", k, maxVal, v))
 			return
 		}
 
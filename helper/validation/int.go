@@ -4,7 +4,6 @@
 package validation
 
 import (
-	"fmt"
 	"math"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,12 +15,12 @@ func IntBetween(minVal, maxVal int) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(int)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be integer", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be integer", k))
 			return warnings, errors
 		}
 
 		if v < minVal || v > maxVal {
-			errors = append(errors, fmt.Errorf("expected %s to be in the range (%d - %d), got %d", k, minVal, maxVal, v))
+			errors = append(errors, codedErrorf(CodeValueOutOfRange, "expected %s to be in the range (%d - %d), got %d", k, minVal, maxVal, v))
 			return warnings, errors
 		}
 
@@ -35,12 +34,12 @@ func IntAtLeast(minVal int) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(int)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be integer", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be integer", k))
 			return warnings, errors
 		}
 
 		if v < minVal {
-			errors = append(errors, fmt.Errorf("expected %s to be at least (%d), got %d", k, minVal, v))
+			errors = append(errors, codedErrorf(CodeValueOutOfRange, "expected %s to be at least (%d), got %d", k, minVal, v))
 			return warnings, errors
 		}
 
@@ -54,12 +53,12 @@ func IntAtMost(maxVal int) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(int)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be integer", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be integer", k))
 			return warnings, errors
 		}
 
 		if v > maxVal {
-			errors = append(errors, fmt.Errorf("expected %s to be at most (%d), got %d", k, maxVal, v))
+			errors = append(errors, codedErrorf(CodeValueOutOfRange, "expected %s to be at most (%d), got %d", k, maxVal, v))
 			return warnings, errors
 		}
 
@@ -73,12 +72,12 @@ func IntDivisibleBy(divisor int) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(int)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be integer", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be integer", k))
 			return warnings, errors
 		}
 
 		if math.Mod(float64(v), float64(divisor)) != 0 {
-			errors = append(errors, fmt.Errorf("expected %s to be divisible by %d, got: %v", k, divisor, i))
+			errors = append(errors, codedErrorf(CodeInvalidValue, "expected %s to be divisible by %d, got: %v", k, divisor, i))
 			return warnings, errors
 		}
 
@@ -92,7 +91,7 @@ func IntInSlice(valid []int) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(int)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be integer", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be integer", k))
 			return warnings, errors
 		}
 
@@ -102,7 +101,7 @@ func IntInSlice(valid []int) schema.SchemaValidateFunc {
 			}
 		}
 
-		errors = append(errors, fmt.Errorf("expected %s to be one of %v, got %d", k, valid, v))
+		errors = append(errors, codedErrorf(CodeValueNotAllowed, "expected %s to be one of %v, got %d", k, valid, v))
 		return warnings, errors
 	}
 }
@@ -113,13 +112,13 @@ func IntNotInSlice(valid []int) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(int)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be integer", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be integer", k))
 			return warnings, errors
 		}
 
 		for _, validInt := range valid {
 			if v == validInt {
-				errors = append(errors, fmt.Errorf("expected %s to not be one of %v, got %d", k, valid, v))
+				errors = append(errors, codedErrorf(CodeValueNotAllowed, "expected %s to not be one of %v, got %d", k, valid, v))
 			}
 		}
 
@@ -28,6 +28,7 @@ func MapKeyLenBetween(minVal, maxVal int) schema.SchemaValidateDiagFunc {
 					Summary:       "Bad map key length",
 					Detail:        fmt.Sprintf("Map key lengths should be in the range (%d - %d): %s (length = %d)", minVal, maxVal, key, keyLen),
 					AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(key)}),
+					Code:          CodeInvalidStringLength,
 				})
 			}
 		}
@@ -53,6 +54,7 @@ func MapValueLenBetween(minVal, maxVal int) schema.SchemaValidateDiagFunc {
 					Summary:       "Bad map value type",
 					Detail:        fmt.Sprintf("Map values should be strings: %s => %v (type = %T)", key, val, val),
 					AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(key)}),
+					Code:          CodeInvalidType,
 				})
 				continue
 			}
@@ -64,6 +66,7 @@ func MapValueLenBetween(minVal, maxVal int) schema.SchemaValidateDiagFunc {
 					Summary:       "Bad map value length",
 					Detail:        fmt.Sprintf("Map value lengths should be in the range (%d - %d): %s => %v (length = %d)", minVal, maxVal, key, val, valLen),
 					AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(key)}),
+					Code:          CodeInvalidStringLength,
 				})
 			}
 		}
@@ -93,6 +96,7 @@ func MapKeyMatch(r *regexp.Regexp, message string) schema.SchemaValidateDiagFunc
 					Summary:       "Invalid map key",
 					Detail:        detail,
 					AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(key)}),
+					Code:          CodeInvalidFormat,
 				})
 			}
 		}
@@ -119,6 +123,7 @@ func MapValueMatch(r *regexp.Regexp, message string) schema.SchemaValidateDiagFu
 					Summary:       "Bad map value type",
 					Detail:        fmt.Sprintf("Map values should be strings: %s => %v (type = %T)", key, val, val),
 					AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(key)}),
+					Code:          CodeInvalidType,
 				})
 				continue
 			}
@@ -136,6 +141,7 @@ func MapValueMatch(r *regexp.Regexp, message string) schema.SchemaValidateDiagFu
 					Summary:       "Invalid map value",
 					Detail:        detail,
 					AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(key)}),
+					Code:          CodeInvalidFormat,
 				})
 			}
 		}
@@ -19,9 +19,9 @@ func NoZeroValues(i interface{}, k string) (s []string, es []error) {
 	if reflect.ValueOf(i).Interface() == reflect.Zero(reflect.TypeOf(i)).Interface() {
 		switch reflect.TypeOf(i).Kind() {
 		case reflect.String:
-			es = append(es, fmt.Errorf("%s must not be empty, got %v", k, i))
+			es = append(es, codedErrorf(CodeEmptyValue, "%s must not be empty, got %v", k, i))
 		case reflect.Int, reflect.Float64:
-			es = append(es, fmt.Errorf("%s must not be zero, got %v", k, i))
+			es = append(es, codedErrorf(CodeEmptyValue, "%s must not be zero, got %v", k, i))
 		default:
 			// this validator should only ever be applied to TypeString, TypeInt and TypeFloat
 			panic(fmt.Errorf("can't use NoZeroValues with %T attribute %s", i, k))
@@ -58,15 +58,17 @@ func AllDiag(validators ...schema.SchemaValidateDiagFunc) schema.SchemaValidateD
 }
 
 // Any returns a SchemaValidateFunc which tests if the provided value
-// passes any of the provided SchemaValidateFunc
+// passes any of the provided SchemaValidateFunc. A validator passes if it
+// returns no errors, in which case only its warnings are returned. Otherwise
+// the warnings and errors of all validators are returned.
 func Any(validators ...schema.SchemaValidateFunc) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) ([]string, []error) {
 		var allErrors []error
 		var allWarnings []string
 		for _, validator := range validators {
 			validatorWarnings, validatorErrors := validator(i, k)
-			if len(validatorWarnings) == 0 && len(validatorErrors) == 0 {
-				return []string{}, []error{}
+			if len(validatorErrors) == 0 {
+				return append([]string{}, validatorWarnings...), []error{}
 			}
 			allWarnings = append(allWarnings, validatorWarnings...)
 			allErrors = append(allErrors, validatorErrors...)
@@ -76,14 +78,16 @@ func Any(validators ...schema.SchemaValidateFunc) schema.SchemaValidateFunc {
 }
 
 // AnyDiag returns a SchemaValidateDiagFunc which tests if the provided value
-// passes any of the provided SchemaValidateDiagFunc
+// passes any of the provided SchemaValidateDiagFunc. A validator passes if it
+// returns no error diagnostics, in which case only its warning diagnostics
+// are returned. Otherwise the diagnostics of all validators are returned.
 func AnyDiag(validators ...schema.SchemaValidateDiagFunc) schema.SchemaValidateDiagFunc {
 	return func(i interface{}, k cty.Path) diag.Diagnostics {
 		var diags diag.Diagnostics
 		for _, validator := range validators {
 			validatorDiags := validator(i, k)
-			if len(validatorDiags) == 0 {
-				return diag.Diagnostics{}
+			if !validatorDiags.HasError() {
+				return append(diag.Diagnostics{}, validatorDiags...)
 			}
 			diags = append(diags, validatorDiags...)
 		}
@@ -125,6 +129,7 @@ func ToDiagFunc(validator schema.SchemaValidateFunc) schema.SchemaValidateDiagFu
 				Severity:      diag.Error,
 				Summary:       e.Error(),
 				AttributePath: p,
+				Code:          diag.ErrorCode(e),
 			})
 		}
 		return diags
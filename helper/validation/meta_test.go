@@ -4,10 +4,12 @@
 package validation
 
 import (
+	"errors"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -169,6 +171,53 @@ func TestValidationAnyDiag(t *testing.T) {
 	})
 }
 
+func TestValidationAny_warnings(t *testing.T) {
+	t.Parallel()
+
+	failing := func(i interface{}, k string) ([]string, []error) {
+		return []string{"failing warning"}, []error{errors.New("failing error")}
+	}
+	warning := func(i interface{}, k string) ([]string, []error) {
+		return []string{"passing warning"}, nil
+	}
+
+	warnings, errs := Any(failing, warning)(7, "test_property")
+
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %v", errs)
+	}
+
+	if len(warnings) != 1 || warnings[0] != "passing warning" {
+		t.Fatalf("expected only the passing warning, got: %v", warnings)
+	}
+}
+
+func TestValidationAnyDiag_warnings(t *testing.T) {
+	t.Parallel()
+
+	failing := func(i interface{}, path cty.Path) diag.Diagnostics {
+		return diag.Diagnostics{
+			{Severity: diag.Warning, Summary: "failing warning"},
+			{Severity: diag.Error, Summary: "failing error"},
+		}
+	}
+	warning := func(i interface{}, path cty.Path) diag.Diagnostics {
+		return diag.Diagnostics{
+			{Severity: diag.Warning, Summary: "passing warning"},
+		}
+	}
+
+	diags := AnyDiag(failing, warning)(7, cty.GetAttrPath("test_property"))
+
+	if diags.HasError() {
+		t.Fatalf("expected no errors, got: %v", diags)
+	}
+
+	if len(diags) != 1 || diags[0].Summary != "passing warning" {
+		t.Fatalf("expected only the passing warning, got: %v", diags)
+	}
+}
+
 func TestToDiagFunc(t *testing.T) {
 	t.Parallel()
 
@@ -5,7 +5,6 @@ package validation
 
 import (
 	"bytes"
-	"fmt"
 	"net"
 	"strings"
 
@@ -16,13 +15,13 @@ import (
 func IsIPAddress(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %q to be string", k))
 		return warnings, errors
 	}
 
 	ip := net.ParseIP(v)
 	if ip == nil {
-		errors = append(errors, fmt.Errorf("expected %s to contain a valid IP, got: %s", k, v))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %s to contain a valid IP, got: %s", k, v))
 	}
 
 	return warnings, errors
@@ -32,13 +31,13 @@ func IsIPAddress(i interface{}, k string) (warnings []string, errors []error) {
 func IsIPv6Address(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %q to be string", k))
 		return warnings, errors
 	}
 
 	ip := net.ParseIP(v)
 	if six := ip.To16(); six == nil {
-		errors = append(errors, fmt.Errorf("expected %s to contain a valid IPv6 address, got: %s", k, v))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %s to contain a valid IPv6 address, got: %s", k, v))
 	}
 
 	return warnings, errors
@@ -48,13 +47,13 @@ func IsIPv6Address(i interface{}, k string) (warnings []string, errors []error)
 func IsIPv4Address(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %q to be string", k))
 		return warnings, errors
 	}
 
 	ip := net.ParseIP(v)
 	if four := ip.To4(); four == nil {
-		errors = append(errors, fmt.Errorf("expected %s to contain a valid IPv4 address, got: %s", k, v))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %s to contain a valid IPv4 address, got: %s", k, v))
 	}
 
 	return warnings, errors
@@ -64,20 +63,20 @@ func IsIPv4Address(i interface{}, k string) (warnings []string, errors []error)
 func IsIPv4Range(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
 		return warnings, errors
 	}
 
 	ips := strings.Split(v, "-")
 	if len(ips) != 2 {
-		errors = append(errors, fmt.Errorf("expected %s to contain a valid IP range, got: %s", k, v))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %s to contain a valid IP range, got: %s", k, v))
 		return warnings, errors
 	}
 
 	ip1 := net.ParseIP(ips[0])
 	ip2 := net.ParseIP(ips[1])
 	if ip1 == nil || ip2 == nil || bytes.Compare(ip1, ip2) > 0 {
-		errors = append(errors, fmt.Errorf("expected %s to contain a valid IP range, got: %s", k, v))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %s to contain a valid IP range, got: %s", k, v))
 	}
 
 	return warnings, errors
@@ -87,41 +86,42 @@ func IsIPv4Range(i interface{}, k string) (warnings []string, errors []error) {
 func IsCIDR(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
 		return warnings, errors
 	}
 
 	if _, _, err := net.ParseCIDR(v); err != nil {
-		errors = append(errors, fmt.Errorf("expected %q to be a valid CIDR Value, got %v: %v", k, i, err))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %q to be a valid CIDR Value, got %v: %v", k, i, err))
 	}
 
 	return warnings, errors
 }
 
 // IsCIDRNetwork returns a SchemaValidateFunc which tests if the provided value
-// is of type string, is in valid Value network notation, and has significant bits between minVal and maxVal (inclusive)
+// is of type string, is in valid CIDR network notation without host bits set,
+// and has significant bits between minVal and maxVal (inclusive)
 func IsCIDRNetwork(minVal, maxVal int) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(string)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
 			return warnings, errors
 		}
 
 		_, ipnet, err := net.ParseCIDR(v)
 		if err != nil {
-			errors = append(errors, fmt.Errorf("expected %s to contain a valid Value, got: %s with err: %s", k, v, err))
+			errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %s to contain a valid CIDR, got: %s with err: %s", k, v, err))
 			return warnings, errors
 		}
 
 		if ipnet == nil || v != ipnet.String() {
-			errors = append(errors, fmt.Errorf("expected %s to contain a valid network Value, expected %s, got %s",
+			errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %s to contain a valid network CIDR, expected %s, got %s",
 				k, ipnet, v))
 		}
 
 		sigbits, _ := ipnet.Mask.Size()
 		if sigbits < minVal || sigbits > maxVal {
-			errors = append(errors, fmt.Errorf("expected %q to contain a network Value with between %d and %d significant bits, got: %d", k, minVal, maxVal, sigbits))
+			errors = append(errors, codedErrorf(CodeValueOutOfRange, "expected %q to contain a network CIDR with between %d and %d significant bits, got: %s", k, minVal, maxVal, v))
 		}
 
 		return warnings, errors
@@ -132,12 +132,12 @@ func IsCIDRNetwork(minVal, maxVal int) schema.SchemaValidateFunc {
 func IsMACAddress(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %q to be string", k))
 		return warnings, errors
 	}
 
 	if _, err := net.ParseMAC(v); err != nil {
-		errors = append(errors, fmt.Errorf("expected %q to be a valid MAC address, got %v: %v", k, i, err))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %q to be a valid MAC address, got %v: %v", k, i, err))
 	}
 
 	return warnings, errors
@@ -147,12 +147,12 @@ func IsMACAddress(i interface{}, k string) (warnings []string, errors []error) {
 func IsPortNumber(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(int)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %q to be integer", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %q to be integer", k))
 		return warnings, errors
 	}
 
 	if 1 > v || v > 65535 {
-		errors = append(errors, fmt.Errorf("expected %q to be a valid port number, got: %v", k, v))
+		errors = append(errors, codedErrorf(CodeValueOutOfRange, "expected %q to be a valid port number, got: %v", k, v))
 	}
 
 	return warnings, errors
@@ -162,12 +162,12 @@ func IsPortNumber(i interface{}, k string) (warnings []string, errors []error) {
 func IsPortNumberOrZero(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(int)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %q to be integer", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %q to be integer", k))
 		return warnings, errors
 	}
 
 	if 0 > v || v > 65535 {
-		errors = append(errors, fmt.Errorf("expected %q to be a valid port number or 0, got: %v", k, v))
+		errors = append(errors, codedErrorf(CodeValueOutOfRange, "expected %q to be a valid port number or 0, got: %v", k, v))
 	}
 
 	return warnings, errors
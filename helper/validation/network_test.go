@@ -307,6 +307,54 @@ func TestValidateIsCIDR(t *testing.T) {
 	}
 }
 
+func TestValidateIsCIDRNetwork(t *testing.T) {
+	cases := map[string]struct {
+		Value interface{}
+		Error bool
+	}{
+		"NotString": {
+			Value: 777,
+			Error: true,
+		},
+		"Empty": {
+			Value: "",
+			Error: true,
+		},
+		"Network": {
+			Value: "10.0.0.0/16",
+			Error: false,
+		},
+		"HostBitsSet": {
+			Value: "10.0.0.1/16",
+			Error: true,
+		},
+		"TooFewBits": {
+			Value: "10.0.0.0/8",
+			Error: true,
+		},
+		"TooManyBits": {
+			Value: "10.0.0.0/28",
+			Error: true,
+		},
+		"IPv6Network": {
+			Value: "2001:d00::/24",
+			Error: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			_, errors := IsCIDRNetwork(12, 24)(tc.Value, tn)
+
+			if len(errors) > 0 && !tc.Error {
+				t.Errorf("IsCIDRNetwork(%s) produced an unexpected error", tc.Value)
+			} else if len(errors) == 0 && tc.Error {
+				t.Errorf("IsCIDRNetwork(%s) did not error", tc.Value)
+			}
+		})
+	}
+}
+
 func TestValidationIsMACAddress(t *testing.T) {
 	cases := map[string]struct {
 		Value interface{}
@@ -9,19 +9,23 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/internal/helper/base64encoding"
 )
 
 // StringIsNotEmpty is a ValidateFunc that ensures a string is not empty
 func StringIsNotEmpty(i interface{}, k string) ([]string, []error) {
 	v, ok := i.(string)
 	if !ok {
-		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+		return nil, []error{codedErrorf(CodeInvalidType, "expected type of %q to be string", k)}
 	}
 
 	if v == "" {
-		return nil, []error{fmt.Errorf("expected %q to not be an empty string, got %v", k, i)}
+		return nil, []error{codedErrorf(CodeEmptyValue, "expected %q to not be an empty string, got %v", k, i)}
 	}
 
 	return nil, nil
@@ -31,11 +35,11 @@ func StringIsNotEmpty(i interface{}, k string) ([]string, []error) {
 func StringIsNotWhiteSpace(i interface{}, k string) ([]string, []error) {
 	v, ok := i.(string)
 	if !ok {
-		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+		return nil, []error{codedErrorf(CodeInvalidType, "expected type of %q to be string", k)}
 	}
 
 	if strings.TrimSpace(v) == "" {
-		return nil, []error{fmt.Errorf("expected %q to not be an empty string or whitespace", k)}
+		return nil, []error{codedErrorf(CodeEmptyValue, "expected %q to not be an empty string or whitespace", k)}
 	}
 
 	return nil, nil
@@ -45,11 +49,11 @@ func StringIsNotWhiteSpace(i interface{}, k string) ([]string, []error) {
 func StringIsEmpty(i interface{}, k string) ([]string, []error) {
 	v, ok := i.(string)
 	if !ok {
-		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+		return nil, []error{codedErrorf(CodeInvalidType, "expected type of %q to be string", k)}
 	}
 
 	if v != "" {
-		return nil, []error{fmt.Errorf("expected %q to be an empty string: got %v", k, v)}
+		return nil, []error{codedErrorf(CodeNonEmptyValue, "expected %q to be an empty string: got %v", k, v)}
 	}
 
 	return nil, nil
@@ -59,11 +63,11 @@ func StringIsEmpty(i interface{}, k string) ([]string, []error) {
 func StringIsWhiteSpace(i interface{}, k string) ([]string, []error) {
 	v, ok := i.(string)
 	if !ok {
-		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+		return nil, []error{codedErrorf(CodeInvalidType, "expected type of %q to be string", k)}
 	}
 
 	if strings.TrimSpace(v) != "" {
-		return nil, []error{fmt.Errorf("expected %q to be an empty string or whitespace: got %v", k, v)}
+		return nil, []error{codedErrorf(CodeNonEmptyValue, "expected %q to be an empty string or whitespace: got %v", k, v)}
 	}
 
 	return nil, nil
@@ -75,12 +79,12 @@ func StringLenBetween(minVal, maxVal int) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(string)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
 			return warnings, errors
 		}
 
 		if len(v) < minVal || len(v) > maxVal {
-			errors = append(errors, fmt.Errorf("expected length of %s to be in the range (%d - %d), got %s", k, minVal, maxVal, v))
+			errors = append(errors, codedErrorf(CodeInvalidStringLength, "expected length of %s to be in the range (%d - %d), got %s", k, minVal, maxVal, v))
 		}
 
 		return warnings, errors
@@ -94,15 +98,15 @@ func StringMatch(r *regexp.Regexp, message string) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) ([]string, []error) {
 		v, ok := i.(string)
 		if !ok {
-			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+			return nil, []error{codedErrorf(CodeInvalidType, "expected type of %s to be string", k)}
 		}
 
 		if ok := r.MatchString(v); !ok {
 			if message != "" {
-				return nil, []error{fmt.Errorf("invalid value for %s (%s)", k, message)}
+				return nil, []error{codedErrorf(CodeInvalidFormat, "invalid value for %s (%s)", k, message)}
 
 			}
-			return nil, []error{fmt.Errorf("expected value of %s to match regular expression %q, got %v", k, r, i)}
+			return nil, []error{codedErrorf(CodeInvalidFormat, "expected value of %s to match regular expression %q, got %v", k, r, i)}
 		}
 		return nil, nil
 	}
@@ -115,15 +119,15 @@ func StringDoesNotMatch(r *regexp.Regexp, message string) schema.SchemaValidateF
 	return func(i interface{}, k string) ([]string, []error) {
 		v, ok := i.(string)
 		if !ok {
-			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+			return nil, []error{codedErrorf(CodeInvalidType, "expected type of %s to be string", k)}
 		}
 
 		if ok := r.MatchString(v); ok {
 			if message != "" {
-				return nil, []error{fmt.Errorf("invalid value for %s (%s)", k, message)}
+				return nil, []error{codedErrorf(CodeInvalidFormat, "invalid value for %s (%s)", k, message)}
 
 			}
-			return nil, []error{fmt.Errorf("expected value of %s to not match regular expression %q, got %v", k, r, i)}
+			return nil, []error{codedErrorf(CodeInvalidFormat, "expected value of %s to not match regular expression %q, got %v", k, r, i)}
 		}
 		return nil, nil
 	}
@@ -136,7 +140,7 @@ func StringInSlice(valid []string, ignoreCase bool) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(string)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
 			return warnings, errors
 		}
 
@@ -146,7 +150,7 @@ func StringInSlice(valid []string, ignoreCase bool) schema.SchemaValidateFunc {
 			}
 		}
 
-		errors = append(errors, fmt.Errorf("expected %s to be one of %q, got %s", k, valid, v))
+		errors = append(errors, codedErrorf(CodeValueNotAllowed, "expected %s to be one of %q, got %s", k, valid, v))
 		return warnings, errors
 	}
 }
@@ -158,13 +162,13 @@ func StringNotInSlice(invalid []string, ignoreCase bool) schema.SchemaValidateFu
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(string)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
 			return warnings, errors
 		}
 
 		for _, str := range invalid {
 			if v == str || (ignoreCase && strings.EqualFold(v, str)) {
-				errors = append(errors, fmt.Errorf("expected %s to not be any of %v, got %s", k, invalid, v))
+				errors = append(errors, codedErrorf(CodeValueNotAllowed, "expected %s to not be any of %v, got %s", k, invalid, v))
 				return warnings, errors
 			}
 		}
@@ -179,12 +183,12 @@ func StringDoesNotContainAny(chars string) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(string)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
 			return warnings, errors
 		}
 
 		if strings.ContainsAny(v, chars) {
-			errors = append(errors, fmt.Errorf("expected value of %s to not contain any of %q, got %v", k, chars, i))
+			errors = append(errors, codedErrorf(CodeValueNotAllowed, "expected value of %s to not contain any of %q, got %v", k, chars, i))
 			return warnings, errors
 		}
 
@@ -203,22 +207,76 @@ func StringIsBase64(i interface{}, k string) (warnings []string, errors []error)
 	v, _ := i.(string)
 
 	if _, err := base64.StdEncoding.DecodeString(v); err != nil {
-		errors = append(errors, fmt.Errorf("expected %q to be a base64 string, got %v", k, v))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %q to be a base64 string, got %v", k, v))
 	}
 
 	return warnings, errors
 }
 
+// StringIsBase64Diag returns a SchemaValidateDiagFunc which tests if the
+// provided value is of type string and decodes cleanly as base64, reporting the
+// byte offset of any invalid input. Both padded and unpadded input is accepted.
+// If allowURLEncoding is true, the URL-safe alphabet ("-" and "_" instead of
+// "+" and "/") is also accepted, but the alphabets cannot be mixed.
+//
+// Unlike StringIsBase64, an empty string is considered valid, as it decodes to
+// no data. Use Required or StringIsNotEmpty to disallow empty values.
+func StringIsBase64Diag(allowURLEncoding bool) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		v, ok := i.(string)
+		if !ok {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Bad value type",
+					Detail:        fmt.Sprintf("Expected value to be string, got %T", i),
+					AttributePath: path,
+					Code:          CodeInvalidType,
+				},
+			}
+		}
+
+		if _, err := base64encoding.For(v, allowURLEncoding).DecodeString(v); err != nil {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Invalid base64 value",
+					Detail:        fmt.Sprintf("Expected value to be a base64 string: %s", err),
+					AttributePath: path,
+					Code:          CodeInvalidFormat,
+				},
+			}
+		}
+
+		return nil
+	}
+}
+
 // StringIsJSON is a SchemaValidateFunc which tests to make sure the supplied string is valid JSON.
 func StringIsJSON(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
 		return warnings, errors
 	}
 
 	if _, err := structure.NormalizeJsonString(v); err != nil {
-		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "%q contains an invalid JSON: %s", k, err))
+	}
+
+	return warnings, errors
+}
+
+// StringIsXML is a SchemaValidateFunc which tests to make sure the supplied string is well-formed XML.
+func StringIsXML(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
+		return warnings, errors
+	}
+
+	if _, err := structure.NormalizeXmlString(v); err != nil {
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "%q contains an invalid XML: %s", k, err))
 	}
 
 	return warnings, errors
@@ -228,12 +286,12 @@ func StringIsJSON(i interface{}, k string) (warnings []string, errors []error) {
 func StringIsValidRegExp(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %s to be string", k))
 		return warnings, errors
 	}
 
 	if _, err := regexp.Compile(v); err != nil {
-		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "%q: %s", k, err))
 	}
 
 	return warnings, errors
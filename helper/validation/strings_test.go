@@ -5,7 +5,10 @@ package validation
 
 import (
 	"regexp"
+	"strings"
 	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
 )
 
 func TestValidationStringIsNotEmpty(t *testing.T) {
@@ -279,6 +282,65 @@ func TestValidationStringIsBase64(t *testing.T) {
 	}
 }
 
+func TestValidationStringIsBase64Diag(t *testing.T) {
+	runDiagTestCases(t, []diagTestCase{
+		{
+			val: "RG8naCE=",
+			f:   StringIsBase64Diag(false),
+		},
+		// unpadded
+		{
+			val: "RG8naCE",
+			f:   StringIsBase64Diag(false),
+		},
+		// empty decodes to no data
+		{
+			val: "",
+			f:   StringIsBase64Diag(false),
+		},
+		{
+			val: "-_8=",
+			f:   StringIsBase64Diag(true),
+		},
+		{
+			val: "-_8",
+			f:   StringIsBase64Diag(true),
+		},
+		{
+			val:                 "-_8=",
+			f:                   StringIsBase64Diag(false),
+			expectedDiagSummary: regexp.MustCompile("Invalid base64 value"),
+		},
+		// mixed alphabets
+		{
+			val:                 "+_8=",
+			f:                   StringIsBase64Diag(true),
+			expectedDiagSummary: regexp.MustCompile("Invalid base64 value"),
+		},
+		{
+			val:                 "Do'h!",
+			f:                   StringIsBase64Diag(false),
+			expectedDiagSummary: regexp.MustCompile("Invalid base64 value"),
+		},
+		// incorrect padding
+		{
+			val:                 "RG8naCE==",
+			f:                   StringIsBase64Diag(false),
+			expectedDiagSummary: regexp.MustCompile("Invalid base64 value"),
+		},
+		{
+			val:                 7,
+			f:                   StringIsBase64Diag(false),
+			expectedDiagSummary: regexp.MustCompile("Bad value type"),
+		},
+	})
+
+	diags := StringIsBase64Diag(false)("RG8n*CE=", cty.GetAttrPath("test_property"))
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "input byte 4") {
+		t.Fatalf("expected diagnostic reporting byte offset 4, got %v", diags)
+	}
+}
+
 func TestValidationStringInSlice(t *testing.T) {
 	runTestCases(t, []testCase{
 		{
@@ -431,6 +493,61 @@ func TestStringIsJSON(t *testing.T) {
 	}
 }
 
+func TestStringIsXML(t *testing.T) {
+	type testCases struct {
+		Value    string
+		ErrCount int
+	}
+
+	invalidCases := []testCases{
+		{
+			Value:    `<a>`,
+			ErrCount: 1,
+		},
+		{
+			Value:    `<a></b>`,
+			ErrCount: 1,
+		},
+		{
+			Value:    `<a></a><b></b>`,
+			ErrCount: 1,
+		},
+		{
+			Value:    `{"abc":1}`,
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range invalidCases {
+		_, errors := StringIsXML(tc.Value, "xml")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %q to trigger a validation error.", tc.Value)
+		}
+	}
+
+	validCases := []testCases{
+		{
+			Value:    ``,
+			ErrCount: 0,
+		},
+		{
+			Value:    `<a/>`,
+			ErrCount: 0,
+		},
+		{
+			Value:    `<?xml version="1.0"?><a b="1"><c>text</c></a>`,
+			ErrCount: 0,
+		},
+	}
+
+	for _, tc := range validCases {
+		_, errors := StringIsXML(tc.Value, "xml")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %q not to trigger a validation error.", tc.Value)
+		}
+	}
+}
+
 func TestStringDoesNotContainAny(t *testing.T) {
 	chars := "|:/"
 
@@ -4,7 +4,6 @@
 package validation
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -45,12 +44,12 @@ func IsMonth(ignoreCase bool) schema.SchemaValidateFunc {
 func IsRFC3339Time(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
 	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %q to be string", k))
 		return warnings, errors
 	}
 
 	if _, err := time.Parse(time.RFC3339, v); err != nil {
-		errors = append(errors, fmt.Errorf("expected %q to be a valid RFC3339 date, got %q: %+v", k, i, err))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %q to be a valid RFC3339 date, got %q: %+v", k, i, err))
 	}
 
 	return warnings, errors
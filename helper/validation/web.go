@@ -4,7 +4,6 @@
 package validation
 
 import (
-	"fmt"
 	"net/url"
 	"strings"
 
@@ -26,23 +25,23 @@ func IsURLWithScheme(validSchemes []string) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (_ []string, errors []error) {
 		v, ok := i.(string)
 		if !ok {
-			errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+			errors = append(errors, codedErrorf(CodeInvalidType, "expected type of %q to be string", k))
 			return
 		}
 
 		if v == "" {
-			errors = append(errors, fmt.Errorf("expected %q url to not be empty, got %v", k, i))
+			errors = append(errors, codedErrorf(CodeEmptyValue, "expected %q url to not be empty, got %v", k, i))
 			return
 		}
 
 		u, err := url.Parse(v)
 		if err != nil {
-			errors = append(errors, fmt.Errorf("expected %q to be a valid url, got %v: %+v", k, v, err))
+			errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %q to be a valid url, got %v: %+v", k, v, err))
 			return
 		}
 
 		if u.Host == "" {
-			errors = append(errors, fmt.Errorf("expected %q to have a host, got %v", k, v))
+			errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %q to have a host, got %v", k, v))
 			return
 		}
 
@@ -52,7 +51,7 @@ func IsURLWithScheme(validSchemes []string) schema.SchemaValidateFunc {
 			}
 		}
 
-		errors = append(errors, fmt.Errorf("expected %q to have a url with schema of: %q, got %v", k, strings.Join(validSchemes, ","), v))
+		errors = append(errors, codedErrorf(CodeInvalidFormat, "expected %q to have a url with schema of: %q, got %v", k, strings.Join(validSchemes, ","), v))
 		return
 	}
 }
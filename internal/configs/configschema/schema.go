@@ -44,6 +44,14 @@ type Block struct {
 	// Deprecated indicates whether the block has been marked as deprecated in the
 	// provider and usage should be discouraged.
 	Deprecated bool
+
+	// SupersededBy is the path of the attribute which replaces the deprecated
+	// block, if any.
+	SupersededBy string
+
+	// UpdateCost is the relative hint of how expensive an in-place update of
+	// the block is, or zero if no hint is given.
+	UpdateCost int
 }
 
 // Attribute represents a configuration attribute, within a block.
@@ -83,6 +91,14 @@ type Attribute struct {
 	// Deprecated indicates whether the attribute has been marked as deprecated in the
 	// provider and usage should be discouraged.
 	Deprecated bool
+
+	// SupersededBy is the path of the attribute which replaces the deprecated
+	// attribute, if any.
+	SupersededBy string
+
+	// UpdateCost is the relative hint of how expensive an in-place update of
+	// the attribute is, or zero if no hint is given.
+	UpdateCost int
 }
 
 // NestedBlock represents the embedding of one block within another.
@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package base64encoding
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// For returns the base64 encoding matching the padding and, if
+// allowURLEncoding is true, the alphabet of v.
+func For(v string, allowURLEncoding bool) *base64.Encoding {
+	padded := strings.HasSuffix(v, "=")
+
+	if allowURLEncoding && strings.ContainsAny(v, "-_") {
+		if padded {
+			return base64.URLEncoding
+		}
+
+		return base64.RawURLEncoding
+	}
+
+	if padded {
+		return base64.StdEncoding
+	}
+
+	return base64.RawStdEncoding
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package base64encoding
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestFor(t *testing.T) {
+	cases := []struct {
+		Value            string
+		AllowURLEncoding bool
+		Expected         *base64.Encoding
+	}{
+		{"RG8naCE=", false, base64.StdEncoding},
+		{"RG8naCE", false, base64.RawStdEncoding},
+		{"-_8=", false, base64.StdEncoding},
+		{"-_8", false, base64.RawStdEncoding},
+		{"RG8naCE=", true, base64.StdEncoding},
+		{"-_8=", true, base64.URLEncoding},
+		{"-_8", true, base64.RawURLEncoding},
+	}
+
+	for _, tc := range cases {
+		if got := For(tc.Value, tc.AllowURLEncoding); got != tc.Expected {
+			t.Errorf("For(%q, %t): unexpected encoding", tc.Value, tc.AllowURLEncoding)
+		}
+	}
+}
@@ -49,6 +49,11 @@ type ServeOpts struct {
 	// added to the GRPC functions when possible.
 	GRPCProviderFunc GRPCProviderFunc
 
+	// GRPCProviderV6Func serves the provider over protocol version 6. To
+	// serve a schema.Provider over protocol version 6, such as to mux it with
+	// providers which require version 6, upgrade its GRPCProviderServer with
+	// the tf5to6server package of terraform-plugin-mux, which returns an
+	// error for any schema which cannot be expressed in version 6.
 	GRPCProviderV6Func GRPCProviderV6Func
 
 	// Logger is the logger that go-plugin will use.